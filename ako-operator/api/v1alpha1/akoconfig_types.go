@@ -66,6 +66,8 @@ type AKOSettings struct {
 	Layer7Only bool `json:"layer7Only,omitempty"`
 	// ServicesAPI enables AKO to do Layer 4 loadbalancing using Services API
 	ServicesAPI bool `json:"servicesAPI,omitempty"`
+	// EnableGatewayAPI enables AKO to process the gateway.networking.k8s.io GatewayClass, Gateway and Route objects
+	EnableGatewayAPI bool `json:"enableGatewayAPI,omitempty"`
 	// VipPerNamespace enables AKO to create Parent VS per Namespace in EVH mode
 	VipPerNamespace bool `json:"vipPerNamespace,omitempty"`
 	// IstioEnabled flag needs to be enabled when AKO is be to brought up in an Istio environment
@@ -74,6 +76,22 @@ type AKOSettings struct {
 	BlockedNamespaceList []string `json:"blockedNamespaceList,omitempty"`
 	// IPFamily specifies IP family to be used. This flag can take values V4 or V6 (default V4). This is for the backend pools to use ipv6 or ipv4. For frontside VS, use v6cidr.
 	IPFamily string `json:"ipFamily,omitempty"`
+	// EnablePodReadinessGate enables AKO to set the readiness gate condition of the Pods once their servers are up in the Avi pools
+	EnablePodReadinessGate bool `json:"enablePodReadinessGate,omitempty"`
+	// LoadBalancerClass is the loadBalancerClass of the Services of type LoadBalancer that AKO handles
+	LoadBalancerClass string `json:"loadBalancerClass,omitempty"`
+	// AllowNoLoadBalancerClass lets AKO handle the Services of type LoadBalancer without a loadBalancerClass, when LoadBalancerClass is set (default true)
+	AllowNoLoadBalancerClass *bool `json:"allowNoLoadBalancerClass,omitempty"`
+	// ServerDrainPeriod is the period in seconds for which the servers of terminating pods are kept disabled in the pools before removal
+	ServerDrainPeriod int `json:"serverDrainPeriod,omitempty"`
+	// ReadinessProbeHealthMonitor enables AKO to create the health monitors of the pools from the readiness probes of the Pods
+	ReadinessProbeHealthMonitor bool `json:"readinessProbeHealthMonitor,omitempty"`
+	// DryRun makes AKO only plan the changes to the Avi objects, without making these in the Avi Controller
+	DryRun bool `json:"dryRun,omitempty"`
+	// TracingExporter is the exporter of the traces of AKO, either otlp or file. Empty disables the tracing
+	TracingExporter string `json:"tracingExporter,omitempty"`
+	// TracingEndpoint is the URL of the OTLP/HTTP endpoint, or the path of the file, to export the traces to
+	TracingEndpoint string `json:"tracingEndpoint,omitempty"`
 }
 
 type NodeNetwork struct {
@@ -126,6 +126,11 @@ func (in *AKOConfigStatus) DeepCopy() *AKOConfigStatus {
 func (in *AKOSettings) DeepCopyInto(out *AKOSettings) {
 	*out = *in
 	out.NSSelector = in.NSSelector
+	if in.AllowNoLoadBalancerClass != nil {
+		in, out := &in.AllowNoLoadBalancerClass, &out.AllowNoLoadBalancerClass
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AKOSettings.
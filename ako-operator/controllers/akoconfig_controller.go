@@ -103,6 +103,7 @@ func removeFinalizer(finalizers []string, key string) (result []string) {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses;ingressclasses/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;ingresses/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.x-k8s.io,resources=gatewayclasses;gatewayclasses/status;gatewayclasses/finalizers;gateways;gateways/status;gateways/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses;gatewayclasses/status;gateways;gateways/status;httproutes;httproutes/status;tlsroutes;tlsroutes/status;tcproutes;tcproutes/status;udproutes;udproutes/status;referencegrants,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=policy;extensions,resources=podsecuritypolicies;podsecuritypolicies/finalizers,verbs=use;get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles;clusterroles/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings;clusterrolebindings/finalizers,verbs=get;list;watch;create;update;patch;delete
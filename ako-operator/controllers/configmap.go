@@ -137,6 +137,12 @@ func BuildConfigMap(ako akov1alpha1.AKOConfig) (corev1.ConfigMap, error) {
 	}
 	cm.Data[ServicesAPI] = servicesAPI
 
+	enableGatewayAPI := "false"
+	if ako.Spec.AKOSettings.EnableGatewayAPI {
+		enableGatewayAPI = "true"
+	}
+	cm.Data[EnableGatewayAPI] = enableGatewayAPI
+
 	vipPerNamespace := "false"
 	if ako.Spec.AKOSettings.VipPerNamespace {
 		vipPerNamespace = "true"
@@ -280,6 +286,36 @@ func BuildConfigMap(ako akov1alpha1.AKOConfig) (corev1.ConfigMap, error) {
 	enableMCI := "false"
 	cm.Data[EnableMCI] = enableMCI
 
+	enablePodReadinessGate := "false"
+	if ako.Spec.AKOSettings.EnablePodReadinessGate {
+		enablePodReadinessGate = "true"
+	}
+	cm.Data[EnablePodReadinessGate] = enablePodReadinessGate
+
+	cm.Data[LoadBalancerClass] = ako.Spec.AKOSettings.LoadBalancerClass
+	allowNoLoadBalancerClass := "true"
+	if ako.Spec.AKOSettings.AllowNoLoadBalancerClass != nil && !*ako.Spec.AKOSettings.AllowNoLoadBalancerClass {
+		allowNoLoadBalancerClass = "false"
+	}
+	cm.Data[AllowNoLoadBalancerClass] = allowNoLoadBalancerClass
+
+	cm.Data[ServerDrainPeriod] = strconv.Itoa(ako.Spec.AKOSettings.ServerDrainPeriod)
+
+	readinessProbeHealthMonitor := "false"
+	if ako.Spec.AKOSettings.ReadinessProbeHealthMonitor {
+		readinessProbeHealthMonitor = "true"
+	}
+	cm.Data[ReadinessProbeHealthMonitor] = readinessProbeHealthMonitor
+
+	dryRun := "false"
+	if ako.Spec.AKOSettings.DryRun {
+		dryRun = "true"
+	}
+	cm.Data[DryRun] = dryRun
+
+	cm.Data[TracingExporter] = ako.Spec.AKOSettings.TracingExporter
+	cm.Data[TracingEndpoint] = ako.Spec.AKOSettings.TracingEndpoint
+
 	return cm, nil
 }
 
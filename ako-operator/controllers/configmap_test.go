@@ -45,6 +45,7 @@ var cmJson = `
 		"enableEVH": "false",
 		"layer7Only": "false",
 		"servicesAPI": "false",
+		"enableGatewayAPI": "false",
 		"vipPerNamespace": "false",
 		"controllerIP": "10.10.10.11",
 		"controllerVersion": "1.1",
@@ -72,7 +73,15 @@ var cmJson = `
 		"primaryInstance": "true",
 		"ipFamily": "V4",
 		"istioEnabled": "false",
-		"blockedNamespaceList": "[]"
+		"blockedNamespaceList": "[]",
+		"enablePodReadinessGate": "false",
+		"loadBalancerClass": "",
+		"allowNoLoadBalancerClass": "true",
+		"serverDrainPeriod": "0",
+		"readinessProbeHealthMonitor": "false",
+		"dryRun": "false",
+		"tracingExporter": "",
+		"tracingEndpoint": ""
 	}
 }
 `
@@ -183,6 +183,46 @@ func createHostRuleCRD(clientset *apiextension.ApiextensionsV1Client, log logr.L
 													},
 												},
 											},
+											"clientAuth": {
+												Type:     "object",
+												Required: []string{"ca"},
+												Properties: map[string]apiextensionv1.JSONSchemaProps{
+													"mode": {
+														Type: "string",
+														Enum: []apiextensionv1.JSON{
+															{
+																Raw: []byte("\"Require\""),
+															},
+															{
+																Raw: []byte("\"Request\""),
+															},
+														},
+														Default: &apiextensionv1.JSON{
+															Raw: []byte("\"Require\""),
+														},
+													},
+													"ca": {
+														Type:     "object",
+														Required: []string{"name", "type"},
+														Properties: map[string]apiextensionv1.JSONSchemaProps{
+															"name": {
+																Type: "string",
+															},
+															"type": {
+																Type: "string",
+																Enum: []apiextensionv1.JSON{
+																	{
+																		Raw: []byte("\"ref\""),
+																	},
+																	{
+																		Raw: []byte("\"secret\""),
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
 										},
 									},
 									"analyticsPolicy": {
@@ -261,6 +301,7 @@ func createHostRuleCRD(clientset *apiextension.ApiextensionsV1Client, log logr.L
 											},
 										},
 									},
+									"rateLimit": rateLimitSchema(),
 								},
 							},
 						},
@@ -417,6 +458,12 @@ func createHttpRuleCRD(clientset *apiextension.ApiextensionsV1Client, log logr.L
 													},
 												},
 											},
+											"inlineHealthMonitors": {
+												Type: "array",
+												Items: &apiextensionv1.JSONSchemaPropsOrArray{
+													Schema: httpRuleHealthMonitorSchema(),
+												},
+											},
 											"applicationPersistence": {
 												Type: "string",
 											},
@@ -443,6 +490,192 @@ func createHttpRuleCRD(clientset *apiextension.ApiextensionsV1Client, log logr.L
 													},
 												},
 											},
+											"matches": {
+												Type: "array",
+												Items: &apiextensionv1.JSONSchemaPropsOrArray{
+													Schema: &apiextensionv1.JSONSchemaProps{
+														Type:     "object",
+														Required: []string{"backend"},
+														Properties: map[string]apiextensionv1.JSONSchemaProps{
+															"headers": {
+																Type: "array",
+																Items: &apiextensionv1.JSONSchemaPropsOrArray{
+																	Schema: &apiextensionv1.JSONSchemaProps{
+																		Type:     "object",
+																		Required: []string{"name"},
+																		Properties: map[string]apiextensionv1.JSONSchemaProps{
+																			"name": {
+																				Type: "string",
+																			},
+																			"value": {
+																				Type: "string",
+																			},
+																			"matchType": {
+																				Type: "string",
+																				Enum: []apiextensionv1.JSON{
+																					{
+																						Raw: []byte("\"Exact\""),
+																					}, {
+																						Raw: []byte("\"Prefix\""),
+																					}, {
+																						Raw: []byte("\"Contains\""),
+																					}, {
+																						Raw: []byte("\"Exists\""),
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															"cookie": {
+																Type:     "object",
+																Required: []string{"name"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"name": {
+																		Type: "string",
+																	},
+																	"value": {
+																		Type: "string",
+																	},
+																	"matchType": {
+																		Type: "string",
+																		Enum: []apiextensionv1.JSON{
+																			{
+																				Raw: []byte("\"Exact\""),
+																			}, {
+																				Raw: []byte("\"Prefix\""),
+																			}, {
+																				Raw: []byte("\"Contains\""),
+																			}, {
+																				Raw: []byte("\"Exists\""),
+																			},
+																		},
+																	},
+																},
+															},
+															"backend": {
+																Type:     "object",
+																Required: []string{"service", "port"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"service": {
+																		Type: "string",
+																	},
+																	"port": {
+																		Type: "integer",
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+											"requestHeaders": {
+												Type: "object",
+												Properties: map[string]apiextensionv1.JSONSchemaProps{
+													"add": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type:     "object",
+																Required: []string{"name", "value"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"name": {
+																		Type: "string",
+																	},
+																	"value": {
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"replace": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type:     "object",
+																Required: []string{"name", "value"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"name": {
+																		Type: "string",
+																	},
+																	"value": {
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"remove": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+												},
+											},
+											"responseHeaders": {
+												Type: "object",
+												Properties: map[string]apiextensionv1.JSONSchemaProps{
+													"add": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type:     "object",
+																Required: []string{"name", "value"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"name": {
+																		Type: "string",
+																	},
+																	"value": {
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"replace": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type:     "object",
+																Required: []string{"name", "value"},
+																Properties: map[string]apiextensionv1.JSONSchemaProps{
+																	"name": {
+																		Type: "string",
+																	},
+																	"value": {
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"remove": {
+														Type: "array",
+														Items: &apiextensionv1.JSONSchemaPropsOrArray{
+															Schema: &apiextensionv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+												},
+											},
+											"rewrite": {
+												Type: "object",
+												Properties: map[string]apiextensionv1.JSONSchemaProps{
+													"pathPrefix": {
+														Type: "string",
+													},
+													"host": {
+														Type: "string",
+													},
+												},
+											},
+											"rateLimit": rateLimitSchema(),
 										},
 									},
 								},
@@ -625,6 +858,19 @@ func createAviInfraSettingCRD(clientset *apiextension.ApiextensionsV1Client, log
 									},
 								},
 							},
+							"zoneAwareness": {
+								Type: "object",
+								Properties: map[string]apiextensionv1.JSONSchemaProps{
+									"zones": {
+										Type: "array",
+										Items: &apiextensionv1.JSONSchemaPropsOrArray{
+											Schema: &apiextensionv1.JSONSchemaProps{
+												Type: "string",
+											},
+										},
+									},
+								},
+							},
 						},
 					},
 					"status": {
@@ -725,3 +971,136 @@ func deleteCRDs(cfg *rest.Config) error {
 	}
 	return nil
 }
+
+// rateLimitSchema returns the schema of the rate limit settings, which are common to HostRule and HTTPRule.
+func rateLimitSchema() apiextensionv1.JSONSchemaProps {
+	enum := func(values ...string) []apiextensionv1.JSON {
+		var jsonValues []apiextensionv1.JSON
+		for _, value := range values {
+			jsonValues = append(jsonValues, apiextensionv1.JSON{Raw: []byte("\"" + value + "\"")})
+		}
+		return jsonValues
+	}
+	return apiextensionv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"requests", "period"},
+		Properties: map[string]apiextensionv1.JSONSchemaProps{
+			"requests": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(1000000000),
+			},
+			"period": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(1000000000),
+			},
+			"burst": {
+				Type:    "integer",
+				Minimum: proto.Float64(0),
+				Maximum: proto.Float64(1000000000),
+			},
+			"key": {
+				Type: "string",
+				Enum: enum("ClientIP", "Header"),
+				Default: &apiextensionv1.JSON{
+					Raw: []byte("\"ClientIP\""),
+				},
+			},
+			"header": {
+				Type: "string",
+			},
+			"action": {
+				Type: "object",
+				Properties: map[string]apiextensionv1.JSONSchemaProps{
+					"type": {
+						Type: "string",
+						Enum: enum("Drop", "Report", "Redirect"),
+						Default: &apiextensionv1.JSON{
+							Raw: []byte("\"Drop\""),
+						},
+					},
+					"redirect": {
+						Type: "object",
+						Properties: map[string]apiextensionv1.JSONSchemaProps{
+							"protocol": {
+								Type: "string",
+								Enum: enum("HTTP", "HTTPS"),
+								Default: &apiextensionv1.JSON{
+									Raw: []byte("\"HTTPS\""),
+								},
+							},
+							"host": {
+								Type: "string",
+							},
+							"path": {
+								Type: "string",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// httpRuleHealthMonitorSchema returns the schema of the health monitors defined inline in the paths of HTTPRule.
+func httpRuleHealthMonitorSchema() *apiextensionv1.JSONSchemaProps {
+	enum := func(values ...string) []apiextensionv1.JSON {
+		var jsonValues []apiextensionv1.JSON
+		for _, value := range values {
+			jsonValues = append(jsonValues, apiextensionv1.JSON{Raw: []byte("\"" + value + "\"")})
+		}
+		return jsonValues
+	}
+	return &apiextensionv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"name", "type"},
+		Properties: map[string]apiextensionv1.JSONSchemaProps{
+			"name": {
+				Type: "string",
+			},
+			"type": {
+				Type: "string",
+				Enum: enum("HTTP", "HTTPS", "TCP"),
+			},
+			"sendString": {
+				Type: "string",
+			},
+			"responseCodes": {
+				Type: "array",
+				Items: &apiextensionv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionv1.JSONSchemaProps{
+						Type: "string",
+						Enum: enum("HTTP_ANY", "HTTP_1XX", "HTTP_2XX", "HTTP_3XX", "HTTP_4XX", "HTTP_5XX"),
+					},
+				},
+			},
+			"monitorPort": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(65535),
+			},
+			"sendInterval": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(3600),
+			},
+			"receiveTimeout": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(2400),
+			},
+			"successfulChecks": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(50),
+			},
+			"failedChecks": {
+				Type:    "integer",
+				Minimum: proto.Float64(1),
+				Maximum: proto.Float64(50),
+			},
+		},
+	}
+}
@@ -102,6 +102,11 @@ func BuildClusterrole(ako akov1alpha1.AKOConfig, r *AKOConfigReconciler, log log
 				Resources: []string{"ingresses", "ingresses/status"},
 				Verbs:     []string{"get", "watch", "list", "patch", "update"},
 			},
+			{
+				APIGroups: []string{"discovery.k8s.io"},
+				Resources: []string{"endpointslices"},
+				Verbs:     []string{"get", "watch", "list"},
+			},
 			{
 				APIGroups: []string{"networking.k8s.io"},
 				Resources: []string{"ingressclasses"},
@@ -112,6 +117,11 @@ func BuildClusterrole(ako akov1alpha1.AKOConfig, r *AKOConfigReconciler, log log
 				Resources: []string{"services", "services/status", "secrets"},
 				Verbs:     []string{"get", "watch", "list", "patch", "update"},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/status"},
+				Verbs:     []string{"get", "patch", "update"},
+			},
 			{
 				APIGroups: []string{""},
 				Resources: []string{"events"},
@@ -142,6 +152,16 @@ func BuildClusterrole(ako akov1alpha1.AKOConfig, r *AKOConfigReconciler, log log
 				Resources: []string{"gateways", "gateways/status", "gatewayclasses", "gatewayclasses/status"},
 				Verbs:     []string{"get", "watch", "list", "patch", "update"},
 			},
+			{
+				APIGroups: []string{"gateway.networking.k8s.io"},
+				Resources: []string{"gatewayclasses", "gateways", "httproutes", "tlsroutes", "tcproutes", "udproutes", "referencegrants"},
+				Verbs:     []string{"get", "watch", "list"},
+			},
+			{
+				APIGroups: []string{"gateway.networking.k8s.io"},
+				Resources: []string{"gatewayclasses/status", "gateways/status", "httproutes/status", "tlsroutes/status", "tcproutes/status", "udproutes/status"},
+				Verbs:     []string{"get", "patch", "update"},
+			},
 		},
 	}
 
@@ -247,6 +247,87 @@ var sfJson = `
                                     }
                                 }
                             },
+                            {
+                                "name": "ENABLE_POD_READINESS_GATE",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "enablePodReadinessGate",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "LOAD_BALANCER_CLASS",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "loadBalancerClass",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "ALLOW_NO_LOAD_BALANCER_CLASS",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "allowNoLoadBalancerClass",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "SERVER_DRAIN_PERIOD",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "serverDrainPeriod",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "READINESS_PROBE_HEALTH_MONITOR",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "readinessProbeHealthMonitor",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "DRY_RUN",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "dryRun",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "TRACING_EXPORTER",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "tracingExporter",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "TRACING_ENDPOINT",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "tracingEndpoint",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
+                            {
+                                "name": "ENABLE_GATEWAY_API",
+                                "valueFrom": {
+                                    "configMapKeyRef": {
+                                        "key": "enableGatewayAPI",
+                                        "name": "avi-k8s-config"
+                                    }
+                                }
+                            },
                             {
                                 "name": "VIP_PER_NAMESPACE",
                                 "valueFrom": {
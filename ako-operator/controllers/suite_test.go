@@ -180,7 +180,29 @@ func TestConfigmap(t *testing.T) {
 
 	t.Log("updating blockedNamespaceList and verifying")
 	akoConfig.Spec.AKOSettings.BlockedNamespaceList = []string{"blocked-ns"}
-	buildConfigMapAndVerify(cmIstioEnabled, akoConfig, true, false, t)
+	cmBlockedNS := buildConfigMapAndVerify(cmIstioEnabled, akoConfig, true, false, t)
+
+	t.Log("updating enableGatewayAPI and verifying")
+	akoConfig.Spec.AKOSettings.EnableGatewayAPI = true
+	cmGatewayAPI := buildConfigMapAndVerify(cmBlockedNS, akoConfig, true, false, t)
+
+	t.Log("updating allowNoLoadBalancerClass and verifying")
+	allowNoLoadBalancerClass := false
+	akoConfig.Spec.AKOSettings.LoadBalancerClass = "ako.vmware.com/avi-lb"
+	akoConfig.Spec.AKOSettings.AllowNoLoadBalancerClass = &allowNoLoadBalancerClass
+	cmLBClass := buildConfigMapAndVerify(cmGatewayAPI, akoConfig, true, false, t)
+	g.Expect(cmLBClass.Data[LoadBalancerClass]).To(gomega.Equal("ako.vmware.com/avi-lb"))
+	g.Expect(cmLBClass.Data[AllowNoLoadBalancerClass]).To(gomega.Equal("false"))
+
+	t.Log("updating serverDrainPeriod and verifying")
+	akoConfig.Spec.AKOSettings.ServerDrainPeriod = 30
+	cmDrain := buildConfigMapAndVerify(cmLBClass, akoConfig, true, false, t)
+	g.Expect(cmDrain.Data[ServerDrainPeriod]).To(gomega.Equal("30"))
+
+	t.Log("updating tracingExporter and verifying")
+	akoConfig.Spec.AKOSettings.TracingExporter = "otlp"
+	akoConfig.Spec.AKOSettings.TracingEndpoint = "http://otel-collector.observability:4318"
+	buildConfigMapAndVerify(cmDrain, akoConfig, true, false, t)
 }
 
 func TestStatefulset(t *testing.T) {
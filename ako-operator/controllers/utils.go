@@ -28,44 +28,53 @@ const (
 
 // below properties are applicable to a configmap object for AKO controller
 const (
-	ControllerIP           = "controllerIP"
-	ControllerVersion      = "controllerVersion"
-	CniPlugin              = "cniPlugin"
-	EnableEVH              = "enableEVH"
-	Layer7Only             = "layer7Only"
-	ServicesAPI            = "servicesAPI"
-	VipPerNamespace        = "vipPerNamespace"
-	ShardVSSize            = "shardVSSize"
-	PassthroughShardSize   = "passhtroughShardSize"
-	FullSyncFrequency      = "fullSyncFrequency"
-	CloudName              = "cloudName"
-	ClusterName            = "clusterName"
-	EnableRHI              = "enableRHI"
-	DefaultDomain          = "defaultDomain"
-	DisableStaticRouteSync = "disableStaticRouteSync"
-	DefaultIngController   = "defaultIngController"
-	VipNetworkList         = "vipNetworkList"
-	BgpPeerLabels          = "bgpPeerLabels"
-	EnableEvents           = "enableEvents"
-	LogLevel               = "logLevel"
-	DeleteConfig           = "deleteConfig"
-	AutoFQDN               = "autoFQDN"
-	ServiceType            = "serviceType"
-	NodeKey                = "nodeKey"
-	NodeValue              = "nodeValue"
-	ServiceEngineGroupName = "serviceEngineGroupName"
-	NodeNetworkList        = "nodeNetworkList"
-	APIServerPort          = "apiServerPort"
-	NSSyncLabelKey         = "nsSyncLabelKey"
-	NSSyncLabelValue       = "nsSyncLabelValue"
-	TenantName             = "tenantName"
-	NoPGForSni             = "noPGForSni"
-	NsxtT1LR               = "nsxtT1LR"
-	PrimaryInstance        = "primaryInstance"
-	IstioEnabled           = "istioEnabled"
-	BlockedNamespaceList   = "blockedNamespaceList"
-	IPFamily               = "ipFamily"
-	EnableMCI              = "enableMCI"
+	ControllerIP                = "controllerIP"
+	ControllerVersion           = "controllerVersion"
+	CniPlugin                   = "cniPlugin"
+	EnableEVH                   = "enableEVH"
+	Layer7Only                  = "layer7Only"
+	ServicesAPI                 = "servicesAPI"
+	VipPerNamespace             = "vipPerNamespace"
+	ShardVSSize                 = "shardVSSize"
+	PassthroughShardSize        = "passhtroughShardSize"
+	FullSyncFrequency           = "fullSyncFrequency"
+	CloudName                   = "cloudName"
+	ClusterName                 = "clusterName"
+	EnableRHI                   = "enableRHI"
+	DefaultDomain               = "defaultDomain"
+	DisableStaticRouteSync      = "disableStaticRouteSync"
+	DefaultIngController        = "defaultIngController"
+	VipNetworkList              = "vipNetworkList"
+	BgpPeerLabels               = "bgpPeerLabels"
+	EnableEvents                = "enableEvents"
+	LogLevel                    = "logLevel"
+	DeleteConfig                = "deleteConfig"
+	AutoFQDN                    = "autoFQDN"
+	ServiceType                 = "serviceType"
+	NodeKey                     = "nodeKey"
+	NodeValue                   = "nodeValue"
+	ServiceEngineGroupName      = "serviceEngineGroupName"
+	NodeNetworkList             = "nodeNetworkList"
+	APIServerPort               = "apiServerPort"
+	NSSyncLabelKey              = "nsSyncLabelKey"
+	NSSyncLabelValue            = "nsSyncLabelValue"
+	TenantName                  = "tenantName"
+	NoPGForSni                  = "noPGForSni"
+	NsxtT1LR                    = "nsxtT1LR"
+	PrimaryInstance             = "primaryInstance"
+	IstioEnabled                = "istioEnabled"
+	BlockedNamespaceList        = "blockedNamespaceList"
+	IPFamily                    = "ipFamily"
+	EnableMCI                   = "enableMCI"
+	EnableGatewayAPI            = "enableGatewayAPI"
+	EnablePodReadinessGate      = "enablePodReadinessGate"
+	LoadBalancerClass           = "loadBalancerClass"
+	AllowNoLoadBalancerClass    = "allowNoLoadBalancerClass"
+	ServerDrainPeriod           = "serverDrainPeriod"
+	ReadinessProbeHealthMonitor = "readinessProbeHealthMonitor"
+	DryRun                      = "dryRun"
+	TracingExporter             = "tracingExporter"
+	TracingEndpoint             = "tracingEndpoint"
 )
 
 var SecretEnvVars = map[string]string{
@@ -73,39 +82,48 @@ var SecretEnvVars = map[string]string{
 }
 
 var ConfigMapEnvVars = map[string]string{
-	"CTRL_IPADDRESS":             ControllerIP,
-	"CTRL_VERSION":               ControllerVersion,
-	"CNI_PLUGIN":                 CniPlugin,
-	"ENABLE_EVH":                 EnableEVH,
-	"SERVICES_API":               ServicesAPI,
-	"SHARD_VS_SIZE":              ShardVSSize,
-	"PASSTHROUGH_SHARD_SIZE":     PassthroughShardSize,
-	"FULL_SYNC_INTERVAL":         FullSyncFrequency,
-	"CLOUD_NAME":                 CloudName,
-	"CLUSTER_NAME":               ClusterName,
-	"ENABLE_RHI":                 EnableRHI,
-	"BGP_PEER_LABELS":            BgpPeerLabels,
-	"DEFAULT_DOMAIN":             DefaultDomain,
-	"DISABLE_STATIC_ROUTE_SYNC":  DisableStaticRouteSync,
-	"DEFAULT_ING_CONTROLLER":     DefaultIngController,
-	"VIP_NETWORK_LIST":           VipNetworkList,
-	"AUTO_L4_FQDN":               AutoFQDN,
-	"SERVICE_TYPE":               ServiceType,
-	"NODE_KEY":                   NodeKey,
-	"NODE_VALUE":                 NodeValue,
-	"SEG_NAME":                   ServiceEngineGroupName,
-	"NODE_NETWORK_LIST":          NodeNetworkList,
-	"AKO_API_PORT":               APIServerPort,
-	"TENANT_NAME":                TenantName,
-	"NAMESPACE_SYNC_LABEL_KEY":   NSSyncLabelKey,
-	"NAMESPACE_SYNC_LABEL_VALUE": NSSyncLabelValue,
-	"NSXT_T1_LR":                 NsxtT1LR,
-	"PRIMARY_AKO_FLAG":           PrimaryInstance,
-	"ISTIO_ENABLED":              IstioEnabled,
-	"IP_FAMILY":                  IPFamily,
-	"MCI_ENABLED":                EnableMCI,
-	"BLOCKED_NS_LIST":            BlockedNamespaceList,
-	"VIP_PER_NAMESPACE":          VipPerNamespace,
+	"CTRL_IPADDRESS":                 ControllerIP,
+	"CTRL_VERSION":                   ControllerVersion,
+	"CNI_PLUGIN":                     CniPlugin,
+	"ENABLE_EVH":                     EnableEVH,
+	"SERVICES_API":                   ServicesAPI,
+	"SHARD_VS_SIZE":                  ShardVSSize,
+	"PASSTHROUGH_SHARD_SIZE":         PassthroughShardSize,
+	"FULL_SYNC_INTERVAL":             FullSyncFrequency,
+	"CLOUD_NAME":                     CloudName,
+	"CLUSTER_NAME":                   ClusterName,
+	"ENABLE_RHI":                     EnableRHI,
+	"BGP_PEER_LABELS":                BgpPeerLabels,
+	"DEFAULT_DOMAIN":                 DefaultDomain,
+	"DISABLE_STATIC_ROUTE_SYNC":      DisableStaticRouteSync,
+	"DEFAULT_ING_CONTROLLER":         DefaultIngController,
+	"VIP_NETWORK_LIST":               VipNetworkList,
+	"AUTO_L4_FQDN":                   AutoFQDN,
+	"SERVICE_TYPE":                   ServiceType,
+	"NODE_KEY":                       NodeKey,
+	"NODE_VALUE":                     NodeValue,
+	"SEG_NAME":                       ServiceEngineGroupName,
+	"NODE_NETWORK_LIST":              NodeNetworkList,
+	"AKO_API_PORT":                   APIServerPort,
+	"TENANT_NAME":                    TenantName,
+	"NAMESPACE_SYNC_LABEL_KEY":       NSSyncLabelKey,
+	"NAMESPACE_SYNC_LABEL_VALUE":     NSSyncLabelValue,
+	"NSXT_T1_LR":                     NsxtT1LR,
+	"PRIMARY_AKO_FLAG":               PrimaryInstance,
+	"ISTIO_ENABLED":                  IstioEnabled,
+	"IP_FAMILY":                      IPFamily,
+	"MCI_ENABLED":                    EnableMCI,
+	"BLOCKED_NS_LIST":                BlockedNamespaceList,
+	"VIP_PER_NAMESPACE":              VipPerNamespace,
+	"ENABLE_GATEWAY_API":             EnableGatewayAPI,
+	"ENABLE_POD_READINESS_GATE":      EnablePodReadinessGate,
+	"LOAD_BALANCER_CLASS":            LoadBalancerClass,
+	"ALLOW_NO_LOAD_BALANCER_CLASS":   AllowNoLoadBalancerClass,
+	"SERVER_DRAIN_PERIOD":            ServerDrainPeriod,
+	"READINESS_PROBE_HEALTH_MONITOR": ReadinessProbeHealthMonitor,
+	"DRY_RUN":                        DryRun,
+	"TRACING_EXPORTER":               TracingExporter,
+	"TRACING_ENDPOINT":               TracingEndpoint,
 }
 
 func getSFNamespacedName() types.NamespacedName {
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/debug"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api"
@@ -60,7 +61,7 @@ func main() {
 }
 
 func InitializeAKOApi() {
-	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{})
+	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{debug.NewModel()})
 	akoApi.InitApi()
 	lib.SetApiServerInstance(akoApi)
 }
@@ -99,6 +100,15 @@ func InitializeAKC() {
 	}
 	akoControlConfig.SetAKOInstanceFlag(isPrimaryAKO)
 	akoControlConfig.SetAKOBlockedNSList(lib.GetGlobalBlockedNSList())
+
+	if exporter := lib.GetTracingExporter(); exporter != "" {
+		resource := map[string]string{"service.version": version, "ako.cluster_name": lib.GetClusterName()}
+		if err := utils.InitTracing(exporter, lib.GetTracingEndpoint(), resource); err != nil {
+			utils.AviLog.Warnf("Tracing is disabled: %v", err)
+		} else {
+			defer utils.StopTracing()
+		}
+	}
 	var crdClient *crd.Clientset
 	var advl4Client *advl4.Clientset
 	var svcAPIClient *svcapi.Clientset
@@ -155,9 +165,11 @@ func InitializeAKC() {
 		}
 	}
 
-	oshiftClient, err := oshiftclient.NewForConfig(cfg)
-	if err != nil {
+	var oshiftClient oshiftclient.Interface
+	if oshiftClientset, err := oshiftclient.NewForConfig(cfg); err != nil {
 		utils.AviLog.Warnf("Error in creating openshift clientset")
+	} else {
+		oshiftClient = oshiftClientset
 	}
 
 	registeredInformers, err := lib.InformersToRegister(kubeClient, oshiftClient)
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// ako-render prints the Avi objects which AKO would create for the Kubernetes objects in the manifests, without
+// connecting to a Kubernetes cluster or an Avi Controller.
+//
+//	ako-render -f ingress.yaml -f services/ > avi-objects.json
+//
+// The settings of AKO are read from the same environment variables as in the AKO pod, for example:
+//
+//	CLUSTER_NAME=my-cluster SHARD_VS_SIZE=SMALL ENABLE_EVH=true ako-render -f ingress.yaml
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/render"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"k8s.io/klog/v2"
+)
+
+type manifestPaths []string
+
+func (m *manifestPaths) String() string {
+	return strings.Join(*m, ",")
+}
+
+func (m *manifestPaths) Set(path string) error {
+	*m = append(*m, path)
+	return nil
+}
+
+// defaultEnv are the values of the settings of AKO which are not set in the environment. These are the defaults
+// of the Helm chart, except for the static routes, which are not synced as there are no Nodes to route to.
+var defaultEnv = map[string]string{
+	lib.CLUSTER_NAME:              "cluster",
+	"SHARD_VS_SIZE":               "LARGE",
+	"AUTO_L4_FQDN":                "default",
+	"POD_NAMESPACE":               utils.AKO_DEFAULT_NS,
+	lib.DISABLE_STATIC_ROUTE_SYNC: "true",
+}
+
+var (
+	paths         manifestPaths
+	dnsSubDomains string
+	logLevel      string
+)
+
+func main() {
+	flag.Var(&paths, "f", "Manifest file, or directory of manifest files, to render. Use - to read from the stdin. Can be repeated.")
+	flag.StringVar(&dnsSubDomains, "dns-subdomains", "", "Comma separated sub-domains of the DNS profile of the cloud, used for the auto FQDNs of the L4 services.")
+	flag.StringVar(&logLevel, "log-level", "WARN", "Log level, one of DEBUG, INFO, WARN and ERROR. The logs are written to the stderr.")
+	flag.Parse()
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "at least one manifest must be specified with -f")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	utils.AviLog.SetOutput(os.Stderr)
+	utils.AviLog.SetLevel(strings.ToUpper(logLevel))
+	klog.SetLogger(utils.AviLog)
+	for env, value := range defaultEnv {
+		if os.Getenv(env) == "" {
+			os.Setenv(env, value)
+		}
+	}
+
+	objs, err := render.ReadManifestFiles(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var opts render.Options
+	if dnsSubDomains != "" {
+		opts.DNSSubDomains = strings.Split(dnsSubDomains, ",")
+	}
+	output, err := render.Render(objs, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
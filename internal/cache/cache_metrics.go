@@ -0,0 +1,57 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var aviObjCacheObjectsDesc = prometheus.NewDesc(
+	"ako_avi_cache_objects",
+	"Number of Avi objects in the controller object cache.",
+	[]string{"type"}, nil,
+)
+
+// aviObjCacheCollector reports the number of objects in each of the caches of the AviObjCache, when the metrics
+// are scraped.
+type aviObjCacheCollector struct {
+	cache *AviObjCache
+}
+
+func (a *aviObjCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- aviObjCacheObjectsDesc
+}
+
+func (a *aviObjCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	caches := map[string]*AviCache{
+		"VirtualService":                a.cache.VsCacheMeta,
+		"PoolGroup":                     a.cache.PgCache,
+		"VSDataScriptSet":               a.cache.DSCache,
+		"Pool":                          a.cache.PoolCache,
+		"HTTPPolicySet":                 a.cache.HTTPPolicyCache,
+		"L4PolicySet":                   a.cache.L4PolicyCache,
+		"SSLKeyAndCertificate":          a.cache.SSLKeyCache,
+		"PKIProfile":                    a.cache.PKIProfileCache,
+		"ApplicationProfile":            a.cache.AppProfileCache,
+		"ApplicationPersistenceProfile": a.cache.PersistenceProfileCache,
+		"NetworkSecurityPolicy":         a.cache.NetworkSecurityPolicyCache,
+		"HealthMonitor":                 a.cache.HealthMonitorCache,
+		"VsVip":                         a.cache.VSVIPCache,
+		"VrfContext":                    a.cache.VrfCache,
+	}
+	for objType, cache := range caches {
+		ch <- prometheus.MustNewConstMetric(aviObjCacheObjectsDesc, prometheus.GaugeValue, float64(cache.AviCacheLen()), objType)
+	}
+}
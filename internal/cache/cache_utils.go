@@ -38,9 +38,11 @@ type AviPoolCache struct {
 	CloudConfigCksum     string
 	ServiceMetadataObj   lib.ServiceMetadataObj
 	PkiProfileCollection NamespaceName
-	LastModified         string
-	InvalidData          bool
-	HasReference         bool
+	// The health monitors created by AKO, which the pool refers to.
+	HealthMonitorCollection []NamespaceName
+	LastModified            string
+	InvalidData             bool
+	HasReference            bool
 }
 
 type AviDSCache struct {
@@ -290,6 +292,42 @@ type AviPkiProfileCache struct {
 	HasReference     bool
 }
 
+type AviAppProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+}
+
+type AviPersistenceProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+}
+
+type AviHealthMonitorCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+}
+
+type AviNetworkSecurityPolicyCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+}
+
 type NextPage struct {
 	NextURI    string
 	Collection interface{}
@@ -339,6 +377,7 @@ type AviL4PolicyCache struct {
 	Uuid             string
 	CloudConfigCksum uint32
 	Pools            []string
+	PoolGroups       []string
 	LastModified     string
 	HasReference     bool
 }
@@ -500,11 +539,24 @@ func (c *AviCache) AviCacheGetNameByUuid(uuid string) (interface{}, bool) {
 			} else if value.(*AviPkiProfileCache).Uuid == uuid {
 				return value.(*AviPkiProfileCache).Name, true
 			}
+		case *AviHealthMonitorCache:
+			if value.(*AviHealthMonitorCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for health monitor key %v", reflect.ValueOf(key))
+			} else if value.(*AviHealthMonitorCache).Uuid == uuid {
+				return value.(*AviHealthMonitorCache).Name, true
+			}
 		}
 	}
 	return nil, false
 }
 
+// AviCacheLen returns the number of objects in the cache.
+func (c *AviCache) AviCacheLen() int {
+	c.cache_lock.RLock()
+	defer c.cache_lock.RUnlock()
+	return len(c.cache)
+}
+
 func (c *AviCache) AviCacheAdd(k interface{}, val interface{}) {
 	c.cache_lock.Lock()
 	defer c.cache_lock.Unlock()
@@ -39,19 +39,23 @@ import (
 )
 
 type AviObjCache struct {
-	PgCache            *AviCache
-	DSCache            *AviCache
-	PoolCache          *AviCache
-	CloudKeyCache      *AviCache
-	HTTPPolicyCache    *AviCache
-	L4PolicyCache      *AviCache
-	SSLKeyCache        *AviCache
-	PKIProfileCache    *AviCache
-	VSVIPCache         *AviCache
-	VrfCache           *AviCache
-	VsCacheMeta        *AviCache
-	VsCacheLocal       *AviCache
-	ClusterStatusCache *AviCache
+	PgCache                    *AviCache
+	DSCache                    *AviCache
+	PoolCache                  *AviCache
+	CloudKeyCache              *AviCache
+	HTTPPolicyCache            *AviCache
+	L4PolicyCache              *AviCache
+	SSLKeyCache                *AviCache
+	PKIProfileCache            *AviCache
+	AppProfileCache            *AviCache
+	PersistenceProfileCache    *AviCache
+	NetworkSecurityPolicyCache *AviCache
+	HealthMonitorCache         *AviCache
+	VSVIPCache                 *AviCache
+	VrfCache                   *AviCache
+	VsCacheMeta                *AviCache
+	VsCacheLocal               *AviCache
+	ClusterStatusCache         *AviCache
 }
 
 func NewAviObjCache() *AviObjCache {
@@ -68,6 +72,10 @@ func NewAviObjCache() *AviObjCache {
 	c.VSVIPCache = NewAviCache()
 	c.VrfCache = NewAviCache()
 	c.PKIProfileCache = NewAviCache()
+	c.AppProfileCache = NewAviCache()
+	c.PersistenceProfileCache = NewAviCache()
+	c.NetworkSecurityPolicyCache = NewAviCache()
+	c.HealthMonitorCache = NewAviCache()
 	c.ClusterStatusCache = NewAviCache()
 	return &c
 }
@@ -78,6 +86,7 @@ var cacheOnce sync.Once
 func SharedAviObjCache() *AviObjCache {
 	cacheOnce.Do(func() {
 		cacheInstance = NewAviObjCache()
+		utils.RegisterMetricsCollector(&aviObjCacheCollector{cache: cacheInstance})
 	})
 	return cacheInstance
 }
@@ -95,6 +104,10 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 		c.PopulateVsVipDataToCache(client[7], cloud)
 	}()
 	c.PopulatePkiProfilesToCache(client[0])
+	c.PopulateAppProfilesToCache(client[0])
+	c.PopulatePersistenceProfilesToCache(client[0])
+	c.PopulateNetworkSecurityPoliciesToCache(client[0])
+	c.PopulateHealthMonitorsToCache(client[0])
 	c.PopulatePoolsToCache(client[1], cloud)
 	c.PopulatePgDataToCache(client[2], cloud)
 
@@ -611,12 +624,13 @@ func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud strin
 		}
 
 		poolCacheObj := AviPoolCache{
-			Name:                 *pool.Name,
-			Uuid:                 *pool.UUID,
-			CloudConfigCksum:     *pool.CloudConfigCksum,
-			PkiProfileCollection: pkiKey,
-			ServiceMetadataObj:   svc_mdata_obj,
-			LastModified:         *pool.LastModified,
+			Name:                    *pool.Name,
+			Uuid:                    *pool.UUID,
+			CloudConfigCksum:        *pool.CloudConfigCksum,
+			PkiProfileCollection:    pkiKey,
+			HealthMonitorCollection: c.GetHealthMonitorCollection(pool.HealthMonitorRefs),
+			ServiceMetadataObj:      svc_mdata_obj,
+			LastModified:            *pool.LastModified,
 		}
 		*poolData = append(*poolData, poolCacheObj)
 	}
@@ -666,6 +680,398 @@ func (c *AviObjCache) PopulatePkiProfilesToCache(client *clients.AviClient, over
 	}
 }
 
+func (c *AviObjCache) AviPopulateAllAppProfiles(client *clients.AviClient, appProfileData *[]AviAppProfileCache, overrideUri ...NextPage) (*[]AviAppProfileCache, int, error) {
+	var uri string
+	akoUser := lib.AKOUser
+
+	if len(overrideUri) == 1 {
+		uri = overrideUri[0].NextURI
+	} else {
+		uri = "/api/applicationprofile/?" + "&include_name=true&" + "&created_by=" + akoUser + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		*appProfileData = append(*appProfileData, AviAppProfileCache{
+			Name:             *appProfile.Name,
+			Uuid:             *appProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: appProfileChecksum(&appProfile),
+		})
+	}
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/applicationprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllAppProfiles(client, appProfileData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return appProfileData, result.Count, nil
+}
+
+// appProfileChecksum returns the checksum of the application profile fetched from the controller, where the
+// PKI profile reference is of the form https://<controller>/api/pkiprofile/<uuid>#<name>.
+func appProfileChecksum(appProfile *models.ApplicationProfile) uint32 {
+	var clientCertMode, pkiProfileName string
+	if appProfile.HTTPProfile != nil {
+		if appProfile.HTTPProfile.SslClientCertificateMode != nil {
+			clientCertMode = *appProfile.HTTPProfile.SslClientCertificateMode
+		}
+		if appProfile.HTTPProfile.PkiProfileRef != nil {
+			pkiProfileRef := strings.Split(*appProfile.HTTPProfile.PkiProfileRef, "#")
+			pkiProfileName = pkiProfileRef[len(pkiProfileRef)-1]
+		}
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return lib.ApplicationProfileChecksum(*appProfile.Name, clientCertMode, pkiProfileName, emptyIngestionMarkers, appProfile.Markers, true)
+}
+
+func (c *AviObjCache) PopulateAppProfilesToCache(client *clients.AviClient, overrideUri ...NextPage) {
+	var appProfileData []AviAppProfileCache
+	c.AviPopulateAllAppProfiles(client, &appProfileData)
+
+	appProfileCacheData := c.AppProfileCache.ShallowCopy()
+	for i, appProfileCacheObj := range appProfileData {
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: appProfileCacheObj.Name}
+		utils.AviLog.Infof("Adding key to applicationprofile cache :%s value :%s", k, appProfileCacheObj.Uuid)
+		c.AppProfileCache.AviCacheAdd(k, &appProfileData[i])
+		delete(appProfileCacheData, k)
+	}
+	// The data that is left in appProfileCacheData should be explicitly removed
+	for key := range appProfileCacheData {
+		utils.AviLog.Infof("Deleting key from applicationprofile cache :%s", key)
+		c.AppProfileCache.AviCacheDelete(key)
+	}
+}
+
+// AviPopulateAllPersistenceProfiles fetches the client IP persistence profiles created by AKO. The persistence
+// profiles do not record their creator, hence these are matched by the name.
+func (c *AviObjCache) AviPopulateAllPersistenceProfiles(client *clients.AviClient, persistenceProfileData *[]AviPersistenceProfileCache, overrideUri ...NextPage) (*[]AviPersistenceProfileCache, int, error) {
+	var uri string
+
+	if len(overrideUri) == 1 {
+		uri = overrideUri[0].NextURI
+	} else {
+		uri = "/api/applicationpersistenceprofile/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationpersistenceprofile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+		if !strings.HasSuffix(*persistenceProfile.Name, lib.PersistenceProfileSuffix) {
+			continue
+		}
+		*persistenceProfileData = append(*persistenceProfileData, AviPersistenceProfileCache{
+			Name:             *persistenceProfile.Name,
+			Uuid:             *persistenceProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: persistenceProfileChecksum(&persistenceProfile),
+		})
+	}
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/applicationpersistenceprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationpersistenceprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllPersistenceProfiles(client, persistenceProfileData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return persistenceProfileData, result.Count, nil
+}
+
+// persistenceProfileChecksum returns the checksum of the client IP persistence profile fetched from the controller.
+func persistenceProfileChecksum(persistenceProfile *models.ApplicationPersistenceProfile) uint32 {
+	var timeout int32
+	if persistenceProfile.IPPersistenceProfile != nil && persistenceProfile.IPPersistenceProfile.IPPersistentTimeout != nil {
+		timeout = *persistenceProfile.IPPersistenceProfile.IPPersistentTimeout
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return lib.PersistenceProfileChecksum(*persistenceProfile.Name, timeout, emptyIngestionMarkers, persistenceProfile.Markers, true)
+}
+
+func (c *AviObjCache) PopulatePersistenceProfilesToCache(client *clients.AviClient, overrideUri ...NextPage) {
+	var persistenceProfileData []AviPersistenceProfileCache
+	c.AviPopulateAllPersistenceProfiles(client, &persistenceProfileData)
+
+	persistenceProfileCacheData := c.PersistenceProfileCache.ShallowCopy()
+	for i, persistenceProfileCacheObj := range persistenceProfileData {
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: persistenceProfileCacheObj.Name}
+		utils.AviLog.Infof("Adding key to applicationpersistenceprofile cache :%s value :%s", k, persistenceProfileCacheObj.Uuid)
+		c.PersistenceProfileCache.AviCacheAdd(k, &persistenceProfileData[i])
+		delete(persistenceProfileCacheData, k)
+	}
+	// The data that is left in persistenceProfileCacheData should be explicitly removed
+	for key := range persistenceProfileCacheData {
+		utils.AviLog.Infof("Deleting key from applicationpersistenceprofile cache :%s", key)
+		c.PersistenceProfileCache.AviCacheDelete(key)
+	}
+}
+
+// AviPopulateAllHealthMonitors fetches the health monitors of the pools, which AKO derives from the readiness probes
+// of the Pods or from the HTTPRules. The health monitors do not record their creator, hence these are matched by
+// the name.
+func (c *AviObjCache) AviPopulateAllHealthMonitors(client *clients.AviClient, healthMonitorData *[]AviHealthMonitorCache, overrideUri ...NextPage) (*[]AviHealthMonitorCache, int, error) {
+	var uri string
+
+	if len(overrideUri) == 1 {
+		uri = overrideUri[0].NextURI
+	} else {
+		uri = "/api/healthmonitor/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		healthMonitor := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &healthMonitor)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if healthMonitor.Name == nil || healthMonitor.UUID == nil {
+			utils.AviLog.Warnf("Incomplete healthmonitor data unmarshalled, %s", utils.Stringify(healthMonitor))
+			continue
+		}
+		if !strings.HasSuffix(*healthMonitor.Name, lib.ReadinessProbeHealthMonitorSuffix) &&
+			!strings.HasSuffix(*healthMonitor.Name, lib.HTTPRuleHealthMonitorSuffix) {
+			continue
+		}
+		*healthMonitorData = append(*healthMonitorData, AviHealthMonitorCache{
+			Name:             *healthMonitor.Name,
+			Uuid:             *healthMonitor.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: HealthMonitorChecksum(&healthMonitor),
+		})
+	}
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/healthmonitor")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/healthmonitor" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllHealthMonitors(client, healthMonitorData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return healthMonitorData, result.Count, nil
+}
+
+// HealthMonitorChecksum returns the checksum of the health monitor of a pool, fetched from the controller or
+// built by the rest layer.
+func HealthMonitorChecksum(healthMonitor *models.HealthMonitor) uint32 {
+	var monitorType, httpRequest, tcpRequest string
+	var responseCodes []string
+	var monitorPort, sendInterval, receiveTimeout, successfulChecks, failedChecks int32
+	if healthMonitor.Type != nil {
+		monitorType = *healthMonitor.Type
+	}
+	if healthMonitor.HTTPMonitor != nil && healthMonitor.HTTPMonitor.HTTPRequest != nil {
+		httpRequest = *healthMonitor.HTTPMonitor.HTTPRequest
+		responseCodes = healthMonitor.HTTPMonitor.HTTPResponseCode
+	} else if healthMonitor.HTTPSMonitor != nil && healthMonitor.HTTPSMonitor.HTTPRequest != nil {
+		httpRequest = *healthMonitor.HTTPSMonitor.HTTPRequest
+		responseCodes = healthMonitor.HTTPSMonitor.HTTPResponseCode
+	}
+	if healthMonitor.TCPMonitor != nil && healthMonitor.TCPMonitor.TCPRequest != nil {
+		tcpRequest = *healthMonitor.TCPMonitor.TCPRequest
+	}
+	if healthMonitor.MonitorPort != nil {
+		monitorPort = *healthMonitor.MonitorPort
+	}
+	if healthMonitor.SendInterval != nil {
+		sendInterval = *healthMonitor.SendInterval
+	}
+	if healthMonitor.ReceiveTimeout != nil {
+		receiveTimeout = *healthMonitor.ReceiveTimeout
+	}
+	if healthMonitor.SuccessfulChecks != nil {
+		successfulChecks = *healthMonitor.SuccessfulChecks
+	}
+	if healthMonitor.FailedChecks != nil {
+		failedChecks = *healthMonitor.FailedChecks
+	}
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return lib.HealthMonitorChecksum(*healthMonitor.Name, monitorType, httpRequest, tcpRequest, responseCodes, monitorPort, sendInterval, receiveTimeout,
+		successfulChecks, failedChecks, emptyIngestionMarkers, healthMonitor.Markers, true)
+}
+
+// GetHealthMonitorCollection returns the health monitors created by AKO, among the health monitor refs of a pool.
+// The refs are either the URLs of the health monitors, or the refs by name which AKO sets on the pool.
+func (c *AviObjCache) GetHealthMonitorCollection(healthMonitorRefs []string) []NamespaceName {
+	var healthMonitors []NamespaceName
+	for _, ref := range healthMonitorRefs {
+		var name string
+		if strings.Contains(ref, "?name=") {
+			name = strings.Split(ref, "?name=")[1]
+		} else {
+			uuid := strings.Split(ref[strings.LastIndex(ref, "/")+1:], "#")[0]
+			if healthMonitorName, found := c.HealthMonitorCache.AviCacheGetNameByUuid(uuid); found {
+				name = healthMonitorName.(string)
+			}
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: name}
+		if _, found := c.HealthMonitorCache.AviCacheGet(k); found {
+			healthMonitors = append(healthMonitors, k)
+		}
+	}
+	return healthMonitors
+}
+
+func (c *AviObjCache) PopulateHealthMonitorsToCache(client *clients.AviClient, overrideUri ...NextPage) {
+	var healthMonitorData []AviHealthMonitorCache
+	c.AviPopulateAllHealthMonitors(client, &healthMonitorData)
+
+	healthMonitorCacheData := c.HealthMonitorCache.ShallowCopy()
+	for i, healthMonitorCacheObj := range healthMonitorData {
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: healthMonitorCacheObj.Name}
+		utils.AviLog.Infof("Adding key to healthmonitor cache :%s value :%s", k, healthMonitorCacheObj.Uuid)
+		c.HealthMonitorCache.AviCacheAdd(k, &healthMonitorData[i])
+		delete(healthMonitorCacheData, k)
+	}
+	// The data that is left in healthMonitorCacheData should be explicitly removed
+	for key := range healthMonitorCacheData {
+		utils.AviLog.Infof("Deleting key from healthmonitor cache :%s", key)
+		c.HealthMonitorCache.AviCacheDelete(key)
+	}
+}
+
+func (c *AviObjCache) AviPopulateAllNetworkSecurityPolicies(client *clients.AviClient, networkSecurityPolicyData *[]AviNetworkSecurityPolicyCache, overrideUri ...NextPage) (*[]AviNetworkSecurityPolicyCache, int, error) {
+	var uri string
+	akoUser := lib.AKOUser
+
+	if len(overrideUri) == 1 {
+		uri = overrideUri[0].NextURI
+	} else {
+		uri = "/api/networksecuritypolicy/?" + "&include_name=true&" + "&created_by=" + akoUser + "&page_size=100"
+	}
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for networksecuritypolicy %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		networkSecurityPolicy := models.NetworkSecurityPolicy{}
+		err = json.Unmarshal(elems[i], &networkSecurityPolicy)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+			continue
+		}
+		if networkSecurityPolicy.Name == nil || networkSecurityPolicy.UUID == nil {
+			utils.AviLog.Warnf("Incomplete networksecuritypolicy data unmarshalled, %s", utils.Stringify(networkSecurityPolicy))
+			continue
+		}
+		*networkSecurityPolicyData = append(*networkSecurityPolicyData, AviNetworkSecurityPolicyCache{
+			Name:             *networkSecurityPolicy.Name,
+			Uuid:             *networkSecurityPolicy.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: networkSecurityPolicyChecksum(&networkSecurityPolicy),
+		})
+	}
+	if result.Next != "" {
+		// It has a next page, let's recursively call the same method.
+		next_uri := strings.Split(result.Next, "/api/networksecuritypolicy")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/networksecuritypolicy" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllNetworkSecurityPolicies(client, networkSecurityPolicyData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return networkSecurityPolicyData, result.Count, nil
+}
+
+func networkSecurityPolicyChecksum(networkSecurityPolicy *models.NetworkSecurityPolicy) uint32 {
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	return lib.NetworkSecurityPolicyChecksum(*networkSecurityPolicy.Name, lib.GetNetworkSecurityPolicySourceRanges(networkSecurityPolicy),
+		emptyIngestionMarkers, networkSecurityPolicy.Markers, true)
+}
+
+func (c *AviObjCache) PopulateNetworkSecurityPoliciesToCache(client *clients.AviClient, overrideUri ...NextPage) {
+	var networkSecurityPolicyData []AviNetworkSecurityPolicyCache
+	c.AviPopulateAllNetworkSecurityPolicies(client, &networkSecurityPolicyData)
+
+	networkSecurityPolicyCacheData := c.NetworkSecurityPolicyCache.ShallowCopy()
+	for i, networkSecurityPolicyCacheObj := range networkSecurityPolicyData {
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: networkSecurityPolicyCacheObj.Name}
+		utils.AviLog.Infof("Adding key to networksecuritypolicy cache :%s value :%s", k, networkSecurityPolicyCacheObj.Uuid)
+		c.NetworkSecurityPolicyCache.AviCacheAdd(k, &networkSecurityPolicyData[i])
+		delete(networkSecurityPolicyCacheData, k)
+	}
+	// The data that is left in networkSecurityPolicyCacheData should be explicitly removed
+	for key := range networkSecurityPolicyCacheData {
+		utils.AviLog.Infof("Deleting key from networksecuritypolicy cache :%s", key)
+		c.NetworkSecurityPolicyCache.AviCacheDelete(key)
+	}
+}
+
 func (c *AviObjCache) PopulatePoolsToCache(client *clients.AviClient, cloud string, overrideUri ...NextPage) {
 	var poolsData []AviPoolCache
 	c.AviPopulateAllPools(client, cloud, &poolsData)
@@ -1101,6 +1507,162 @@ func (c *AviObjCache) AviPopulateOnePKICache(client *clients.AviClient,
 	return nil
 }
 
+func (c *AviObjCache) AviPopulateOneAppProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/applicationprofile?name=" + objName + "&include_name=true&created_by=" + lib.AKOUser
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		appProfileCacheObj := AviAppProfileCache{
+			Name:             *appProfile.Name,
+			Uuid:             *appProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: appProfileChecksum(&appProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *appProfile.Name}
+		c.AppProfileCache.AviCacheAdd(k, &appProfileCacheObj)
+		utils.AviLog.Debugf("Adding applicationprofile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOnePersistenceProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/applicationpersistenceprofile?name=" + objName + "&include_name=true"
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationpersistenceprofile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+		persistenceProfileCacheObj := AviPersistenceProfileCache{
+			Name:             *persistenceProfile.Name,
+			Uuid:             *persistenceProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: persistenceProfileChecksum(&persistenceProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *persistenceProfile.Name}
+		c.PersistenceProfileCache.AviCacheAdd(k, &persistenceProfileCacheObj)
+		utils.AviLog.Debugf("Adding applicationpersistenceprofile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneNetworkSecurityPolicyCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/networksecuritypolicy?name=" + objName + "&include_name=true&created_by=" + lib.AKOUser
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for networksecuritypolicy %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		networkSecurityPolicy := models.NetworkSecurityPolicy{}
+		err = json.Unmarshal(elems[i], &networkSecurityPolicy)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal networksecuritypolicy data, err: %v", err)
+			continue
+		}
+		if networkSecurityPolicy.Name == nil || networkSecurityPolicy.UUID == nil {
+			utils.AviLog.Warnf("Incomplete networksecuritypolicy data unmarshalled, %s", utils.Stringify(networkSecurityPolicy))
+			continue
+		}
+		networkSecurityPolicyCacheObj := AviNetworkSecurityPolicyCache{
+			Name:             *networkSecurityPolicy.Name,
+			Uuid:             *networkSecurityPolicy.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: networkSecurityPolicyChecksum(&networkSecurityPolicy),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *networkSecurityPolicy.Name}
+		c.NetworkSecurityPolicyCache.AviCacheAdd(k, &networkSecurityPolicyCacheObj)
+		utils.AviLog.Debugf("Adding networksecuritypolicy to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneHealthMonitorCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	uri := "/api/healthmonitor?name=" + objName + "&include_name=true"
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		healthMonitor := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &healthMonitor)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if healthMonitor.Name == nil || healthMonitor.UUID == nil {
+			utils.AviLog.Warnf("Incomplete healthmonitor data unmarshalled, %s", utils.Stringify(healthMonitor))
+			continue
+		}
+		healthMonitorCacheObj := AviHealthMonitorCache{
+			Name:             *healthMonitor.Name,
+			Uuid:             *healthMonitor.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: HealthMonitorChecksum(&healthMonitor),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *healthMonitor.Name}
+		c.HealthMonitorCache.AviCacheAdd(k, &healthMonitorCacheObj)
+		utils.AviLog.Debugf("Adding healthmonitor to Cache during refresh %s", k)
+	}
+	return nil
+}
+
 func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 	cloud string, objName string) error {
 	var uri string
@@ -1152,12 +1714,13 @@ func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 		}
 
 		poolCacheObj := AviPoolCache{
-			Name:                 *pool.Name,
-			Uuid:                 *pool.UUID,
-			CloudConfigCksum:     *pool.CloudConfigCksum,
-			PkiProfileCollection: pkiKey,
-			ServiceMetadataObj:   svc_mdata_obj,
-			LastModified:         *pool.LastModified,
+			Name:                    *pool.Name,
+			Uuid:                    *pool.UUID,
+			CloudConfigCksum:        *pool.CloudConfigCksum,
+			PkiProfileCollection:    pkiKey,
+			HealthMonitorCollection: c.GetHealthMonitorCollection(pool.HealthMonitorRefs),
+			ServiceMetadataObj:      svc_mdata_obj,
+			LastModified:            *pool.LastModified,
 		}
 		k := NamespaceName{Namespace: lib.GetTenant(), Name: *pool.Name}
 		c.PoolCache.AviCacheAdd(k, &poolCacheObj)
@@ -1476,18 +2039,14 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 			continue
 		}
 		// Fetch the pools associated with the l4 policyset object
-		var pools []string
+		var pools, poolGroups []string
 		var ports []int64
 		var protocols []string
 		if l4pol.L4ConnectionPolicy != nil {
 			for _, rule := range l4pol.L4ConnectionPolicy.Rules {
 				protocols = append(protocols, *rule.Match.Protocol.Protocol)
 				if rule.Action != nil {
-					poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
-					}
+					pools, poolGroups = c.appendL4RulePools(rule.Action.SelectPool, pools, poolGroups)
 				}
 				if rule.Match != nil {
 					ports = append(ports, rule.Match.Port.Ports...)
@@ -1495,11 +2054,12 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 			}
 		}
 		emptyIngestionMarkers := utils.AviObjectMarkers{}
-		cksum := lib.L4PolicyChecksum(ports, protocols, emptyIngestionMarkers, l4pol.Markers, true)
+		cksum := lib.L4PolicyChecksum(ports, protocols, poolGroups, emptyIngestionMarkers, l4pol.Markers, true)
 		l4PolCacheObj := AviL4PolicyCache{
 			Name:             *l4pol.Name,
 			Uuid:             *l4pol.UUID,
 			Pools:            pools,
+			PoolGroups:       poolGroups,
 			LastModified:     *l4pol.LastModified,
 			CloudConfigCksum: cksum,
 		}
@@ -1688,7 +2248,7 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 
 		// Fetch the pgs associated with the http policyset object
 		// Fetch the pools associated with the l4 policyset object
-		var pools []string
+		var pools, poolGroups []string
 		var ports []int64
 		var protocols []string
 		if l4pol.L4ConnectionPolicy != nil {
@@ -1701,11 +2261,7 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 						protocol = utils.UDP
 					}
 					protocols = append(protocols, protocol)
-					poolUuid := ExtractUuid(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
-					}
+					pools, poolGroups = c.appendL4RulePools(rule.Action.SelectPool, pools, poolGroups)
 				}
 				if rule.Match != nil {
 					ports = append(ports, rule.Match.Port.Ports...)
@@ -1714,11 +2270,12 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 		}
 
 		emptyIngestionMarkers := utils.AviObjectMarkers{}
-		cksum := lib.L4PolicyChecksum(ports, protocols, emptyIngestionMarkers, l4pol.Markers, true)
+		cksum := lib.L4PolicyChecksum(ports, protocols, poolGroups, emptyIngestionMarkers, l4pol.Markers, true)
 		l4PolCacheObj := AviL4PolicyCache{
 			Name:             *l4pol.Name,
 			Uuid:             *l4pol.UUID,
 			Pools:            pools,
+			PoolGroups:       poolGroups,
 			LastModified:     *l4pol.LastModified,
 			CloudConfigCksum: cksum,
 		}
@@ -1984,6 +2541,12 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 									poolKey := NamespaceName{Namespace: lib.GetTenant(), Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
+								for _, pgName := range l4Obj.(*AviL4PolicyCache).PoolGroups {
+									pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName}
+									poolgroupKeys = append(poolgroupKeys, pgKey)
+									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
+									poolKeys = append(poolKeys, pgpoolKeys...)
+								}
 								l4Keys = append(l4Keys, l4key)
 							}
 						}
@@ -2224,6 +2787,12 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 									poolKey := NamespaceName{Namespace: lib.GetTenant(), Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
+								for _, pgName := range l4Obj.(*AviL4PolicyCache).PoolGroups {
+									pgKey := NamespaceName{Namespace: lib.GetTenant(), Name: pgName}
+									poolgroupKeys = append(poolgroupKeys, pgKey)
+									pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, pgName)
+									poolKeys = append(poolKeys, pgpoolKeys...)
+								}
 								l4Keys = append(l4Keys, l4key)
 							}
 						}
@@ -2720,6 +3289,10 @@ func ConfigureSeGroupLabels(client *clients.AviClient, seGroup *models.ServiceEn
 
 	labels := seGroup.Labels
 	segName := *seGroup.Name
+	if len(labels) == 0 && lib.IsDryRunEnabled() {
+		utils.AviLog.Infof("Dry run, skipped the configuration of labels %s on SE Group %s", utils.Stringify(lib.GetLabels()), segName)
+		return nil
+	}
 	SetAdminTenant := session.SetTenant(lib.GetAdminTenant())
 	SetTenant := session.SetTenant(lib.GetTenant())
 	if len(labels) == 0 {
@@ -2757,7 +3330,7 @@ func ConfigureSeGroupLabels(client *clients.AviClient, seGroup *models.ServiceEn
 // DeConfigureSeGroupLabels deconfigures labels on the SeGroup.
 func DeConfigureSeGroupLabels() {
 
-	if !lib.AKOControlConfig().IsLeader() {
+	if !lib.AKOControlConfig().IsLeader() || lib.IsDryRunEnabled() {
 		return
 	}
 
@@ -3347,3 +3920,24 @@ func ExtractUuidWithoutHash(word, pattern string) string {
 	}
 	return ""
 }
+
+// appendL4RulePools appends the name of the pool or the pool group, which the L4 rule selects, to the pools
+// or the pool groups of the L4 policy set.
+func (c *AviObjCache) appendL4RulePools(selectPool *models.L4RuleActionSelectPool, pools, poolGroups []string) ([]string, []string) {
+	if selectPool == nil {
+		return pools, poolGroups
+	}
+	if selectPool.PoolGroupRef != nil {
+		pgUuid := ExtractUuid(*selectPool.PoolGroupRef, "poolgroup-.*.#")
+		if pgName, found := c.PgCache.AviCacheGetNameByUuid(pgUuid); found {
+			poolGroups = append(poolGroups, pgName.(string))
+		}
+	}
+	if selectPool.PoolRef != nil {
+		poolUuid := ExtractUuid(*selectPool.PoolRef, "pool-.*.#")
+		if poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid); found {
+			pools = append(pools, poolName.(string))
+		}
+	}
+	return pools, poolGroups
+}
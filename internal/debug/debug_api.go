@@ -0,0 +1,416 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package debug implements the read-only debug API of the AKO API server, which returns the models built by the
+// graph layer, the Avi controller object cache, the Kubernetes objects tied to a virtual service and the REST
+// operations planned in the dry run mode.
+// It is kept out of pkg/api/models, since the packages it reads import pkg/api/models.
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/rest"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const redactedValue = "REDACTED"
+
+// Model implements models.ApiModel, and serves the debug API.
+type Model struct{}
+
+func NewModel() *Model {
+	return &Model{}
+}
+
+func (a *Model) InitModel() {}
+
+func (a *Model) ApiOperationMap() []models.OperationMap {
+	return []models.OperationMap{
+		{
+			Route:   "/api/debug/models",
+			Method:  "GET",
+			Handler: getModelNames,
+		},
+		{
+			Route:   "/api/debug/model",
+			Method:  "GET",
+			Handler: getModel,
+		},
+		{
+			Route:   "/api/debug/vscache",
+			Method:  "GET",
+			Handler: getVSCache,
+		},
+		{
+			Route:   "/api/debug/k8sobjects",
+			Method:  "GET",
+			Handler: getKubernetesObjects,
+		},
+		{
+			Route:   "/api/debug/plan",
+			Method:  "GET",
+			Handler: getDryRunPlan,
+		},
+	}
+}
+
+// ModelNode is a node of the model built by the graph layer.
+type ModelNode struct {
+	Type string          `json:"type"`
+	Node json.RawMessage `json:"node"`
+}
+
+// ModelResponse is the model built by the graph layer for a model name.
+type ModelResponse struct {
+	Name          string      `json:"name"`
+	GraphChecksum uint32      `json:"graph_checksum"`
+	RetryCount    int         `json:"retry_count"`
+	Nodes         []ModelNode `json:"nodes"`
+}
+
+// VSCacheResponse is the cache of a virtual service, along with the caches of its child objects.
+type VSCacheResponse struct {
+	VirtualService       *cache.AviVsCache  `json:"virtualservice"`
+	PoolGroups           []interface{}      `json:"poolgroups,omitempty"`
+	Pools                []interface{}      `json:"pools,omitempty"`
+	PKIProfiles          []interface{}      `json:"pkiprofiles,omitempty"`
+	HealthMonitors       []interface{}      `json:"healthmonitors,omitempty"`
+	VSVips               []interface{}      `json:"vsvips,omitempty"`
+	HTTPPolicySets       []interface{}      `json:"httppolicysets,omitempty"`
+	DataScripts          []interface{}      `json:"vsdatascriptsets,omitempty"`
+	SSLKeyCerts          []interface{}      `json:"sslkeyandcertificates,omitempty"`
+	L4PolicySets         []interface{}      `json:"l4policysets,omitempty"`
+	ChildVirtualServices []*VSCacheResponse `json:"child_virtualservices,omitempty"`
+}
+
+// KubernetesObjectsResponse holds the namespaced names of the Kubernetes objects tied to a virtual service, as found
+// in the service metadata of the virtual service, its child virtual services and pools, and in the relationship
+// listers of the ingestion layer.
+type KubernetesObjectsResponse struct {
+	Ingresses        []string `json:"ingresses,omitempty"`
+	Services         []string `json:"services,omitempty"`
+	Secrets          []string `json:"secrets,omitempty"`
+	Gateways         []string `json:"gateways,omitempty"`
+	HostNames        []string `json:"hostnames,omitempty"`
+	HostRules        []string `json:"hostrules,omitempty"`
+	HTTPRules        []string `json:"httprules,omitempty"`
+	AviInfraSettings []string `json:"aviinfrasettings,omitempty"`
+}
+
+// DryRunPlanResponse holds the REST operations planned in the dry run mode, for each virtual service.
+type DryRunPlanResponse struct {
+	DryRun bool                             `json:"dry_run"`
+	Plans  map[string][]*rest.PlannedRestOp `json:"plans"`
+}
+
+func getModelNames(w http.ResponseWriter, r *http.Request) {
+	modelNames := []string{}
+	for _, modelName := range objects.SharedAviGraphLister().AviGraphStore.GetAllKeys() {
+		_, model := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel, ok := model.(*nodes.AviObjectGraph); ok && aviModel != nil {
+			modelNames = append(modelNames, modelName)
+		}
+	}
+	sort.Strings(modelNames)
+	utils.Respond(w, modelNames)
+}
+
+func getModel(w http.ResponseWriter, r *http.Request) {
+	modelName := r.URL.Query().Get("name")
+	if modelName == "" {
+		respondError(w, http.StatusBadRequest, "query parameter name is required")
+		return
+	}
+	found, model := objects.SharedAviGraphLister().Get(modelName)
+	aviModel, ok := model.(*nodes.AviObjectGraph)
+	if !found || !ok || aviModel == nil {
+		respondError(w, http.StatusNotFound, "model "+modelName+" not found")
+		return
+	}
+	response, err := buildModelResponse(modelName, aviModel)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.Respond(w, response)
+}
+
+func getVSCache(w http.ResponseWriter, r *http.Request) {
+	vsKey, ok := vsKeyFromRequest(w, r)
+	if !ok {
+		return
+	}
+	response := buildVSCacheResponse(cache.SharedAviObjCache(), vsKey)
+	if response == nil {
+		respondError(w, http.StatusNotFound, "virtualservice "+vsKey.Namespace+"/"+vsKey.Name+" not found in the cache")
+		return
+	}
+	utils.Respond(w, response)
+}
+
+func getKubernetesObjects(w http.ResponseWriter, r *http.Request) {
+	vsKey, ok := vsKeyFromRequest(w, r)
+	if !ok {
+		return
+	}
+	response := buildVSCacheResponse(cache.SharedAviObjCache(), vsKey)
+	if response == nil {
+		respondError(w, http.StatusNotFound, "virtualservice "+vsKey.Namespace+"/"+vsKey.Name+" not found in the cache")
+		return
+	}
+	utils.Respond(w, buildKubernetesObjectsResponse(response))
+}
+
+func getDryRunPlan(w http.ResponseWriter, r *http.Request) {
+	utils.Respond(w, &DryRunPlanResponse{
+		DryRun: lib.IsDryRunEnabled(),
+		Plans:  rest.GetDryRunPlan(),
+	})
+}
+
+// vsKeyFromRequest returns the cache key of the virtual service given by the name and the optional tenant query
+// parameters.
+func vsKeyFromRequest(w http.ResponseWriter, r *http.Request) (cache.NamespaceName, bool) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		respondError(w, http.StatusBadRequest, "query parameter name is required")
+		return cache.NamespaceName{}, false
+	}
+	tenant := r.URL.Query().Get("tenant")
+	if tenant == "" {
+		tenant = lib.GetTenant()
+	}
+	return cache.NamespaceName{Namespace: tenant, Name: name}, true
+}
+
+func respondError(w http.ResponseWriter, status int, message string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func buildModelResponse(modelName string, aviModel *nodes.AviObjectGraph) (*ModelResponse, error) {
+	aviModel.Lock.RLock()
+	defer aviModel.Lock.RUnlock()
+	response := &ModelResponse{
+		Name:          modelName,
+		GraphChecksum: aviModel.GraphChecksum,
+		RetryCount:    aviModel.RetryCount,
+		Nodes:         []ModelNode{},
+	}
+	for _, node := range aviModel.GetOrderedNodes() {
+		nodeJSON, err := redactedJSON(node)
+		if err != nil {
+			return nil, err
+		}
+		response.Nodes = append(response.Nodes, ModelNode{Type: node.GetNodeType(), Node: nodeJSON})
+	}
+	return response, nil
+}
+
+// redactedJSON marshals a model node, replacing the private keys of the TLS key and certificate nodes it contains.
+func redactedJSON(node interface{}) (json.RawMessage, error) {
+	nodeJSON, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	var nodeObj interface{}
+	if err = json.Unmarshal(nodeJSON, &nodeObj); err != nil {
+		return nil, err
+	}
+	redactKeys(nodeObj)
+	return json.Marshal(nodeObj)
+}
+
+func redactKeys(obj interface{}) {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		_, hasKey := value["Key"]
+		_, hasCert := value["Cert"]
+		if hasKey && hasCert && value["Key"] != nil {
+			value["Key"] = redactedValue
+		}
+		for _, field := range value {
+			redactKeys(field)
+		}
+	case []interface{}:
+		for _, elem := range value {
+			redactKeys(elem)
+		}
+	}
+}
+
+func buildVSCacheResponse(aviObjCache *cache.AviObjCache, vsKey cache.NamespaceName) *VSCacheResponse {
+	vsCache, found := aviObjCache.VsCacheMeta.AviCacheGet(vsKey)
+	if !found {
+		return nil
+	}
+	vsCacheObj, ok := vsCache.(*cache.AviVsCache)
+	if !ok {
+		return nil
+	}
+	vsCopy, ok := vsCacheObj.GetVSCopy()
+	if !ok {
+		return nil
+	}
+	response := &VSCacheResponse{
+		VirtualService: vsCopy,
+		PoolGroups:     getCacheObjects(aviObjCache.PgCache, vsCopy.PGKeyCollection),
+		Pools:          getCacheObjects(aviObjCache.PoolCache, vsCopy.PoolKeyCollection),
+		VSVips:         getCacheObjects(aviObjCache.VSVIPCache, vsCopy.VSVipKeyCollection),
+		HTTPPolicySets: getCacheObjects(aviObjCache.HTTPPolicyCache, vsCopy.HTTPKeyCollection),
+		DataScripts:    getCacheObjects(aviObjCache.DSCache, vsCopy.DSKeyCollection),
+		SSLKeyCerts:    getCacheObjects(aviObjCache.SSLKeyCache, vsCopy.SSLKeyCertCollection),
+		L4PolicySets:   getCacheObjects(aviObjCache.L4PolicyCache, vsCopy.L4PolicyCollection),
+	}
+	for _, pool := range response.Pools {
+		poolCache := pool.(*cache.AviPoolCache)
+		if poolCache.PkiProfileCollection.Name != "" {
+			response.PKIProfiles = append(response.PKIProfiles, getCacheObjects(aviObjCache.PKIProfileCache, []cache.NamespaceName{poolCache.PkiProfileCollection})...)
+		}
+		response.HealthMonitors = append(response.HealthMonitors, getCacheObjects(aviObjCache.HealthMonitorCache, poolCache.HealthMonitorCollection)...)
+	}
+	for _, childUuid := range vsCopy.SNIChildCollection {
+		childKey, found := aviObjCache.VsCacheMeta.AviCacheGetKeyByUuid(childUuid)
+		if !found {
+			continue
+		}
+		if childResponse := buildVSCacheResponse(aviObjCache, childKey.(cache.NamespaceName)); childResponse != nil {
+			response.ChildVirtualServices = append(response.ChildVirtualServices, childResponse)
+		}
+	}
+	return response
+}
+
+func getCacheObjects(aviCache *cache.AviCache, keys []cache.NamespaceName) []interface{} {
+	var cacheObjects []interface{}
+	for _, key := range keys {
+		if cacheObj, found := aviCache.AviCacheGet(key); found && cacheObj != nil {
+			cacheObjects = append(cacheObjects, cacheObj)
+		}
+	}
+	return cacheObjects
+}
+
+// serviceMetadata returns the service metadata of the virtual service, its child virtual services and its pools.
+func (v *VSCacheResponse) serviceMetadata() []lib.ServiceMetadataObj {
+	metadata := []lib.ServiceMetadataObj{v.VirtualService.ServiceMetadataObj}
+	for _, pool := range v.Pools {
+		metadata = append(metadata, pool.(*cache.AviPoolCache).ServiceMetadataObj)
+	}
+	for _, child := range v.ChildVirtualServices {
+		metadata = append(metadata, child.serviceMetadata()...)
+	}
+	return metadata
+}
+
+func buildKubernetesObjectsResponse(vsCache *VSCacheResponse) *KubernetesObjectsResponse {
+	ingresses := make(map[string]bool)
+	services := make(map[string]bool)
+	secrets := make(map[string]bool)
+	gateways := make(map[string]bool)
+	hostNames := make(map[string]bool)
+	hostRules := make(map[string]bool)
+	httpRules := make(map[string]bool)
+	infraSettings := make(map[string]bool)
+
+	for _, metadata := range vsCache.serviceMetadata() {
+		for _, ingress := range metadata.NamespaceIngressName {
+			ingresses[ingress] = true
+		}
+		if metadata.IngressName != "" && metadata.Namespace != "" {
+			ingresses[metadata.Namespace+"/"+metadata.IngressName] = true
+		}
+		for _, service := range metadata.NamespaceServiceName {
+			services[service] = true
+		}
+		for _, hostName := range metadata.HostNames {
+			hostNames[hostName] = true
+		}
+		if metadata.Gateway != "" {
+			gateways[metadata.Gateway] = true
+		}
+	}
+
+	// The ingresses and the routes are mapped in separate listers, only one of which is in use.
+	svcListers := []*objects.SvcLister{objects.SharedSvcLister(), objects.OshiftRouteSvcLister()}
+	for ingress := range ingresses {
+		namespace, name := utils.ExtractNamespaceObjectName(ingress)
+		if name == "" {
+			continue
+		}
+		for _, svcLister := range svcListers {
+			if found, svcNames := svcLister.IngressMappings(namespace).GetIngToSvc(name); found {
+				for _, svcName := range svcNames {
+					services[namespacedName(namespace, svcName)] = true
+				}
+			}
+			if found, secretNames := svcLister.IngressMappings(namespace).GetIngToSecret(name); found {
+				for _, secretName := range secretNames {
+					secrets[namespacedName(namespace, secretName)] = true
+				}
+			}
+		}
+		if found, infraSetting := objects.InfraSettingL7Lister().GetIngRouteToInfraSetting(ingress); found {
+			infraSettings[infraSetting] = true
+		}
+	}
+	for hostName := range hostNames {
+		if found, hostRule := objects.SharedCRDLister().GetFQDNToHostruleMapping(hostName); found {
+			hostRules[hostRule] = true
+		}
+		if found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(hostName); found {
+			for _, httpRule := range pathRules {
+				httpRules[httpRule] = true
+			}
+		}
+	}
+
+	return &KubernetesObjectsResponse{
+		Ingresses:        sortedKeys(ingresses),
+		Services:         sortedKeys(services),
+		Secrets:          sortedKeys(secrets),
+		Gateways:         sortedKeys(gateways),
+		HostNames:        sortedKeys(hostNames),
+		HostRules:        sortedKeys(hostRules),
+		HTTPRules:        sortedKeys(httpRules),
+		AviInfraSettings: sortedKeys(infraSettings),
+	}
+}
+
+// namespacedName prefixes the namespace to the name of an object, unless it is already namespaced.
+func namespacedName(namespace, name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func sortedKeys(set map[string]bool) []string {
+	var keys []string
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -478,6 +478,7 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	// set up signals so we handle the first shutdown signal gracefully
 	var worker *utils.FullSyncThread
 	var tokenWorker *utils.FullSyncThread
+	var podReadinessWorker *utils.FullSyncThread
 	informersArg := make(map[string]interface{})
 	informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = informers.OshiftClient
 	if lib.GetNamespaceToSync() != "" {
@@ -599,6 +600,11 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 		tokenWorker.SyncFunction = c.RefreshAuthToken
 		go tokenWorker.Run()
 	}
+	if lib.IsPodReadinessGateEnabled() {
+		podReadinessWorker = utils.NewFullSyncThread(time.Duration(PodReadinessGateSyncInterval) * time.Second)
+		podReadinessWorker.SyncFunction = c.SyncPodReadinessGates
+		go podReadinessWorker.Run()
+	}
 	if lib.DisableSync {
 		lib.AKOControlConfig().PodEventf(corev1.EventTypeNormal, lib.AKODeleteConfigSet, "AKO is in disable sync state")
 	} else {
@@ -633,6 +639,9 @@ LABEL:
 	if worker != nil {
 		worker.Shutdown()
 	}
+	if podReadinessWorker != nil {
+		podReadinessWorker.Shutdown()
+	}
 
 	cancel()
 	if !lib.IsWCP() {
@@ -754,6 +763,7 @@ func (c *AviController) addIndexers() {
 }
 
 func (c *AviController) FullSync() {
+	defer utils.ObserveFullSync("avi", time.Now())
 	aviRestClientPool := avicache.SharedAVIClients()
 	aviObjCache := avicache.SharedAviObjCache()
 
@@ -793,6 +803,7 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 		utils.AviLog.Infof("Sync disabled, skipping full sync")
 		return nil
 	}
+	defer utils.ObserveFullSync("kubernetes", time.Now())
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 	var vrfModelName string
 	if lib.GetDisableStaticRoute() && !lib.IsNodePortMode() {
@@ -1098,6 +1109,11 @@ func (c *AviController) FullSyncK8s(sync bool) error {
 				}
 			}
 		}
+		if lib.UseGatewayAPI() && c.dynamicInformers != nil && c.dynamicInformers.GatewayClassInformer != nil {
+			if err := c.fullSyncGatewayAPIObjects(); err != nil {
+				return err
+			}
+		}
 	} else {
 		//Gateway Section
 
@@ -1279,6 +1295,8 @@ func SyncFromIngestionLayer(key interface{}, wg *sync.WaitGroup) error {
 		utils.AviLog.Warnf("Unexpected object type: expected string, got %T", key)
 		return nil
 	}
+	span := utils.StartKeySpan("ingestion", utils.ObjectIngestionLayer, keyStr)
+	defer span.End()
 	nodes.DequeueIngestion(keyStr, false)
 	return nil
 }
@@ -1289,6 +1307,8 @@ func SyncFromFastRetryLayer(key interface{}, wg *sync.WaitGroup) error {
 		utils.AviLog.Warnf("Unexpected object type: expected string, got %T", key)
 		return nil
 	}
+	span := utils.StartKeySpan("retry", lib.FAST_RETRY_LAYER, keyStr)
+	defer span.End()
 	retry.DequeueFastRetry(keyStr)
 	return nil
 }
@@ -1299,6 +1319,8 @@ func SyncFromSlowRetryLayer(key interface{}, wg *sync.WaitGroup) error {
 		utils.AviLog.Warnf("Unexpected object type: expected string, got %T", key)
 		return nil
 	}
+	span := utils.StartKeySpan("retry", lib.SLOW_RETRY_LAYER, keyStr)
+	defer span.End()
 	retry.DequeueSlowRetry(keyStr)
 	return nil
 }
@@ -1312,12 +1334,19 @@ func SyncFromNodesLayer(key interface{}, wg *sync.WaitGroup) error {
 	cache := avicache.SharedAviObjCache()
 	aviclient := avicache.SharedAVIClients()
 	restlayer := rest.NewRestOperations(cache, aviclient)
+	span := utils.StartKeySpan("rest", utils.GraphLayer, keyStr)
+	defer span.End()
 	restlayer.DequeueNodes(keyStr)
 	return nil
 }
 
 func SyncFromStatusQueue(key interface{}, wg *sync.WaitGroup) error {
 	publisher := status.NewStatusPublisher()
+	if statusOption, ok := key.(status.StatusOptions); ok {
+		span := utils.StartKeySpan("status", utils.StatusQueue, statusOption.Key)
+		span.SetAttribute("ako.status.object", statusOption.ObjType)
+		defer span.End()
+	}
 	publisher.DequeueStatus(key)
 	return nil
 }
@@ -17,6 +17,7 @@ package k8s
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -27,6 +28,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -52,6 +54,8 @@ var ctrlonce sync.Once
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;delete;update;patch
 // +kubebuilder:rbac:groups=core,resources=services;services/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
@@ -83,6 +87,26 @@ func SharedAviController() *AviController {
 	return controllerInstance
 }
 
+// enqueueZoneAwareInfraSettings adds the AviInfraSettings with zone awareness to the ingestion queue, so that the
+// servers of their pools are split again by zone after the zone of a node changes.
+func (c *AviController) enqueueZoneAwareInfraSettings(nodeKey string, numWorkers uint32) {
+	infraSettings, err := lib.AKOControlConfig().CRDInformers().AviInfraSettingInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the AviInfraSettings for the zone change: %v", nodeKey, err)
+		return
+	}
+	for _, infraSetting := range infraSettings {
+		if len(infraSetting.Spec.ZoneAwareness.Zones) == 0 {
+			continue
+		}
+		namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(infraSetting))
+		key := lib.AviInfraSetting + "/" + utils.ObjKey(infraSetting)
+		bkt := utils.Bkt(namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: zone of node changed, resyncing %s", nodeKey, key)
+	}
+}
+
 func isNodeUpdated(oldNode, newNode *corev1.Node) bool {
 	oldPodCIDRAnnotation, oldOk := oldNode.Annotations[lib.StaticRouteAnnotation]
 	newPodCIDRAnnotation, newOk := newNode.Annotations[lib.StaticRouteAnnotation]
@@ -121,6 +145,12 @@ func isNodeUpdated(oldNode, newNode *corev1.Node) bool {
 		return true
 	}
 
+	// The servers of the nodes which are cordoned or being deleted are drained in NodePort mode.
+	if oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+		(oldNode.GetDeletionTimestamp() == nil) != (newNode.GetDeletionTimestamp() == nil) {
+		return true
+	}
+
 	if !reflect.DeepEqual(oldNode.Labels, newNode.Labels) {
 		return true
 	}
@@ -535,6 +565,128 @@ func AddPodEventHandler(numWorkers uint32, c *AviController) cache.ResourceEvent
 	return podEventHandler
 }
 
+// AddPodReadinessGateEventHandler returns the event handler for the Pods with the pool server readiness gate.
+// The servers of such Pods are added to the pools once their containers are ready, which does not update the
+// EndpointSlices, hence the Endpoints of the Services of the Pod are published.
+func AddPodReadinessGateEventHandler(numWorkers uint32, c *AviController) cache.ResourceEventHandler {
+	podEventHandler := cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldPod := old.(*corev1.Pod)
+			newPod := cur.(*corev1.Pod)
+			if !lib.HasPodReadinessGate(newPod) || isPodContainersReady(oldPod) == isPodContainersReady(newPod) {
+				return
+			}
+			if lib.IsNamespaceBlocked(newPod.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: Pod Update event: Namespace: %s didn't qualify filter", utils.ObjKey(newPod), newPod.Namespace)
+				return
+			}
+			services, _ := lib.GetServicesForPod(newPod)
+			bkt := utils.Bkt(newPod.Namespace, numWorkers)
+			for _, svc := range services {
+				if !strings.HasPrefix(svc, newPod.Namespace+"/") {
+					continue
+				}
+				key := utils.Endpoints + "/" + svc
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE for containers ready of Pod %s", key, newPod.Name)
+			}
+		},
+	}
+	return podEventHandler
+}
+
+func isPodContainersReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.ContainersReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// AddEndpointSliceEventHandler returns the event handler for the EndpointSlices. The EndpointSlices are
+// published with the key of the Endpoints of their Service, so that all the slices of a Service are
+// processed together.
+func AddEndpointSliceEventHandler(numWorkers uint32, c *AviController) cache.ResourceEventHandler {
+	epSliceEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			epSlice := obj.(*discovery.EndpointSlice)
+			svcName, ok := epSlice.Labels[discovery.LabelServiceName]
+			if !ok {
+				return
+			}
+			key := utils.Endpoints + "/" + epSlice.Namespace + "/" + svcName
+			if lib.IsNamespaceBlocked(epSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Add event: Namespace: %s didn't qualify filter", key, epSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(epSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			epSlice, ok := obj.(*discovery.EndpointSlice)
+			if !ok {
+				// endpointslice was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				epSlice, ok = tombstone.Obj.(*discovery.EndpointSlice)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not an EndpointSlice: %#v", obj)
+					return
+				}
+			}
+			svcName, ok := epSlice.Labels[discovery.LabelServiceName]
+			if !ok {
+				return
+			}
+			key := utils.Endpoints + "/" + epSlice.Namespace + "/" + svcName
+			if lib.IsNamespaceBlocked(epSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Delete event: Namespace: %s didn't qualify filter", key, epSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(epSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oepSlice := old.(*discovery.EndpointSlice)
+			cepSlice := cur.(*discovery.EndpointSlice)
+			if reflect.DeepEqual(cepSlice.Endpoints, oepSlice.Endpoints) && reflect.DeepEqual(cepSlice.Ports, oepSlice.Ports) {
+				return
+			}
+			svcName, ok := cepSlice.Labels[discovery.LabelServiceName]
+			if !ok {
+				return
+			}
+			key := utils.Endpoints + "/" + cepSlice.Namespace + "/" + svcName
+			if lib.IsNamespaceBlocked(cepSlice.Namespace) {
+				utils.AviLog.Debugf("key: %s, msg: EndpointSlice Update event: Namespace: %s didn't qualify filter", key, cepSlice.Namespace)
+				return
+			}
+			bkt := utils.Bkt(cepSlice.Namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+		},
+	}
+	return epSliceEventHandler
+}
+
 func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 	mcpQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
 	c.workqueue = mcpQueue.Workqueue
@@ -735,6 +887,15 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 						// Handles no-annotation -> annotation transition too, old L4 VS is deleted.
 						oldKey = utils.L4LBService + "/" + utils.ObjKey(oldobj)
 					}
+				} else if !isSvcLb &&
+					!lib.GetLayer7Only() &&
+					svc.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+					isServiceLBType(oldobj) {
+					// Handles the loadBalancerClass of the service changing to one not handled by AKO, old L4 VS is deleted.
+					oldKey = utils.L4LBService + "/" + utils.ObjKey(oldobj)
+					if oldobj.Annotations[lib.SharedVipSvcLBAnnotation] != "" {
+						oldKey = lib.SharedVipServiceKey + "/" + utils.ObjKey(oldobj)
+					}
 				}
 				c.workqueue[bkt].AddRateLimited(key)
 				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
@@ -746,7 +907,12 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		},
 	}
 
-	c.informers.EpInformer.Informer().AddEventHandler(epEventHandler)
+	if c.informers.EpSlicesInformer != nil {
+		epSliceEventHandler := AddEndpointSliceEventHandler(numWorkers, c)
+		c.informers.EpSlicesInformer.Informer().AddEventHandler(epSliceEventHandler)
+	} else {
+		c.informers.EpInformer.Informer().AddEventHandler(epEventHandler)
+	}
 
 	c.informers.ServiceInformer.Informer().AddEventHandler(svcEventHandler)
 
@@ -1089,6 +1255,50 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		},
 	}
 
+	// The zones of the nodes are needed for the pools with zone awareness, irrespective of the static routes.
+	nodeZoneEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			node := obj.(*corev1.Node)
+			if node.Labels[corev1.LabelTopologyZone] != "" {
+				c.enqueueZoneAwareInfraSettings(utils.NodeObj+"/"+node.Name, numWorkers)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			node, ok := obj.(*corev1.Node)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				node, ok = tombstone.Obj.(*corev1.Node)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not an Node: %#v", obj)
+					return
+				}
+			}
+			if node.Labels[corev1.LabelTopologyZone] != "" {
+				c.enqueueZoneAwareInfraSettings(utils.NodeObj+"/"+node.Name, numWorkers)
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldobj := old.(*corev1.Node)
+			node := cur.(*corev1.Node)
+			if oldobj.Labels[corev1.LabelTopologyZone] != node.Labels[corev1.LabelTopologyZone] {
+				c.enqueueZoneAwareInfraSettings(utils.NodeObj+"/"+node.Name, numWorkers)
+			}
+		},
+	}
+
 	if c.informers.IngressInformer != nil {
 		c.informers.IngressInformer.Informer().AddEventHandler(ingressEventHandler)
 	}
@@ -1163,6 +1373,10 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		c.informers.NodeInformer.Informer().AddEventHandler(nodeEventHandler)
 	}
 
+	if c.informers.NodeInformer != nil && lib.AKOControlConfig().AviInfraSettingEnabled() {
+		c.informers.NodeInformer.Informer().AddEventHandler(nodeZoneEventHandler)
+	}
+
 	if c.informers.RouteInformer != nil {
 		routeEventHandler := AddRouteEventHandler(numWorkers, c)
 		c.informers.RouteInformer.Informer().AddEventHandler(routeEventHandler)
@@ -1177,6 +1391,17 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		c.SetupServiceImportEventHandlers(numWorkers)
 	}
 
+	// Add gateway.networking.k8s.io GatewayClass, Gateway and HTTPRoute event handlers
+	if lib.UseGatewayAPI() && c.dynamicInformers != nil && c.dynamicInformers.GatewayClassInformer != nil {
+		c.SetupGatewayAPIEventHandlers(numWorkers)
+	}
+
+	// Add gateway.networking.k8s.io ReferenceGrant event handler, the grants allow Ingresses to refer to
+	// Services in other namespaces.
+	if lib.IsReferenceGrantEnabled() {
+		c.dynamicInformers.ReferenceGrantInformer.Informer().AddEventHandler(c.gatewayAPIEventHandler(lib.ReferenceGrant, numWorkers))
+	}
+
 	//Add namespace event handler if migration is enabled and informer not nil
 	nsFilterObj := utils.GetGlobalNSFilter()
 	if nsFilterObj.EnableMigration && c.informers.NSInformer != nil {
@@ -1188,6 +1413,9 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 	if lib.GetServiceType() == lib.NodePortLocal {
 		podEventHandler := AddPodEventHandler(numWorkers, c)
 		c.informers.PodInformer.Informer().AddEventHandler(podEventHandler)
+	} else if lib.IsPodReadinessGateEnabled() {
+		podEventHandler := AddPodReadinessGateEventHandler(numWorkers, c)
+		c.informers.PodInformer.Informer().AddEventHandler(podEventHandler)
 	}
 }
 
@@ -1216,24 +1444,34 @@ func checkAviSecretUpdateAndShutdown(secret *corev1.Secret) bool {
 
 func (c *AviController) Start(stopCh <-chan struct{}) {
 	go c.informers.ServiceInformer.Informer().Run(stopCh)
-	go c.informers.EpInformer.Informer().Run(stopCh)
 	go c.informers.NSInformer.Informer().Run(stopCh)
 
 	informersList := []cache.InformerSynced{
-		c.informers.EpInformer.Informer().HasSynced,
 		c.informers.ServiceInformer.Informer().HasSynced,
 		c.informers.NSInformer.Informer().HasSynced,
 	}
 
+	if c.informers.EpSlicesInformer != nil {
+		go c.informers.EpSlicesInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.informers.EpSlicesInformer.Informer().HasSynced)
+	} else {
+		go c.informers.EpInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.informers.EpInformer.Informer().HasSynced)
+	}
+
 	if !lib.AviSecretInitialized {
 		go c.informers.SecretInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.informers.SecretInformer.Informer().HasSynced)
 	}
 
-	if lib.GetServiceType() == lib.NodePortLocal {
+	if lib.GetServiceType() == lib.NodePortLocal || lib.IsPodReadinessGateEnabled() || lib.IsReadinessProbeHealthMonitorEnabled() {
 		go c.informers.PodInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.informers.PodInformer.Informer().HasSynced)
 	}
+	if c.dynamicInformers != nil && c.dynamicInformers.PodInformer != nil {
+		go c.dynamicInformers.PodInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.dynamicInformers.PodInformer.Informer().HasSynced)
+	}
 	if lib.GetCNIPlugin() == lib.CALICO_CNI {
 		go c.dynamicInformers.CalicoBlockAffinityInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.dynamicInformers.CalicoBlockAffinityInformer.Informer().HasSynced)
@@ -1304,12 +1542,32 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 			informersList = append(informersList, lib.AKOControlConfig().CRDInformers().HTTPRuleInformer.Informer().HasSynced)
 		}
 
+		if lib.IsReferenceGrantEnabled() {
+			go c.dynamicInformers.ReferenceGrantInformer.Informer().Run(stopCh)
+			informersList = append(informersList, c.dynamicInformers.ReferenceGrantInformer.Informer().HasSynced)
+		}
+
 		if utils.IsMultiClusterIngressEnabled() {
 			go c.informers.MultiClusterIngressInformer.Informer().Run(stopCh)
 			informersList = append(informersList, c.informers.MultiClusterIngressInformer.Informer().HasSynced)
 			go c.informers.ServiceImportInformer.Informer().Run(stopCh)
 			informersList = append(informersList, c.informers.ServiceImportInformer.Informer().HasSynced)
 		}
+
+		if lib.UseGatewayAPI() && c.dynamicInformers != nil && c.dynamicInformers.GatewayClassInformer != nil {
+			go c.dynamicInformers.GatewayClassInformer.Informer().Run(stopCh)
+			informersList = append(informersList, c.dynamicInformers.GatewayClassInformer.Informer().HasSynced)
+			go c.dynamicInformers.GatewayInformer.Informer().Run(stopCh)
+			informersList = append(informersList, c.dynamicInformers.GatewayInformer.Informer().HasSynced)
+			go c.dynamicInformers.HTTPRouteInformer.Informer().Run(stopCh)
+			informersList = append(informersList, c.dynamicInformers.HTTPRouteInformer.Informer().HasSynced)
+			for _, kind := range []string{lib.TLSRoute, lib.TCPRoute, lib.UDPRoute} {
+				if routeInformer := lib.GetGatewayAPIRouteInformer(kind); routeInformer != nil {
+					go routeInformer.Informer().Run(stopCh)
+					informersList = append(informersList, routeInformer.Informer().HasSynced)
+				}
+			}
+		}
 	}
 
 	if !cache.WaitForCacheSync(stopCh, informersList...) {
@@ -1321,8 +1579,10 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 
 func isServiceLBType(svcObj *corev1.Service) bool {
 	// If we don't find a service or it is not of type loadbalancer - return false.
+	// The Services of type loadbalancer of a loadBalancerClass not handled by AKO are
+	// treated like the Services of other types, so that their virtualservice is removed.
 	if svcObj.Spec.Type == "LoadBalancer" {
-		return true
+		return lib.IsServiceLBClassAccepted(svcObj)
 	}
 	return false
 }
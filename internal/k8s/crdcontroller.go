@@ -642,6 +642,10 @@ func (c *AviController) SetupServiceImportEventHandlers(numWorkers uint32) {
 }
 
 func checkRefsOnController(key string, refMap map[string]string) error {
+	if lib.AKOControlConfig().IsOfflineMode() {
+		utils.AviLog.Debugf("key: %s, msg: running offline, skipping the check for refs on the controller", key)
+		return nil
+	}
 	for k, value := range refMap {
 		if k == "" {
 			continue
@@ -758,7 +762,7 @@ func checkRefOnController(key, refKey, refValue string) error {
 // creation/updates after ingestion
 func addSeGroupLabel(key, segName string) {
 	// No need to configure labels if static route sync is disabled globally.
-	if lib.GetDisableStaticRoute() {
+	if lib.GetDisableStaticRoute() || lib.AKOControlConfig().IsOfflineMode() {
 		utils.AviLog.Infof("Skipping the check for SE group labels for SEG %s", segName)
 		return
 	}
@@ -0,0 +1,483 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+)
+
+// SetupGatewayAPIEventHandlers handles setting up of the gateway.networking.k8s.io GatewayClass, Gateway
+// and route event handlers. The objects are watched using the dynamic informers.
+func (c *AviController) SetupGatewayAPIEventHandlers(numWorkers uint32) {
+	utils.AviLog.Infof("Setting up Gateway API event handlers")
+	informers := c.dynamicInformers
+
+	gatewayClassEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			gwClass := &gatewayv1.GatewayClass{}
+			if err := lib.ConvertUnstructuredObj(obj, gwClass); err != nil {
+				utils.AviLog.Warnf("Unable to convert the GatewayClass object: %v", err)
+				return
+			}
+			key := lib.GatewayAPIGatewayClass + "/" + utils.ObjKey(gwClass)
+			if !lib.IsGatewayAPIGatewayClassValid(gwClass) {
+				utils.AviLog.Debugf("key: %s, msg: GatewayClass is not handled by AKO, ignoring", key)
+				return
+			}
+			validateGatewayAPIGatewayClass(key, gwClass)
+			c.validateGatewaysForGatewayClass(gwClass.Name)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			bkt := utils.Bkt(lib.GetTenant(), numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldObj := &gatewayv1.GatewayClass{}
+			gwClass := &gatewayv1.GatewayClass{}
+			if lib.ConvertUnstructuredObj(old, oldObj) != nil || lib.ConvertUnstructuredObj(new, gwClass) != nil {
+				utils.AviLog.Warnf("Unable to convert the GatewayClass object")
+				return
+			}
+			if reflect.DeepEqual(oldObj.Spec, gwClass.Spec) {
+				return
+			}
+			key := lib.GatewayAPIGatewayClass + "/" + utils.ObjKey(gwClass)
+			if !lib.IsGatewayAPIGatewayClassValid(gwClass) && !lib.IsGatewayAPIGatewayClassValid(oldObj) {
+				utils.AviLog.Debugf("key: %s, msg: GatewayClass is not handled by AKO, ignoring", key)
+				return
+			}
+			if lib.IsGatewayAPIGatewayClassValid(gwClass) {
+				validateGatewayAPIGatewayClass(key, gwClass)
+			}
+			c.validateGatewaysForGatewayClass(gwClass.Name)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			bkt := utils.Bkt(lib.GetTenant(), numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			gwClass := &gatewayv1.GatewayClass{}
+			if err := lib.ConvertUnstructuredObj(obj, gwClass); err != nil {
+				utils.AviLog.Errorf("Unable to convert the deleted GatewayClass object %#v: %v", obj, err)
+				return
+			}
+			key := lib.GatewayAPIGatewayClass + "/" + utils.ObjKey(gwClass)
+			if !lib.IsGatewayAPIGatewayClassValid(gwClass) {
+				return
+			}
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			bkt := utils.Bkt(lib.GetTenant(), numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+	}
+
+	gatewayEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			gw := &gatewayv1.Gateway{}
+			if err := lib.ConvertUnstructuredObj(obj, gw); err != nil {
+				utils.AviLog.Warnf("Unable to convert the Gateway object: %v", err)
+				return
+			}
+			namespace := gw.Namespace
+			key := lib.GatewayAPIGateway + "/" + utils.ObjKey(gw)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: Gateway add event: Namespace: %s didn't qualify filter. Not adding Gateway", key, namespace)
+				return
+			}
+			if !validateGatewayAPIGateway(key, gw) {
+				return
+			}
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldObj := &gatewayv1.Gateway{}
+			gw := &gatewayv1.Gateway{}
+			if lib.ConvertUnstructuredObj(old, oldObj) != nil || lib.ConvertUnstructuredObj(new, gw) != nil {
+				utils.AviLog.Warnf("Unable to convert the Gateway object")
+				return
+			}
+			if reflect.DeepEqual(oldObj.Spec, gw.Spec) {
+				return
+			}
+			namespace := gw.Namespace
+			key := lib.GatewayAPIGateway + "/" + utils.ObjKey(gw)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: Gateway update event: Namespace: %s didn't qualify filter. Not updating Gateway", key, namespace)
+				return
+			}
+			if !validateGatewayAPIGateway(key, gw) {
+				// The Gateway might have been moved out of an AKO managed GatewayClass,
+				// the attached routes are still required to be processed.
+				objects.GatewayAPIObjLister().DeleteGateway(utils.ObjKey(gw))
+			}
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			gw := &gatewayv1.Gateway{}
+			if err := lib.ConvertUnstructuredObj(obj, gw); err != nil {
+				utils.AviLog.Errorf("Unable to convert the deleted Gateway object %#v: %v", obj, err)
+				return
+			}
+			namespace := gw.Namespace
+			key := lib.GatewayAPIGateway + "/" + utils.ObjKey(gw)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: Gateway delete event: Namespace: %s didn't qualify filter. Not deleting Gateway", key, namespace)
+				return
+			}
+			objects.GatewayAPIObjLister().DeleteGateway(utils.ObjKey(gw))
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+	}
+
+	informers.GatewayClassInformer.Informer().AddEventHandler(gatewayClassEventHandler)
+	informers.GatewayInformer.Informer().AddEventHandler(gatewayEventHandler)
+	informers.HTTPRouteInformer.Informer().AddEventHandler(c.gatewayAPIEventHandler(lib.HTTPRoute, numWorkers))
+	// The TLSRoute, TCPRoute and UDPRoute CRDs are part of the experimental channel, and are optional.
+	for _, kind := range []string{lib.TLSRoute, lib.TCPRoute, lib.UDPRoute} {
+		if routeInformer := lib.GetGatewayAPIRouteInformer(kind); routeInformer != nil {
+			routeInformer.Informer().AddEventHandler(c.gatewayAPIEventHandler(kind, numWorkers))
+		}
+	}
+}
+
+// gatewayAPIEventHandler returns the event handler for the namespaced objects of the given kind, i.e. the routes
+// and the ReferenceGrants. Since the status of the routes is updated by AKO itself, only spec changes and
+// deletion are processed on update.
+func (c *AviController) gatewayAPIEventHandler(kind string, numWorkers uint32) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			route, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			namespace := route.GetNamespace()
+			key := kind + "/" + utils.ObjKey(route)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: %s add event: Namespace: %s didn't qualify filter. Not adding %s", key, kind, namespace, kind)
+				return
+			}
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldObj, okOld := old.(*unstructured.Unstructured)
+			route, ok := new.(*unstructured.Unstructured)
+			if !okOld || !ok {
+				utils.AviLog.Warnf("Unable to convert the %s object", kind)
+				return
+			}
+			if reflect.DeepEqual(oldObj.Object["spec"], route.Object["spec"]) && route.GetDeletionTimestamp() == nil {
+				return
+			}
+			namespace := route.GetNamespace()
+			key := kind + "/" + utils.ObjKey(route)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: %s update event: Namespace: %s didn't qualify filter. Not updating %s", key, kind, namespace, kind)
+				return
+			}
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			route, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				utils.AviLog.Errorf("Tombstone contained object that is not a %s: %#v", kind, obj)
+				return
+			}
+			namespace := route.GetNamespace()
+			key := kind + "/" + utils.ObjKey(route)
+			if lib.IsNamespaceBlocked(namespace) || !utils.CheckIfNamespaceAccepted(namespace) {
+				utils.AviLog.Debugf("key: %s, msg: %s delete event: Namespace: %s didn't qualify filter. Not deleting %s", key, kind, namespace, kind)
+				return
+			}
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+	}
+}
+
+// validateGatewaysForGatewayClass re-validates the Gateways of the GatewayClass, since a Gateway can be
+// received before its GatewayClass. The Gateway relationships are removed if the GatewayClass is no longer
+// handled by AKO.
+func (c *AviController) validateGatewaysForGatewayClass(gwClassName string) {
+	gatewayObjs, err := c.dynamicInformers.GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to retrieve the Gateways of GatewayClass %s: %v", gwClassName, err)
+		return
+	}
+	for _, obj := range gatewayObjs {
+		gw := &gatewayv1.Gateway{}
+		if err := lib.ConvertUnstructuredObj(obj, gw); err != nil || string(gw.Spec.GatewayClassName) != gwClassName {
+			continue
+		}
+		if lib.IsNamespaceBlocked(gw.Namespace) || !utils.CheckIfNamespaceAccepted(gw.Namespace) {
+			continue
+		}
+		if !validateGatewayAPIGateway(lib.GatewayAPIGateway+"/"+utils.ObjKey(gw), gw) {
+			objects.GatewayAPIObjLister().DeleteGateway(utils.ObjKey(gw))
+		}
+	}
+}
+
+// validateGatewayAPIGatewayClass sets the Accepted condition on a GatewayClass handled by AKO.
+func validateGatewayAPIGatewayClass(key string, gwClass *gatewayv1.GatewayClass) {
+	gwClassStatus := gwClass.Status.DeepCopy()
+	status.SetGatewayAPICondition(&gwClassStatus.Conditions, gatewayv1.GatewayClassConditionStatusAccepted, metav1.ConditionTrue, gatewayv1.GatewayReasonAccepted, "GatewayClass is accepted by AKO", gwClass.Generation)
+	status.UpdateGatewayAPIGatewayClassStatusObject(key, gwClass, gwClassStatus)
+}
+
+// validateGatewayAPIGateway validates the listeners of a Gateway referring to an AKO managed GatewayClass,
+// updates the Gateway relationships and sets the Gateway and listener conditions.
+// Returns false if the Gateway is not handled by AKO.
+func validateGatewayAPIGateway(key string, gw *gatewayv1.Gateway) bool {
+	if !lib.IsGatewayAPIGatewayValid(gw) {
+		utils.AviLog.Debugf("key: %s, msg: GatewayClass %s of the Gateway is not handled by AKO", key, gw.Spec.GatewayClassName)
+		return false
+	}
+	gwNSName := utils.ObjKey(gw)
+	objects.GatewayAPIObjLister().UpdateGatewayToGatewayClass(gwNSName, string(gw.Spec.GatewayClassName))
+
+	gwStatus := gw.Status.DeepCopy()
+	var secrets []string
+	var listenerStatuses []gatewayv1.ListenerStatus
+	invalidListeners := 0
+	for _, listener := range gw.Spec.Listeners {
+		listenerStatus := gatewayv1.ListenerStatus{
+			Name:           listener.Name,
+			SupportedKinds: []gatewayv1.RouteGroupKind{},
+			Conditions:     []metav1.Condition{},
+		}
+		for _, oldStatus := range gw.Status.Listeners {
+			if oldStatus.Name == listener.Name {
+				listenerStatus.AttachedRoutes = oldStatus.AttachedRoutes
+				listenerStatus.Conditions = append(listenerStatus.Conditions, oldStatus.Conditions...)
+				break
+			}
+		}
+
+		accepted, resolvedRefs := true, true
+		acceptedMessage, resolvedRefsMessage := "Listener is accepted", "All references are resolved"
+		acceptedReason, resolvedRefsReason := gatewayv1.GatewayReasonAccepted, gatewayv1.RouteReasonResolvedRefs
+		routeKind := lib.HTTPRoute
+		switch listener.Protocol {
+		case gatewayv1.HTTPProtocolType:
+		case gatewayv1.HTTPSProtocolType:
+			listenerSecrets, err := getGatewayListenerSecrets(gw.Namespace, listener)
+			if err != nil {
+				resolvedRefs = false
+				resolvedRefsReason = gatewayv1.ListenerReasonInvalidCertificateRef
+				resolvedRefsMessage = err.Error()
+			}
+			for _, secret := range listenerSecrets {
+				if !utils.HasElem(secrets, secret) {
+					secrets = append(secrets, secret)
+				}
+			}
+		case gatewayv1.TLSProtocolType:
+			// Only TLS passthrough is supported for the TLS listeners, TLS termination is done by the HTTPS listeners.
+			routeKind = lib.TLSRoute
+			if listener.TLS == nil || listener.TLS.Mode == nil || *listener.TLS.Mode != gatewayv1.TLSModePassthrough {
+				accepted = false
+				acceptedReason = gatewayv1.ListenerReasonUnsupportedProtocol
+				acceptedMessage = "Only Passthrough TLS mode is supported for the TLS listener"
+			}
+		case gatewayv1.TCPProtocolType:
+			routeKind = lib.TCPRoute
+		case gatewayv1.UDPProtocolType:
+			routeKind = lib.UDPRoute
+		default:
+			accepted = false
+			acceptedReason = gatewayv1.ListenerReasonUnsupportedProtocol
+			acceptedMessage = fmt.Sprintf("Protocol %s is not supported", listener.Protocol)
+		}
+		if accepted && lib.GetGatewayAPIRouteInformer(routeKind) == nil {
+			accepted = false
+			acceptedReason = gatewayv1.ListenerReasonUnsupportedProtocol
+			acceptedMessage = fmt.Sprintf("%s CRD is not installed in the cluster", routeKind)
+		}
+		if accepted {
+			group := gatewayv1.Group(gatewayv1.GroupName)
+			listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gatewayv1.RouteGroupKind{Group: &group, Kind: gatewayv1.Kind(routeKind)})
+		}
+		if !accepted || !resolvedRefs {
+			invalidListeners++
+		}
+		status.SetGatewayAPICondition(&listenerStatus.Conditions, gatewayv1.ListenerConditionAccepted, conditionStatus(accepted), acceptedReason, acceptedMessage, gw.Generation)
+		status.SetGatewayAPICondition(&listenerStatus.Conditions, gatewayv1.ListenerConditionResolvedRefs, conditionStatus(resolvedRefs), resolvedRefsReason, resolvedRefsMessage, gw.Generation)
+		listenerStatuses = append(listenerStatuses, listenerStatus)
+	}
+	objects.GatewayAPIObjLister().UpdateGatewayToSecrets(gwNSName, secrets)
+
+	gwStatus.Listeners = listenerStatuses
+	if invalidListeners == len(gw.Spec.Listeners) {
+		status.SetGatewayAPICondition(&gwStatus.Conditions, gatewayv1.GatewayConditionAccepted, metav1.ConditionFalse, gatewayv1.GatewayReasonInvalid, "No valid listeners found in the Gateway", gw.Generation)
+	} else {
+		status.SetGatewayAPICondition(&gwStatus.Conditions, gatewayv1.GatewayConditionAccepted, metav1.ConditionTrue, gatewayv1.GatewayReasonAccepted, "Gateway is accepted by AKO", gw.Generation)
+	}
+	if len(gwStatus.Addresses) == 0 {
+		status.SetGatewayAPICondition(&gwStatus.Conditions, gatewayv1.GatewayConditionProgrammed, metav1.ConditionFalse, gatewayv1.GatewayReasonPending, "Waiting for routes to be attached to the Gateway", gw.Generation)
+	}
+	status.UpdateGatewayAPIGatewayStatusObject(key, gw, gwStatus)
+	return true
+}
+
+// getGatewayListenerSecrets returns the secrets in namespace/name format referred by an HTTPS listener.
+// Only core Secrets in the namespace of the Gateway are supported.
+func getGatewayListenerSecrets(namespace string, listener gatewayv1.Listener) ([]string, error) {
+	if listener.TLS == nil || len(listener.TLS.CertificateRefs) == 0 {
+		return nil, fmt.Errorf("no certificateRefs found for the HTTPS listener")
+	}
+	if listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate {
+		return nil, fmt.Errorf("TLS mode %s is not supported for the HTTPS listener", *listener.TLS.Mode)
+	}
+	var secrets []string
+	for _, certRef := range listener.TLS.CertificateRefs {
+		if (certRef.Group != nil && *certRef.Group != "") || (certRef.Kind != nil && *certRef.Kind != utils.Secret) {
+			return secrets, fmt.Errorf("certificateRef %s is not a Secret", certRef.Name)
+		}
+		if certRef.Namespace != nil && string(*certRef.Namespace) != namespace {
+			return secrets, fmt.Errorf("certificateRef %s refers to a Secret in another namespace", certRef.Name)
+		}
+		secretNSName := namespace + "/" + string(certRef.Name)
+		secrets = append(secrets, secretNSName)
+		if _, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(string(certRef.Name)); err != nil {
+			return secrets, fmt.Errorf("Secret %s not found", secretNSName)
+		}
+	}
+	return secrets, nil
+}
+
+func conditionStatus(status bool) metav1.ConditionStatus {
+	if status {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
+// fullSyncGatewayAPIObjects validates the GatewayClasses and Gateways handled by AKO, which builds the
+// Gateway relationships, and then processes the routes attached to them.
+func (c *AviController) fullSyncGatewayAPIObjects() error {
+	gwClassObjs, err := c.dynamicInformers.GatewayClassInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("Unable to retrieve the Gateway API gatewayclasses during full sync: %s", err)
+		return err
+	}
+	for _, obj := range gwClassObjs {
+		gwClass := &gatewayv1.GatewayClass{}
+		if err := lib.ConvertUnstructuredObj(obj, gwClass); err != nil || !lib.IsGatewayAPIGatewayClassValid(gwClass) {
+			continue
+		}
+		validateGatewayAPIGatewayClass(lib.GatewayAPIGatewayClass+"/"+gwClass.Name, gwClass)
+	}
+
+	gatewayObjs, err := c.dynamicInformers.GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Errorf("Unable to retrieve the Gateway API gateways during full sync: %s", err)
+		return err
+	}
+	for _, obj := range gatewayObjs {
+		gw := &gatewayv1.Gateway{}
+		if err := lib.ConvertUnstructuredObj(obj, gw); err != nil {
+			continue
+		}
+		if lib.IsNamespaceBlocked(gw.Namespace) || !utils.CheckIfNamespaceAccepted(gw.Namespace) {
+			continue
+		}
+		validateGatewayAPIGateway(lib.GatewayAPIGateway+"/"+utils.ObjKey(gw), gw)
+	}
+
+	for _, kind := range []string{lib.HTTPRoute, lib.TLSRoute, lib.TCPRoute, lib.UDPRoute} {
+		routeInformer := lib.GetGatewayAPIRouteInformer(kind)
+		if routeInformer == nil {
+			continue
+		}
+		routeObjs, err := routeInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the %ss during full sync: %s", kind, err)
+			return err
+		}
+		for _, obj := range routeObjs {
+			route, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			if lib.IsNamespaceBlocked(route.GetNamespace()) || !utils.CheckIfNamespaceAccepted(route.GetNamespace()) {
+				continue
+			}
+			key := kind + "/" + utils.ObjKey(route)
+			objects.SharedResourceVerInstanceLister().Save(key, route.GetResourceVersion())
+			utils.AviLog.Debugf("Dequeue for %s key: %v", kind, key)
+			nodes.DequeueIngestion(key, true)
+		}
+	}
+	return nil
+}
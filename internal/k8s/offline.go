@@ -0,0 +1,132 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const offlineCRDStatusTimeout = 30 * time.Second
+
+// SyncOffline builds the models of all the objects served by the clients in informers, the same way as the
+// full sync on bootup, without connecting to the Avi Controller. The refs of the CRDs are not checked on the
+// controller, and the models are not published to the rest layer.
+func (c *AviController) SyncOffline(informers K8sinformers, registeredInformers []string, stopCh <-chan struct{}) error {
+	lib.AKOControlConfig().SetOfflineMode(true)
+	// Only the leader validates the CRDs and sets their status, which the graph layer relies upon.
+	lib.AKOControlConfig().SetIsLeaderFlag(true)
+
+	informersArg := make(map[string]interface{})
+	if informers.OshiftClient != nil {
+		informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = informers.OshiftClient
+	}
+	c.informers = utils.NewInformers(utils.KubeClientIntf{ClientSet: informers.Cs}, registeredInformers, informersArg)
+	utils.SharedWorkQueue()
+
+	c.addIndexers()
+	c.Start(stopCh)
+	c.DisableSync = false
+
+	if err := c.validateCRDsOffline(); err != nil {
+		return err
+	}
+	return c.FullSyncK8s(false)
+}
+
+// validateCRDsOffline validates the HostRules, HTTPRules and AviInfraSettings, and waits for their status to be
+// updated in the informers, so that the full sync builds the models with the accepted CRDs.
+func (c *AviController) validateCRDsOffline() error {
+	validator := c.GetValidator()
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	var pending []string
+
+	if lib.AKOControlConfig().HostRuleEnabled() {
+		hostRuleObjs, err := crdInformers.HostRuleInformer.Lister().HostRules(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return fmt.Errorf("unable to retrieve the hostrules: %v", err)
+		}
+		for _, hostRuleObj := range hostRuleObjs {
+			key := lib.HostRule + "/" + utils.ObjKey(hostRuleObj)
+			if err := validator.ValidateHostRuleObj(key, hostRuleObj); err != nil {
+				utils.AviLog.Warnf("key: %s, Error retrieved during validation of HostRule: %v", key, err)
+			}
+			pending = append(pending, key)
+		}
+	}
+
+	if lib.AKOControlConfig().HttpRuleEnabled() {
+		httpRuleObjs, err := crdInformers.HTTPRuleInformer.Lister().HTTPRules(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return fmt.Errorf("unable to retrieve the httprules: %v", err)
+		}
+		for _, httpRuleObj := range httpRuleObjs {
+			key := lib.HTTPRule + "/" + utils.ObjKey(httpRuleObj)
+			if err := validator.ValidateHTTPRuleObj(key, httpRuleObj); err != nil {
+				utils.AviLog.Warnf("key: %s, Error retrieved during validation of HTTPRule: %v", key, err)
+			}
+			pending = append(pending, key)
+		}
+	}
+
+	if lib.AKOControlConfig().AviInfraSettingEnabled() {
+		aviInfraObjs, err := crdInformers.AviInfraSettingInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return fmt.Errorf("unable to retrieve the avinfrasettings: %v", err)
+		}
+		for _, aviInfraObj := range aviInfraObjs {
+			key := lib.AviInfraSetting + "/" + utils.ObjKey(aviInfraObj)
+			if err := validator.ValidateAviInfraSetting(key, aviInfraObj); err != nil {
+				utils.AviLog.Warnf("key: %s, Error retrieved during validation of AviInfraSetting: %v", key, err)
+			}
+			pending = append(pending, key)
+		}
+	}
+
+	for _, key := range pending {
+		err := wait.PollImmediate(100*time.Millisecond, offlineCRDStatusTimeout, func() (bool, error) {
+			return crdStatusUpdated(key), nil
+		})
+		if err != nil {
+			return fmt.Errorf("timed out waiting for the status of %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// crdStatusUpdated returns true if the informer has the status set by the validation of the CRD of the key.
+func crdStatusUpdated(key string) bool {
+	objType, namespace, name := lib.ExtractTypeNameNamespace(key)
+	crdInformers := lib.AKOControlConfig().CRDInformers()
+	switch objType {
+	case lib.HostRule:
+		hostRuleObj, err := crdInformers.HostRuleInformer.Lister().HostRules(namespace).Get(name)
+		return err == nil && hostRuleObj.Status.Status != ""
+	case lib.HTTPRule:
+		httpRuleObj, err := crdInformers.HTTPRuleInformer.Lister().HTTPRules(namespace).Get(name)
+		return err == nil && httpRuleObj.Status.Status != ""
+	case lib.AviInfraSetting:
+		aviInfraObj, err := crdInformers.AviInfraSettingInformer.Lister().Get(name)
+		return err == nil && aviInfraObj.Status.Status != ""
+	}
+	return true
+}
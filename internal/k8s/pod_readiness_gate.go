@@ -0,0 +1,366 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	// PodReadinessGateSyncInterval is the interval, in seconds, at which the readiness gate condition of the Pods is synced.
+	PodReadinessGateSyncInterval = 5
+	aviServerOperUp              = "OPER_UP"
+	// maxPoolRuntimeQueryBackoff is the maximum interval, in seconds, between the queries of the server runtime of a pool.
+	maxPoolRuntimeQueryBackoff = 60
+)
+
+// poolServerStatus caches the servers which are up in the pools queried within a sync, and the pools in which
+// some of the servers are not up.
+type poolServerStatus struct {
+	serverUp   map[string]map[string]bool
+	notUpPools sets.String
+}
+
+// poolRef is a copy of the fields of a pool node, which are required to query the runtime of its servers.
+type poolRef struct {
+	name   string
+	tenant string
+	port   int32
+}
+
+func (p poolRef) key() string {
+	return p.tenant + "/" + p.name
+}
+
+// poolQueryBackoff is the backoff of the queries of the server runtime of a pool, whose servers are not yet up.
+type poolQueryBackoff struct {
+	next     time.Time
+	interval time.Duration
+}
+
+// poolQueryBackoffs are the backoffs of the pools, keyed by the tenant and the name of the pool. These are
+// only accessed by the readiness gate sync, which runs in a single goroutine.
+var poolQueryBackoffs = make(map[string]*poolQueryBackoff)
+
+// podServer is a pool server of a Pod. The port is set only in NodePortLocal mode, where the servers have
+// a port of their own.
+type podServer struct {
+	addr string
+	port int32
+}
+
+func getServerKey(addr string, port int32) string {
+	if port == 0 {
+		return addr
+	}
+	return addr + "/" + strconv.Itoa(int(port))
+}
+
+// SyncPodReadinessGates sets the pool server readiness gate condition of the Pods to True, once the servers of
+// the Pod are up in all the pools that these belong to. The runtime of the servers is queried only for the pools
+// of the Pods whose containers are ready, and the queries of a pool are backed off while its servers are not up.
+// The condition of a Pod, whose servers are not yet added to any pool, is set to True only if none of the Services
+// of the Pod have AKO pools.
+func (c *AviController) SyncPodReadinessGates() {
+	if c.DisableSync || !lib.AKOControlConfig().IsLeader() {
+		return
+	}
+	pods, err := c.informers.PodInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to list the Pods for the readiness gate sync: %v", err)
+		return
+	}
+
+	var poolsByServer map[string][]poolRef
+	var l4Services sets.String
+	status := poolServerStatus{serverUp: make(map[string]map[string]bool), notUpPools: sets.NewString()}
+	for _, pod := range pods {
+		if !lib.HasPodReadinessGate(pod) || pod.GetDeletionTimestamp() != nil || lib.IsNamespaceBlocked(pod.Namespace) {
+			continue
+		}
+		if condition := getPodReadinessGateCondition(pod); condition != nil && condition.Status == corev1.ConditionTrue {
+			continue
+		}
+		key := utils.Pod + "/" + utils.ObjKey(pod)
+		if lib.IsNodePortMode() {
+			// The Pods are not servers of the pools in NodePort mode.
+			c.setPodReadinessGateCondition(pod, corev1.ConditionTrue, "NotApplicable", "Pods are not pool servers in NodePort mode", key)
+			continue
+		}
+		if !isPodContainersReady(pod) {
+			// The servers of the Pod are added to the pools once its containers are ready.
+			continue
+		}
+		if poolsByServer == nil {
+			poolsByServer, l4Services = getPoolsByServer()
+		}
+
+		notUpServers := []string{}
+		serverFound, backingOff := false, false
+		for _, server := range getPodServers(pod) {
+			for _, pool := range poolsByServer[getServerKey(server.addr, server.port)] {
+				serverFound = true
+				port := server.port
+				if port == 0 {
+					port = pool.port
+				}
+				serverUp, queried := status.isServerUp(pool, server.addr, port, key)
+				if !queried {
+					backingOff = true
+				} else if !serverUp {
+					notUpServers = append(notUpServers, fmt.Sprintf("%s port %d in pool %s", server.addr, port, pool.name))
+				}
+			}
+		}
+		if !serverFound {
+			if !hasAviPools(pod, l4Services) {
+				c.setPodReadinessGateCondition(pod, corev1.ConditionTrue, "NotAPoolMember", "Services of the Pod have no pools", key)
+				continue
+			}
+			utils.AviLog.Debugf("key: %s, msg: servers of the Pod are not found in any pool", key)
+			continue
+		}
+		if len(notUpServers) > 0 {
+			c.setPodReadinessGateCondition(pod, corev1.ConditionFalse, "PoolServerNotUp", "Servers are not up: "+strings.Join(notUpServers, ", "), key)
+		} else if !backingOff {
+			c.setPodReadinessGateCondition(pod, corev1.ConditionTrue, "PoolServerUp", "Servers are up in all the pools", key)
+		}
+	}
+	status.updateBackoffs()
+}
+
+// getPoolsByServer indexes the pools of all the models by their servers. The servers with a port of their own, which
+// is the case in NodePortLocal mode, are indexed by the address and the port. The Services of the L4 pools are
+// returned as well.
+func getPoolsByServer() (map[string][]poolRef, sets.String) {
+	poolsByServer := make(map[string][]poolRef)
+	l4Services := sets.NewString()
+	allModels := objects.SharedAviGraphLister().GetAll()
+	for modelName := range allModels.(map[string]interface{}) {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			continue
+		}
+		aviModelGraph, ok := aviModel.(*nodes.AviObjectGraph)
+		if !ok {
+			continue
+		}
+		aviModelGraph.Lock.RLock()
+		for _, pool := range aviModelGraph.GetAviPoolNodes() {
+			l4Services.Insert(pool.ServiceMetadata.NamespaceServiceName...)
+			ref := poolRef{name: pool.Name, tenant: pool.Tenant, port: pool.Port}
+			for _, server := range pool.Servers {
+				if server.Ip.Addr == nil || server.Draining {
+					continue
+				}
+				serverKey := getServerKey(*server.Ip.Addr, server.Port)
+				poolsByServer[serverKey] = append(poolsByServer[serverKey], ref)
+			}
+		}
+		aviModelGraph.Lock.RUnlock()
+	}
+	return poolsByServer, l4Services
+}
+
+// hasAviPools returns true if any of the Services, which select the Pod, are backed by AKO pools, either as
+// LoadBalancer Services or as backends of the Ingresses, Routes, MultiClusterIngresses and Gateway API routes.
+func hasAviPools(pod *corev1.Pod, l4Services sets.String) bool {
+	services, err := utils.GetInformers().ServiceInformer.Lister().Services(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return true
+	}
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.GetLabels())) {
+			continue
+		}
+		svcNSName := svc.Namespace + "/" + svc.Name
+		if l4Services.Has(svcNSName) ||
+			(svc.Spec.Type == corev1.ServiceTypeLoadBalancer && lib.IsServiceLBClassAccepted(svc)) {
+			return true
+		}
+		if found, _ := objects.ServiceGWLister().GetSvcToGw(svcNSName); found {
+			return true
+		}
+		for _, svcLister := range []*objects.SvcNSCache{
+			objects.SharedSvcLister().IngressMappings(svc.Namespace),
+			objects.OshiftRouteSvcLister().IngressMappings(svc.Namespace),
+			objects.SharedHTTPRouteSvcLister().IngressMappings(svc.Namespace),
+			objects.SharedTLSRouteSvcLister().IngressMappings(svc.Namespace),
+			objects.SharedMultiClusterIngressSvcLister().MultiClusterIngressMappings(svc.Namespace),
+		} {
+			if _, parents := svcLister.GetSvcToIng(svc.Name); len(parents) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getPodServers returns the pool servers of the Pod, which are the Pod IPs, or the node IP and the node ports
+// of the Pod in NodePortLocal mode.
+func getPodServers(pod *corev1.Pod) []podServer {
+	var servers []podServer
+	if lib.GetServiceType() == lib.NodePortLocal {
+		var annotations []lib.NPLAnnotation
+		if err := json.Unmarshal([]byte(pod.GetAnnotations()[lib.NPLPodAnnotation]), &annotations); err != nil {
+			return servers
+		}
+		for _, a := range annotations {
+			servers = append(servers, podServer{addr: a.NodeIP, port: int32(a.NodePort)})
+		}
+		return servers
+	}
+	for _, podIP := range pod.Status.PodIPs {
+		servers = append(servers, podServer{addr: podIP.IP})
+	}
+	if len(servers) == 0 && pod.Status.PodIP != "" {
+		servers = append(servers, podServer{addr: pod.Status.PodIP})
+	}
+	return servers
+}
+
+// isServerUp returns true if the server is up in the pool. The runtime of the servers of the pool is queried once
+// within a sync, and not until the backoff of the pool ends, in which case false is returned for queried.
+func (s poolServerStatus) isServerUp(pool poolRef, addr string, port int32, key string) (bool, bool) {
+	serverUp, ok := s.serverUp[pool.key()]
+	if !ok {
+		if backoff, found := poolQueryBackoffs[pool.key()]; found && time.Now().Before(backoff.next) {
+			return false, false
+		}
+		serverUp = getPoolServerRuntime(pool, key)
+		s.serverUp[pool.key()] = serverUp
+	}
+	up := serverUp[getServerKey(addr, port)]
+	if !up {
+		s.notUpPools.Insert(pool.key())
+	}
+	return up, true
+}
+
+// updateBackoffs doubles the backoff interval of the pools queried in this sync, in which some of the servers are
+// not up, up to the maximum. The backoff of the other pools is removed.
+func (s poolServerStatus) updateBackoffs() {
+	for poolKey, backoff := range poolQueryBackoffs {
+		if _, queried := s.serverUp[poolKey]; !s.notUpPools.Has(poolKey) && (queried || time.Now().After(backoff.next)) {
+			delete(poolQueryBackoffs, poolKey)
+		}
+	}
+	for poolKey := range s.notUpPools {
+		backoff, found := poolQueryBackoffs[poolKey]
+		if !found {
+			backoff = &poolQueryBackoff{interval: PodReadinessGateSyncInterval * time.Second}
+			poolQueryBackoffs[poolKey] = backoff
+		} else if backoff.interval *= 2; backoff.interval > maxPoolRuntimeQueryBackoff*time.Second {
+			backoff.interval = maxPoolRuntimeQueryBackoff * time.Second
+		}
+		backoff.next = time.Now().Add(backoff.interval)
+	}
+}
+
+// getPoolServerRuntime queries the runtime of the servers of the pool, and returns the servers which are up.
+func getPoolServerRuntime(pool poolRef, key string) map[string]bool {
+	serverUp := make(map[string]bool)
+	poolCache, ok := avicache.SharedAviObjCache().PoolCache.AviCacheGet(avicache.NamespaceName{Namespace: pool.tenant, Name: pool.name})
+	if !ok {
+		return serverUp
+	}
+	poolCacheObj, ok := poolCache.(*avicache.AviPoolCache)
+	if !ok || poolCacheObj.Uuid == "" {
+		return serverUp
+	}
+	aviRestClientPool := avicache.SharedAVIClients()
+	if len(aviRestClientPool.AviClient) == 0 {
+		return serverUp
+	}
+
+	uri := "/api/pool/" + poolCacheObj.Uuid + "/runtime/server/"
+	var response interface{}
+	if err := lib.AviGet(aviRestClientPool.AviClient[0], uri, &response); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: pool server runtime Get uri %v returned err %v", key, uri, err)
+		return serverUp
+	}
+	servers, ok := response.([]interface{})
+	if !ok {
+		if responseMap, isMap := response.(map[string]interface{}); isMap {
+			servers, _ = responseMap["results"].([]interface{})
+		}
+	}
+	for _, serverIntf := range servers {
+		server, ok := serverIntf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		serverIP, _ := server["server_ip"].(map[string]interface{})
+		addr, _ := serverIP["addr"].(string)
+		port, _ := server["port"].(float64)
+		operStatus, _ := server["oper_status"].(map[string]interface{})
+		state, _ := operStatus["state"].(string)
+		if addr != "" && state == aviServerOperUp {
+			serverUp[getServerKey(addr, int32(port))] = true
+		}
+	}
+	return serverUp
+}
+
+func getPodReadinessGateCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == lib.PodReadinessGateConditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// setPodReadinessGateCondition updates the readiness gate condition of the Pod, if the status or the reason changed.
+func (c *AviController) setPodReadinessGateCondition(pod *corev1.Pod, conditionStatus corev1.ConditionStatus, reason, message, key string) {
+	if condition := getPodReadinessGateCondition(pod); condition != nil && condition.Status == conditionStatus && condition.Reason == reason {
+		return
+	}
+	podCopy := pod.DeepCopy()
+	newCondition := corev1.PodCondition{
+		Type:               lib.PodReadinessGateConditionType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	if condition := getPodReadinessGateCondition(podCopy); condition != nil {
+		*condition = newCondition
+	} else {
+		podCopy.Status.Conditions = append(podCopy.Status.Conditions, newCondition)
+	}
+	_, err := utils.GetInformers().ClientSet.CoreV1().Pods(pod.Namespace).UpdateStatus(context.TODO(), podCopy, metav1.UpdateOptions{})
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to update the readiness gate condition of the Pod: %v", key, err)
+		return
+	}
+	utils.AviLog.Infof("key: %s, msg: readiness gate condition of the Pod set to %s: %s", key, conditionStatus, message)
+}
@@ -17,6 +17,7 @@ package k8s
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
@@ -140,6 +141,26 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1alpha1.HostRule)
 		}
 	}
 
+	if err := validateRateLimit(hostrule.Spec.VirtualHost.RateLimit); err != nil {
+		err = fmt.Errorf("rateLimit: %v", err)
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		return err
+	}
+
+	clientAuth := hostrule.Spec.VirtualHost.TLS.ClientAuth
+	if clientAuth != nil {
+		if hostrule.Spec.VirtualHost.ApplicationProfile != "" {
+			err = fmt.Errorf("clientAuth can not be set along with applicationProfile")
+			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			return err
+		}
+		if err := validateClientAuth(hostrule.Namespace, clientAuth); err != nil {
+			err = fmt.Errorf("clientAuth: %v", err)
+			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			return err
+		}
+	}
+
 	refData := map[string]string{
 		hostrule.Spec.VirtualHost.WAFPolicy:          "WafPolicy",
 		hostrule.Spec.VirtualHost.ApplicationProfile: "AppProfile",
@@ -150,6 +171,9 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1alpha1.HostRule)
 	if hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Type == akov1alpha1.HostRuleSecretTypeAviReference {
 		refData[hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name] = "SslKeyCert"
 	}
+	if clientAuth != nil && clientAuth.CA.Type == akov1alpha1.HostRuleSecretTypeAviReference {
+		refData[clientAuth.CA.Name] = "PKIProfile"
+	}
 
 	if hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Type == akov1alpha1.HostRuleSecretTypeSecretReference {
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name
@@ -240,6 +264,24 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1alpha1.HTTPRule)
 		for _, hm := range path.HealthMonitors {
 			refData[hm] = "HealthMonitor"
 		}
+
+		err := validateHTTPRuleMatches(path)
+		if err == nil {
+			err = validateHTTPRuleRewrites(path)
+		}
+		if err == nil {
+			err = validateHTTPRuleRateLimit(path)
+		}
+		if err == nil {
+			err = validateHTTPRuleHealthMonitors(path)
+		}
+		if err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			return fmt.Errorf("key: %s, msg: %v", key, err)
+		}
 	}
 
 	if err := checkRefsOnController(key, refData); err != nil {
@@ -262,6 +304,189 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1alpha1.HTTPRule)
 	return nil
 }
 
+// validateHTTPRuleMatches checks that every match of the HTTPRule path has a backend and at least one
+// header or cookie to match, with a value unless the match type is Exists.
+func validateHTTPRuleMatches(path akov1alpha1.HTTPRulePaths) error {
+	for i, match := range path.Matches {
+		if match.Backend.Service == "" || match.Backend.Port <= 0 {
+			return fmt.Errorf("match %d of path %s must have a backend service and port", i, path.Target)
+		}
+		if len(match.Headers) == 0 && match.Cookie == nil {
+			return fmt.Errorf("match %d of path %s must have at least one header or cookie", i, path.Target)
+		}
+		hdrMatches := append([]akov1alpha1.HTTPRuleHeaderMatch{}, match.Headers...)
+		if match.Cookie != nil {
+			hdrMatches = append(hdrMatches, *match.Cookie)
+		}
+		for _, hdrMatch := range hdrMatches {
+			if hdrMatch.Name == "" {
+				return fmt.Errorf("match %d of path %s has a header or cookie without name", i, path.Target)
+			}
+			switch hdrMatch.MatchType {
+			case "", lib.MatchTypeExact, lib.MatchTypePrefix, lib.MatchTypeContains:
+				if hdrMatch.Value == "" {
+					return fmt.Errorf("match %d of path %s must have a value for %s", i, path.Target, hdrMatch.Name)
+				}
+			case lib.MatchTypeExists:
+				if hdrMatch.Value != "" {
+					return fmt.Errorf("match %d of path %s must not have a value for %s with match type %s", i, path.Target, hdrMatch.Name, lib.MatchTypeExists)
+				}
+			default:
+				return fmt.Errorf("match %d of path %s has invalid match type %s for %s", i, path.Target, hdrMatch.MatchType, hdrMatch.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHTTPRuleRewrites checks that the header modifiers of the HTTPRule path have header names, and that the
+// rewritten path prefix is an absolute path.
+func validateHTTPRuleRewrites(path akov1alpha1.HTTPRulePaths) error {
+	for _, headers := range []*akov1alpha1.HTTPRuleHeaders{path.RequestHeaders, path.ResponseHeaders} {
+		if headers == nil {
+			continue
+		}
+		names := append([]string{}, headers.Remove...)
+		for _, header := range append(append([]akov1alpha1.HTTPRuleHeader{}, headers.Add...), headers.Replace...) {
+			names = append(names, header.Name)
+		}
+		for _, name := range names {
+			if strings.TrimSpace(name) == "" {
+				return fmt.Errorf("header modifiers of path %s must have a header name", path.Target)
+			}
+		}
+	}
+	if path.Rewrite != nil {
+		if path.Rewrite.PathPrefix != "" && !strings.HasPrefix(path.Rewrite.PathPrefix, "/") {
+			return fmt.Errorf("rewrite pathPrefix %s of path %s must start with /", path.Rewrite.PathPrefix, path.Target)
+		}
+		if strings.ContainsAny(path.Rewrite.Host, "/ ") {
+			return fmt.Errorf("rewrite host %s of path %s is not a valid host", path.Rewrite.Host, path.Target)
+		}
+	}
+	return nil
+}
+
+// validateHTTPRuleRateLimit checks the rate limit of the HTTPRule path. The requests of a path can only be
+// rate limited per client IP, since the rate limit per header applies to the whole virtual service.
+func validateHTTPRuleRateLimit(path akov1alpha1.HTTPRulePaths) error {
+	if path.RateLimit == nil {
+		return nil
+	}
+	if path.RateLimit.Key == lib.RateLimitKeyHeader {
+		return fmt.Errorf("rateLimit of path %s does not support key %s", path.Target, lib.RateLimitKeyHeader)
+	}
+	if err := validateRateLimit(path.RateLimit); err != nil {
+		return fmt.Errorf("rateLimit of path %s: %v", path.Target, err)
+	}
+	return nil
+}
+
+// validateHTTPRuleHealthMonitors checks the health monitors defined inline in the HTTPRule path. The names must be
+// unique in the path, and the receive timeout must be shorter than the send interval, after the defaults are applied.
+func validateHTTPRuleHealthMonitors(path akov1alpha1.HTTPRulePaths) error {
+	names := make(map[string]bool)
+	for _, hm := range path.InlineHealthMonitors {
+		if strings.TrimSpace(hm.Name) == "" {
+			return fmt.Errorf("inlineHealthMonitors of path %s must have a name", path.Target)
+		}
+		if names[hm.Name] {
+			return fmt.Errorf("inlineHealthMonitors of path %s has duplicate name %s", path.Target, hm.Name)
+		}
+		names[hm.Name] = true
+		switch hm.Type {
+		case lib.HealthMonitorTypeHTTP, lib.HealthMonitorTypeHTTPS:
+		case lib.HealthMonitorTypeTCP:
+			if len(hm.ResponseCodes) > 0 {
+				return fmt.Errorf("health monitor %s of path %s must have responseCodes only with type %s or %s", hm.Name, path.Target, lib.HealthMonitorTypeHTTP, lib.HealthMonitorTypeHTTPS)
+			}
+		default:
+			return fmt.Errorf("health monitor %s of path %s has invalid type %s", hm.Name, path.Target, hm.Type)
+		}
+		sendInterval := hm.SendInterval
+		if sendInterval == 0 {
+			sendInterval = lib.DefaultHealthMonitorSendInterval
+		}
+		if (hm.ReceiveTimeout != 0 && hm.ReceiveTimeout >= sendInterval) || sendInterval < 2 {
+			return fmt.Errorf("health monitor %s of path %s must have a receiveTimeout shorter than the sendInterval", hm.Name, path.Target)
+		}
+	}
+	return nil
+}
+
+// validateClientAuth checks the client certificate validation of a HostRule. A CA Secret must be
+// present in the namespace of the HostRule and must contain the CA bundle in the ca.crt key.
+func validateClientAuth(namespace string, clientAuth *akov1alpha1.HostRuleClientAuth) error {
+	if clientAuth.Mode != "" && clientAuth.Mode != lib.ClientAuthModeRequire && clientAuth.Mode != lib.ClientAuthModeRequest {
+		return fmt.Errorf("mode %s is not supported", clientAuth.Mode)
+	}
+	if clientAuth.CA.Name == "" {
+		return fmt.Errorf("ca name is required")
+	}
+	if clientAuth.CA.Type != akov1alpha1.HostRuleSecretTypeSecretReference {
+		return nil
+	}
+	if err := validateSecretReferenceInHostrule(namespace, clientAuth.CA.Name); err != nil {
+		return err
+	}
+	secret, _ := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(clientAuth.CA.Name)
+	if len(secret.Data[lib.ClientAuthCAKey]) == 0 {
+		return fmt.Errorf("secret %s does not contain the %s key", clientAuth.CA.Name, lib.ClientAuthCAKey)
+	}
+	return nil
+}
+
+// validateRateLimit checks the rate limit of a HostRule or HTTPRule.
+func validateRateLimit(rateLimit *akov1alpha1.RateLimit) error {
+	if rateLimit == nil {
+		return nil
+	}
+	if rateLimit.Requests < 1 || rateLimit.Requests > 1000000000 {
+		return fmt.Errorf("requests must be between 1 and 1000000000")
+	}
+	if rateLimit.Period < 1 || rateLimit.Period > 1000000000 {
+		return fmt.Errorf("period must be between 1 and 1000000000")
+	}
+	if rateLimit.Burst < 0 || rateLimit.Burst > 1000000000 {
+		return fmt.Errorf("burst must be between 0 and 1000000000")
+	}
+	switch rateLimit.Key {
+	case "", lib.RateLimitKeyClientIP:
+		if rateLimit.Header != "" {
+			return fmt.Errorf("header must be set only with key %s", lib.RateLimitKeyHeader)
+		}
+	case lib.RateLimitKeyHeader:
+		if strings.TrimSpace(rateLimit.Header) == "" {
+			return fmt.Errorf("header must be set with key %s", lib.RateLimitKeyHeader)
+		}
+	default:
+		return fmt.Errorf("invalid key %s", rateLimit.Key)
+	}
+	redirect := rateLimit.Action.Redirect
+	switch rateLimit.Action.Type {
+	case "", lib.RateLimitActionDrop, lib.RateLimitActionReport:
+		if redirect != nil {
+			return fmt.Errorf("redirect must be set only with action %s", lib.RateLimitActionRedirect)
+		}
+	case lib.RateLimitActionRedirect:
+		if redirect == nil || (redirect.Host == "" && redirect.Path == "") {
+			return fmt.Errorf("redirect must have a host or path with action %s", lib.RateLimitActionRedirect)
+		}
+		if redirect.Protocol != "" && redirect.Protocol != "HTTP" && redirect.Protocol != "HTTPS" {
+			return fmt.Errorf("invalid redirect protocol %s", redirect.Protocol)
+		}
+		if redirect.Path != "" && !strings.HasPrefix(redirect.Path, "/") {
+			return fmt.Errorf("redirect path %s must start with /", redirect.Path)
+		}
+		if strings.ContainsAny(redirect.Host, "/ ") {
+			return fmt.Errorf("redirect host %s is not a valid host", redirect.Host)
+		}
+	default:
+		return fmt.Errorf("invalid action type %s", rateLimit.Action.Type)
+	}
+	return nil
+}
+
 // validateAviInfraSetting would do validaion checks on the
 // ingested AviInfraSetting objects
 func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1alpha1.AviInfraSetting) error {
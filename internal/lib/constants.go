@@ -43,6 +43,15 @@ const (
 	DEFAULT_DOMAIN                             = "DEFAULT_DOMAIN"
 	ADVANCED_L4                                = "ADVANCED_L4"
 	SERVICES_API                               = "SERVICES_API"
+	ENABLE_GATEWAY_API                         = "ENABLE_GATEWAY_API"
+	SERVER_DRAIN_PERIOD                        = "SERVER_DRAIN_PERIOD"
+	ENABLE_POD_READINESS_GATE                  = "ENABLE_POD_READINESS_GATE"
+	READINESS_PROBE_HEALTH_MONITOR             = "READINESS_PROBE_HEALTH_MONITOR"
+	DRY_RUN                                    = "DRY_RUN"
+	TRACING_EXPORTER                           = "TRACING_EXPORTER"
+	TRACING_ENDPOINT                           = "TRACING_ENDPOINT"
+	LOAD_BALANCER_CLASS                        = "LOAD_BALANCER_CLASS"
+	ALLOW_NO_LOAD_BALANCER_CLASS               = "ALLOW_NO_LOAD_BALANCER_CLASS"
 	CLUSTER_NAME                               = "CLUSTER_NAME"
 	CLUSTER_ID                                 = "CLUSTER_ID"
 	CLOUD_VCENTER                              = "CLOUD_VCENTER"
@@ -60,6 +69,7 @@ const (
 	NOT_FOUND                                  = "HTTP code: 404"
 	STATUS_REDIRECT                            = "HTTP_REDIRECT_STATUS_CODE_302"
 	CLOSE_CONNECTION                           = "HTTP_SECURITY_ACTION_CLOSE_CONN"
+	RATE_LIMIT                                 = "HTTP_SECURITY_ACTION_RATE_LIMIT"
 	IS_IN                                      = "IS_IN"
 	SLOW_SYNC_TIME                             = 90 // seconds
 	LOG_LEVEL                                  = "logLevel"
@@ -102,11 +112,41 @@ const (
 	StatusAccepted                             = "Accepted"
 	AllowedApplicationProfile                  = "APPLICATION_PROFILE_TYPE_HTTP"
 	TypeTLSReencrypt                           = "reencrypt"
+	MatchTypeExact                             = "Exact"
+	MatchTypePrefix                            = "Prefix"
+	MatchTypeContains                          = "Contains"
+	MatchTypeExists                            = "Exists"
+	RateLimitKeyClientIP                       = "ClientIP"
+	RateLimitKeyHeader                         = "Header"
+	RateLimitActionDrop                        = "Drop"
+	RateLimitActionReport                      = "Report"
+	RateLimitActionRedirect                    = "Redirect"
+	ClientAuthModeRequire                      = "Require"
+	ClientAuthModeRequest                      = "Request"
+	ClientAuthCAKey                            = "ca.crt"
+	MaxClientIPPersistenceTimeout              = 720
+	PersistenceProfileSuffix                   = "--client-ip"
+	NetworkSecurityPolicySuffix                = "--source-ranges"
+	ReadinessProbeHealthMonitorSuffix          = "--readiness-probe"
+	HTTPRuleHealthMonitorSuffix                = "--httprule"
+	HealthMonitorTypeHTTP                      = "HTTP"
+	HealthMonitorTypeHTTPS                     = "HTTPS"
+	HealthMonitorTypeTCP                       = "TCP"
+	DefaultHealthMonitorSendInterval           = 10
+	DefaultHealthMonitorReceiveTimeout         = 4
+	DefaultHealthMonitorChecks                 = 2
 	DefaultPoolSSLProfile                      = "System-Standard"
 	LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER = "LB_ALGORITHM_CONSISTENT_HASH_CUSTOM_HEADER"
 	LB_ALGORITHM_CONSISTENT_HASH               = "LB_ALGORITHM_CONSISTENT_HASH"
 	Gateway                                    = "Gateway"
 	GatewayClass                               = "GatewayClass"
+	GatewayAPIGateway                          = "GatewayAPIGateway"
+	GatewayAPIGatewayClass                     = "GatewayAPIGatewayClass"
+	HTTPRoute                                  = "HTTPRoute"
+	TLSRoute                                   = "TLSRoute"
+	TCPRoute                                   = "TCPRoute"
+	UDPRoute                                   = "UDPRoute"
+	ReferenceGrant                             = "ReferenceGrant"
 	DuplicateBackends                          = "MultipleBackendsWithSameServiceError"
 	DummyVSForStaleData                        = "DummyVSForStaleData"
 	ControllerReqWaitTime                      = 300
@@ -139,6 +179,7 @@ const (
 	HTTPRewriteRule                            = "HTTP Header Rewrite Rule"
 	HTTPRedirectPolicy                         = "HTTP Redirect Policy"
 	HeaderRewritePolicy                        = "Header Rewrite Policy"
+	RateLimitPolicy                            = "Rate Limit Policy"
 	L4VS                                       = "L4 Virtual Service"
 	L4VIP                                      = "L4 VIP"
 	L4Pool                                     = "L4 Pool"
@@ -151,6 +192,10 @@ const (
 	PriorityLabel                              = "PriorityLabel"
 	SSLKeyCert                                 = "SSLKeyandCertificate"
 	PKIProfile                                 = "PKI Profile"
+	ApplicationProfile                         = "Application Profile"
+	ApplicationPersistenceProfile              = "Application Persistence Profile"
+	NetworkSecurityPolicy                      = "Network Security Policy"
+	HealthMonitor                              = "Health Monitor"
 	PassthroughPG                              = "Passthrough PG"
 	Passthroughpool                            = "Passthrough pool"
 	PassthroughVS                              = "Passthrough VirtualService"
@@ -196,6 +241,9 @@ const (
 	AKOPause               = "AKOPause"
 	DuplicateHostPath      = "DuplicateHostPath"
 	DuplicateHost          = "DuplicateHost"
+	DefaultBackendConflict = "DefaultBackendConflict"
+	InvalidSourceRange     = "InvalidSourceRange"
+	IPFamilyNotSupported   = "IPFamilyNotSupported"
 	Removed                = "Removed"
 	Synced                 = "Synced"
 	Attached               = "Attached"
@@ -220,7 +268,9 @@ const (
 	SvcApiGatewayNameLabelKey      = "ako.vmware.com/gateway-name"
 	SvcApiGatewayNamespaceLabelKey = "ako.vmware.com/gateway-namespace"
 	SvcApiAviGatewayController     = "ako.vmware.com/avi-lb"
+	GatewayAPIAviController        = "ako.vmware.com/avi-lb"
 	NPLPodAnnotation               = "nodeportlocal.antrea.io"
+	PodReadinessGateConditionType  = "ako.vmware.com/pool-server-up"
 	NPLSvcAnnotation               = "nodeportlocal.antrea.io/enabled"
 	InfraSettingNameAnnotation     = "aviinfrasetting.ako.vmware.com/name"
 	SkipNodePortAnnotation         = "skipnodeport.ako.vmware.com/enabled"
@@ -233,12 +283,15 @@ const (
 	SharedVipSvcLBAnnotation       = "ako.vmware.com/enable-shared-vip"
 	LoadBalancerIP                 = "ako.vmware.com/load-balancer-ip"
 	LBSvcAppProfileAnnotation      = "ako.vmware.com/application-profile"
+	BackendNamespacesAnnotation    = "ako.vmware.com/backend-namespaces"
+	BackendWeightsAnnotation       = "ako.vmware.com/backend-weights"
 
 	// Specifies command used in namespace event handler
 	NsFilterAdd                    = "ADD"
 	NsFilterDelete                 = "DELETE"
 	PoolNameSuffixForHttpPolToPool = "policy-to-pool"
 	AVI_OBJ_NAME_MAX_LENGTH        = 255
+	MaxBackendWeight               = 1000
 )
 
 // Cache Indexer constants.
@@ -125,6 +125,10 @@ type akoControlConfig struct {
 	// controllerVersion stores the version of the controller to
 	// which AKO is communicating with
 	controllerVersion string
+
+	// offlineMode is set to true when the Kubernetes objects are translated
+	// without connecting to the Avi Controller, such as by ako-render.
+	offlineMode bool
 }
 
 var akoControlConfigInstance *akoControlConfig
@@ -142,6 +146,7 @@ func (c *akoControlConfig) SetIsLeaderFlag(flag bool) {
 	c.isLeaderLock.Lock()
 	defer c.isLeaderLock.Unlock()
 	c.isLeader = flag
+	utils.SetLeaderMetric(flag)
 }
 
 func (c *akoControlConfig) IsLeader() bool {
@@ -150,6 +155,14 @@ func (c *akoControlConfig) IsLeader() bool {
 	return c.isLeader
 }
 
+func (c *akoControlConfig) SetOfflineMode(flag bool) {
+	c.offlineMode = flag
+}
+
+func (c *akoControlConfig) IsOfflineMode() bool {
+	return c.offlineMode
+}
+
 func (c *akoControlConfig) SetAKOInstanceFlag(flag bool) {
 	c.primaryaAKO = flag
 }
@@ -65,6 +65,51 @@ var (
 		Version:  "v1alpha1",
 		Resource: "clusternetworkinfos",
 	}
+
+	// GatewayAPI resource identifiers for gateway.networking.k8s.io/v1
+	GatewayClassGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1",
+		Resource: "gatewayclasses",
+	}
+
+	GatewayGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1",
+		Resource: "gateways",
+	}
+
+	HTTPRouteGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1",
+		Resource: "httproutes",
+	}
+
+	// GatewayAPI resource identifiers for gateway.networking.k8s.io/v1alpha2
+	TLSRouteGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1alpha2",
+		Resource: "tlsroutes",
+	}
+
+	TCPRouteGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1alpha2",
+		Resource: "tcproutes",
+	}
+
+	UDPRouteGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1alpha2",
+		Resource: "udproutes",
+	}
+
+	// GatewayAPI resource identifiers for gateway.networking.k8s.io/v1beta1
+	ReferenceGrantGVR = schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1beta1",
+		Resource: "referencegrants",
+	}
 )
 
 type BootstrapCRData struct {
@@ -74,7 +119,11 @@ type BootstrapCRData struct {
 // NewDynamicClientSet initializes dynamic client set instance
 func NewDynamicClientSet(config *rest.Config) (dynamic.Interface, error) {
 	// do not instantiate the dynamic client set if the CNI being used is NOT calico
-	if !utils.IsVCFCluster() && GetCNIPlugin() != CALICO_CNI && GetCNIPlugin() != OPENSHIFT_CNI {
+	// and the Gateway API is not enabled. Ingresses can refer to the ReferenceGrants
+	// hence the client is always required outside of the advanced L4 mode, and the
+	// gRPC readiness probes of the Pods are read through the client.
+	if !utils.IsVCFCluster() && GetCNIPlugin() != CALICO_CNI && GetCNIPlugin() != OPENSHIFT_CNI && !UseGatewayAPI() && GetAdvancedL4() &&
+		!IsReadinessProbeHealthMonitorEnabled() {
 		return nil, nil
 	}
 
@@ -110,6 +159,19 @@ type DynamicInformers struct {
 
 	VCFNetworkInfoInformer    informers.GenericInformer
 	VCFClusterNetworkInformer informers.GenericInformer
+
+	GatewayClassInformer informers.GenericInformer
+	GatewayInformer      informers.GenericInformer
+	HTTPRouteInformer    informers.GenericInformer
+	TLSRouteInformer     informers.GenericInformer
+	TCPRouteInformer     informers.GenericInformer
+	UDPRouteInformer     informers.GenericInformer
+
+	ReferenceGrantInformer informers.GenericInformer
+
+	// PodInformer caches the Pods unstructured, which keeps the fields that are not decoded by this version
+	// of the Kubernetes API, such as the gRPC readiness probes.
+	PodInformer informers.GenericInformer
 }
 
 // NewDynamicInformers initializes the DynamicInformers struct
@@ -133,10 +195,45 @@ func NewDynamicInformers(client dynamic.Interface, akoInfra bool) *DynamicInform
 		}
 	}
 
+	if UseGatewayAPI() && !akoInfra {
+		informers.GatewayClassInformer = f.ForResource(GatewayClassGVR)
+		informers.GatewayInformer = f.ForResource(GatewayGVR)
+		informers.HTTPRouteInformer = f.ForResource(HTTPRouteGVR)
+		// The v1alpha2 routes are part of the experimental channel of the Gateway API, and are
+		// watched only if their CRDs are installed in the cluster.
+		if isDynamicResourceAvailable(client, TLSRouteGVR) {
+			informers.TLSRouteInformer = f.ForResource(TLSRouteGVR)
+		}
+		if isDynamicResourceAvailable(client, TCPRouteGVR) {
+			informers.TCPRouteInformer = f.ForResource(TCPRouteGVR)
+		}
+		if isDynamicResourceAvailable(client, UDPRouteGVR) {
+			informers.UDPRouteInformer = f.ForResource(UDPRouteGVR)
+		}
+	}
+
+	// ReferenceGrants allow the Ingresses to refer to Services in other namespaces, and are
+	// watched irrespective of the Gateway API being enabled, if the CRD is installed.
+	if client != nil && !akoInfra && !GetAdvancedL4() && isDynamicResourceAvailable(client, ReferenceGrantGVR) {
+		informers.ReferenceGrantInformer = f.ForResource(ReferenceGrantGVR)
+	}
+
+	if client != nil && !akoInfra && IsReadinessProbeHealthMonitorEnabled() {
+		informers.PodInformer = f.ForResource(v1.SchemeGroupVersion.WithResource("pods"))
+	}
+
 	dynamicInformerInstance = informers
 	return dynamicInformerInstance
 }
 
+func isDynamicResourceAvailable(client dynamic.Interface, gvr schema.GroupVersionResource) bool {
+	if _, err := client.Resource(gvr).List(context.TODO(), metav1.ListOptions{Limit: 1}); err != nil {
+		utils.AviLog.Infof("Skipped initializing dynamic informer for %s, err: %v", gvr.String(), err)
+		return false
+	}
+	return true
+}
+
 // GetDynamicInformers returns DynamicInformers instance
 func GetDynamicInformers() *DynamicInformers {
 	if dynamicInformerInstance == nil {
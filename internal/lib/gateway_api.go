@@ -0,0 +1,191 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+	gatewayv1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1alpha2"
+)
+
+// Gateway API objects are watched through the dynamic informers, the helpers below convert the cached
+// unstructured objects to the typed gateway.networking.k8s.io/v1 and v1alpha2 structs.
+
+// ConvertUnstructuredObj converts an object received from a dynamic informer to the typed object out.
+func ConvertUnstructuredObj(obj interface{}, out interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("object of type %T is not unstructured", obj)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), out)
+}
+
+func gatewayAPIInformersReady() bool {
+	informers := GetDynamicInformers()
+	return informers != nil && informers.GatewayInformer != nil
+}
+
+func GetGatewayAPIGatewayClass(name string) (*gatewayv1.GatewayClass, error) {
+	if !gatewayAPIInformersReady() {
+		return nil, errors.New("gateway api informers not initialized")
+	}
+	obj, err := GetDynamicInformers().GatewayClassInformer.Lister().Get(name)
+	if err != nil {
+		return nil, err
+	}
+	gwClass := &gatewayv1.GatewayClass{}
+	if err := ConvertUnstructuredObj(obj, gwClass); err != nil {
+		return nil, err
+	}
+	return gwClass, nil
+}
+
+func GetGatewayAPIGateway(namespace, name string) (*gatewayv1.Gateway, error) {
+	if !gatewayAPIInformersReady() {
+		return nil, errors.New("gateway api informers not initialized")
+	}
+	obj, err := GetDynamicInformers().GatewayInformer.Lister().ByNamespace(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	gw := &gatewayv1.Gateway{}
+	if err := ConvertUnstructuredObj(obj, gw); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+// GetGatewayAPIRouteInformer returns the informer of the route kind, which is nil if the kind is not watched.
+func GetGatewayAPIRouteInformer(kind string) informers.GenericInformer {
+	if !gatewayAPIInformersReady() {
+		return nil
+	}
+	switch kind {
+	case HTTPRoute:
+		return GetDynamicInformers().HTTPRouteInformer
+	case TLSRoute:
+		return GetDynamicInformers().TLSRouteInformer
+	case TCPRoute:
+		return GetDynamicInformers().TCPRouteInformer
+	case UDPRoute:
+		return GetDynamicInformers().UDPRouteInformer
+	}
+	return nil
+}
+
+func getGatewayAPIRoute(kind, namespace, name string, out interface{}) error {
+	informer := GetGatewayAPIRouteInformer(kind)
+	if informer == nil {
+		return fmt.Errorf("gateway api informer for %s not initialized", kind)
+	}
+	obj, err := informer.Lister().ByNamespace(namespace).Get(name)
+	if err != nil {
+		return err
+	}
+	return ConvertUnstructuredObj(obj, out)
+}
+
+func GetHTTPRoute(namespace, name string) (*gatewayv1.HTTPRoute, error) {
+	route := &gatewayv1.HTTPRoute{}
+	if err := getGatewayAPIRoute(HTTPRoute, namespace, name, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
+func GetTLSRoute(namespace, name string) (*gatewayv1alpha2.TLSRoute, error) {
+	route := &gatewayv1alpha2.TLSRoute{}
+	if err := getGatewayAPIRoute(TLSRoute, namespace, name, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
+func GetTCPRoute(namespace, name string) (*gatewayv1alpha2.TCPRoute, error) {
+	route := &gatewayv1alpha2.TCPRoute{}
+	if err := getGatewayAPIRoute(TCPRoute, namespace, name, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
+func GetUDPRoute(namespace, name string) (*gatewayv1alpha2.UDPRoute, error) {
+	route := &gatewayv1alpha2.UDPRoute{}
+	if err := getGatewayAPIRoute(UDPRoute, namespace, name, route); err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
+// GetGatewayAPIRouteKey returns the key of the route used in the Gateway to route relationships.
+// HTTPRoutes are stored in namespace/name format, the other route kinds are prefixed with the kind.
+func GetGatewayAPIRouteKey(kind, namespace, name string) string {
+	if kind == HTTPRoute {
+		return namespace + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// ParseGatewayAPIRouteKey returns the kind, namespace and name of the route from the key built by GetGatewayAPIRouteKey.
+func ParseGatewayAPIRouteKey(routeKey string) (string, string, string) {
+	keySplit := strings.Split(routeKey, "/")
+	if len(keySplit) == 3 {
+		return keySplit[0], keySplit[1], keySplit[2]
+	}
+	if len(keySplit) == 2 {
+		return HTTPRoute, keySplit[0], keySplit[1]
+	}
+	return "", "", routeKey
+}
+
+// IsGatewayAPIGatewayClassValid returns true if the GatewayClass is handled by AKO.
+func IsGatewayAPIGatewayClassValid(gwClass *gatewayv1.GatewayClass) bool {
+	return string(gwClass.Spec.ControllerName) == GatewayAPIAviController
+}
+
+// IsGatewayAPIGatewayValid returns true if the Gateway refers to a GatewayClass handled by AKO.
+func IsGatewayAPIGatewayValid(gw *gatewayv1.Gateway) bool {
+	gwClass, err := GetGatewayAPIGatewayClass(string(gw.Spec.GatewayClassName))
+	if err != nil {
+		return false
+	}
+	return IsGatewayAPIGatewayClassValid(gwClass)
+}
+
+// GetParentGatewayNamespace returns the namespace of the parent Gateway, which defaults to the route namespace.
+func GetParentGatewayNamespace(parentRef gatewayv1.ParentReference, routeNamespace string) string {
+	if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+		return string(*parentRef.Namespace)
+	}
+	return routeNamespace
+}
+
+// IsGatewayParentRef returns true if the parentRef points to a gateway.networking.k8s.io Gateway.
+func IsGatewayParentRef(parentRef gatewayv1.ParentReference) bool {
+	if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1.GroupName {
+		return false
+	}
+	if parentRef.Kind != nil && string(*parentRef.Kind) != Gateway {
+		return false
+	}
+	return true
+}
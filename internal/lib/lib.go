@@ -87,6 +87,9 @@ type ServiceMetadataObj struct {
 	Gateway               string      `json:"gateway"` // ns/name
 	InsecureEdgeTermAllow bool        `json:"insecureedgetermallow"`
 	IsMCIIngress          bool        `json:"is_mci_ingress"`
+	IsHTTPRoute           bool        `json:"is_httproute"`
+	IsTLSRoute            bool        `json:"is_tlsroute"`
+	IsGatewayAPI          bool        `json:"is_gatewayapi"`
 }
 
 type ServiceMetadataMappingObjType string
@@ -120,6 +123,10 @@ func (c ServiceMetadataObj) ServiceMetadataMapping(objType string) ServiceMetada
 		// 1) Advl4 Pools: without hostname information
 		// 2) SvcApi Pools: with hostname information
 		// 3) SharedVip SvcLB Pools: with hostname information
+		// 4) Gateway API TCPRoute/UDPRoute Pools: status is set on the Gateway via the VS
+		if c.IsGatewayAPI {
+			return ""
+		}
 		return GatewayPool
 	} else if c.Namespace != "" && c.IngressName != "" {
 		// Check for `Namespace` and `IngressName` in Pool serviceMetadata. Present in case of
@@ -373,6 +380,12 @@ func GetL7SharedPGName(vsName string) string {
 	return l7PGName
 }
 
+// GetDefaultBackendName returns the name of the pool and the poolgroup built for the
+// Ingress default backend on the virtual service vsName.
+func GetDefaultBackendName(vsName string) string {
+	return Encode(vsName+"-default-backend", Pool)
+}
+
 func GetPassthroughPGName(hostname, infrasettingName string) string {
 	var pgName string
 	if infrasettingName != "" {
@@ -420,6 +433,53 @@ func GetHeaderRewritePolicy(vsName, localHost string) string {
 	return headerWriterPolicy
 }
 
+// GetRateLimitPolicy returns the name of the http policyset which holds the rate limit of the host on the vs.
+func GetRateLimitPolicy(vsName string) string {
+	rateLimitPolicy := vsName + "--rate-limit"
+	CheckObjectNameLength(rateLimitPolicy, RateLimitPolicy)
+	return rateLimitPolicy
+}
+
+// GetClientAuthName returns the name of the PKI profile and application profile that validate the client
+// certificates for the virtualservice.
+func GetClientAuthName(vsName string) string {
+	clientAuthName := vsName + "--client-auth"
+	CheckObjectNameLength(clientAuthName, ApplicationProfile)
+	return clientAuthName
+}
+
+// GetPersistenceProfileName returns the name of the client IP persistence profile of the pools of the
+// virtualservice, for the Services with the ClientIP session affinity.
+func GetPersistenceProfileName(vsName string) string {
+	persistenceProfileName := vsName + PersistenceProfileSuffix
+	CheckObjectNameLength(persistenceProfileName, ApplicationPersistenceProfile)
+	return persistenceProfileName
+}
+
+// GetNetworkSecurityPolicyName returns the name of the network security policy of the virtualservice, which
+// allows the clients only from the loadBalancerSourceRanges of the Service.
+func GetNetworkSecurityPolicyName(vsName string) string {
+	networkSecurityPolicyName := vsName + NetworkSecurityPolicySuffix
+	CheckObjectNameLength(networkSecurityPolicyName, NetworkSecurityPolicy)
+	return networkSecurityPolicyName
+}
+
+// GetReadinessProbeHealthMonitorName returns the name of the health monitor of the pool, which is derived from
+// the readiness probe of the Pods of the backend Service.
+func GetReadinessProbeHealthMonitorName(poolName string) string {
+	healthMonitorName := poolName + ReadinessProbeHealthMonitorSuffix
+	CheckObjectNameLength(healthMonitorName, HealthMonitor)
+	return healthMonitorName
+}
+
+// GetHTTPRuleHealthMonitorName returns the name of a health monitor of the pool, which is defined inline in the
+// path of an HTTPRule.
+func GetHTTPRuleHealthMonitorName(poolName, healthMonitorName string) string {
+	name := poolName + "-" + healthMonitorName + HTTPRuleHealthMonitorSuffix
+	CheckObjectNameLength(name, HealthMonitor)
+	return name
+}
+
 func GetSniNodeName(infrasetting, sniHostName string) string {
 	namePrefix := NamePrefix
 	if infrasetting != "" {
@@ -468,6 +528,12 @@ func GetSniHppMapName(ingName, namespace, host, path, infrasetting string, dedic
 	return Encode(hppmap, HPPMAP)
 }
 
+// GetHTTPRuleMatchName returns the name of the pool, poolgroup and http policy rule built for the match at
+// index in the HTTPRule of a path, from the name of the poolgroup of the path.
+func GetHTTPRuleMatchName(pgName string, index int) string {
+	return Encode(pgName+"-match-"+strconv.Itoa(index), PG)
+}
+
 func GetSniPGName(ingName, namespace, host, path, infrasetting string, dedicatedVS bool) string {
 	path = strings.ReplaceAll(path, "/", "_")
 	var sniPGName string
@@ -882,6 +948,83 @@ func UseServicesAPI() bool {
 	return false
 }
 
+// UseGatewayAPI returns true if AKO is configured to watch gateway.networking.k8s.io/v1
+// Gateway, GatewayClass and HTTPRoute objects.
+func UseGatewayAPI() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_GATEWAY_API)); ok {
+		return true
+	}
+	return false
+}
+
+// GetServerDrainPeriod returns the period for which the servers of the terminating pods, or of the nodes
+// being drained in NodePort mode, are kept in the pools as disabled before these are removed. Draining is
+// disabled when the period is 0.
+func GetServerDrainPeriod() time.Duration {
+	drainPeriodStr := os.Getenv(SERVER_DRAIN_PERIOD)
+	if drainPeriodStr == "" {
+		return 0
+	}
+	drainPeriod, err := strconv.Atoi(drainPeriodStr)
+	if err != nil || drainPeriod < 0 {
+		utils.AviLog.Warnf("Invalid value %s for serverDrainPeriod, servers will not be drained", drainPeriodStr)
+		return 0
+	}
+	return time.Duration(drainPeriod) * time.Second
+}
+
+// IsPodReadinessGateEnabled returns true if AKO is configured to set the pool server readiness gate
+// condition of the Pods.
+func IsPodReadinessGateEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_POD_READINESS_GATE)); ok {
+		return true
+	}
+	return false
+}
+
+// GetLoadBalancerClass returns the loadBalancerClass of the Services of type LoadBalancer that AKO handles.
+// With an empty value, AKO handles only the Services without a loadBalancerClass.
+func GetLoadBalancerClass() string {
+	return strings.TrimSpace(os.Getenv(LOAD_BALANCER_CLASS))
+}
+
+// AllowNoLoadBalancerClass returns true if AKO handles the Services of type LoadBalancer without a
+// loadBalancerClass, when a loadBalancerClass is configured. Defaults to true.
+func AllowNoLoadBalancerClass() bool {
+	if allow, err := strconv.ParseBool(os.Getenv(ALLOW_NO_LOAD_BALANCER_CLASS)); err == nil {
+		return allow
+	}
+	return true
+}
+
+// IsReadinessProbeHealthMonitorEnabled returns true if AKO is configured to create the health monitors of the
+// pools from the readiness probes of the Pods of the backend Services.
+func IsReadinessProbeHealthMonitorEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(READINESS_PROBE_HEALTH_MONITOR)); ok {
+		return true
+	}
+	return false
+}
+
+// IsDryRunEnabled returns true if AKO is configured to only plan the REST operations to the Avi Controller,
+// without making these.
+func IsDryRunEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(DRY_RUN)); ok {
+		return true
+	}
+	return false
+}
+
+// GetTracingExporter returns the exporter of the traces of the keys, otlp or file. The tracing is disabled if empty.
+func GetTracingExporter() string {
+	return os.Getenv(TRACING_EXPORTER)
+}
+
+// GetTracingEndpoint returns the URL of the OTLP/HTTP endpoint, or the path of the file, to export the traces to.
+func GetTracingEndpoint() string {
+	return os.Getenv(TRACING_ENDPOINT)
+}
+
 // CompareVersions compares version v1 against version v2.
 func CompareVersions(v1, cmpSign, v2 string) bool {
 	if c, err := semver.NewConstraint(cmpSign + v2); err == nil {
@@ -1194,20 +1337,39 @@ func PopulatePassthroughPoolMarkers(host, svcName, infrasettingName string) util
 	return markers
 }
 
-func InformersToRegister(kclient *kubernetes.Clientset, oclient *oshiftclient.Clientset) ([]string, error) {
+// IsEndpointSliceEnabled checks if the cluster serves the discovery.k8s.io/v1 EndpointSlices.
+func IsEndpointSliceEnabled(kclient kubernetes.Interface) bool {
+	informerTimeout := int64(120)
+	_, err := kclient.DiscoveryV1().EndpointSlices(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{Limit: 1, TimeoutSeconds: &informerTimeout})
+	if err != nil {
+		utils.AviLog.Infof("EndpointSlices are not available, Endpoints would be used instead: %v", err)
+		return false
+	}
+	return true
+}
+
+func InformersToRegister(kclient kubernetes.Interface, oclient oshiftclient.Interface) ([]string, error) {
 	var isOshift bool
 	// Initialize the following informers in all AKO deployments. Provide AKO the ability to watch over
 	// Services, Endpoints, Secrets, ConfigMaps and Namespaces.
 	allInformers := []string{
 		utils.ServiceInformer,
-		utils.EndpointInformer,
 		utils.SecretInformer,
 		utils.ConfigMapInformer,
 		utils.NSInformer,
 	}
 
-	// AKO must watch over Pods in case of NodePortLocal, to get Antrea annotation values.
-	if GetServiceType() == NodePortLocal {
+	// Watch over EndpointSlices, which are not truncated for large Services, if the cluster serves
+	// discovery.k8s.io/v1. Endpoints are watched over only in older clusters.
+	if IsEndpointSliceEnabled(kclient) {
+		allInformers = append(allInformers, utils.EndpointSlicesInformer)
+	} else {
+		allInformers = append(allInformers, utils.EndpointInformer)
+	}
+
+	// AKO must watch over Pods in case of NodePortLocal, to get Antrea annotation values, to set the
+	// pool server readiness gate condition of the Pods, and to get the readiness probes of the Pods.
+	if GetServiceType() == NodePortLocal || IsPodReadinessGateEnabled() || IsReadinessProbeHealthMonitorEnabled() {
 		allInformers = append(allInformers, utils.PodInformer)
 	}
 
@@ -1283,7 +1445,84 @@ func SSLKeyCertChecksum(sslName, certificate, cacert string, ingestionMarkers ut
 	return checksum
 }
 
-func L4PolicyChecksum(ports []int64, protocols []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+func ApplicationProfileChecksum(appProfileName, clientCertMode, pkiProfileName string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(appProfileName + clientCertMode + pkiProfileName)
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func PersistenceProfileChecksum(persistenceProfileName string, timeout int32, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(persistenceProfileName + strconv.Itoa(int(timeout)))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// HealthMonitorChecksum returns the checksum of a health monitor of a pool, which is derived from the readiness
+// probe of the Pods of the backend Service, or defined inline in an HTTPRule.
+func HealthMonitorChecksum(healthMonitorName, monitorType, httpRequest, tcpRequest string, responseCodes []string, monitorPort, sendInterval, receiveTimeout, successfulChecks, failedChecks int32, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	checksum := utils.Hash(healthMonitorName + monitorType + httpRequest + tcpRequest)
+	codes := make([]string, len(responseCodes))
+	copy(codes, responseCodes)
+	sort.Strings(codes)
+	checksum += utils.Hash(utils.Stringify(codes))
+	checksum += utils.Hash(utils.Stringify([]int32{monitorPort, sendInterval, receiveTimeout, successfulChecks, failedChecks}))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func NetworkSecurityPolicyChecksum(networkSecurityPolicyName string, sourceRanges []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	ranges := make([]string, len(sourceRanges))
+	copy(ranges, sourceRanges)
+	sort.Strings(ranges)
+	checksum := utils.Hash(networkSecurityPolicyName) + utils.Hash(utils.Stringify(ranges))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+// GetNetworkSecurityPolicySourceRanges returns the source ranges that are allowed by the network security policy
+// created for the loadBalancerSourceRanges of a Service.
+func GetNetworkSecurityPolicySourceRanges(networkSecurityPolicy *models.NetworkSecurityPolicy) []string {
+	var sourceRanges []string
+	for _, rule := range networkSecurityPolicy.Rules {
+		if rule.Match == nil || rule.Match.ClientIP == nil || rule.Match.ClientIP.MatchCriteria == nil ||
+			*rule.Match.ClientIP.MatchCriteria != "IS_NOT_IN" {
+			continue
+		}
+		for _, prefix := range rule.Match.ClientIP.Prefixes {
+			if prefix.IPAddr == nil || prefix.IPAddr.Addr == nil || prefix.Mask == nil {
+				continue
+			}
+			sourceRanges = append(sourceRanges, fmt.Sprintf("%s/%d", *prefix.IPAddr.Addr, *prefix.Mask))
+		}
+	}
+	return sourceRanges
+}
+
+func L4PolicyChecksum(ports []int64, protocols, poolGroups []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
 	var portsInt []int
 	for _, port := range ports {
 		portsInt = append(portsInt, int(port))
@@ -1291,6 +1530,11 @@ func L4PolicyChecksum(ports []int64, protocols []string, ingestionMarkers utils.
 	sort.Ints(portsInt)
 	sort.Strings(protocols)
 	checksum := utils.Hash(utils.Stringify(portsInt)) + utils.Hash(utils.Stringify(protocols))
+	// The pool groups are selected instead of the pools for the ports with zone awareness.
+	if len(poolGroups) > 0 {
+		sort.Strings(poolGroups)
+		checksum += utils.Hash(utils.Stringify(poolGroups))
+	}
 	if populateCache {
 		if markers != nil {
 			checksum += ObjectLabelChecksum(markers)
@@ -1830,6 +2074,65 @@ func UpdateV6(vip *models.Vip, vipNetwork *akov1alpha1.AviInfraSettingVipNetwork
 	}
 }
 
+// UpdateVipIPFamily sets the IP families of the VIP to be allocated, as required by the ipFamilies of a Service.
+func UpdateVipIPFamily(vip *models.Vip, ipFamily string) {
+	switch ipFamily {
+	case "V4":
+		vip.AutoAllocateIPType = proto.String(IPTypeV4Only)
+	case "V6":
+		vip.AutoAllocateIPType = proto.String(IPTypeV6Only)
+	case IPTypeV4V6:
+		vip.AutoAllocateIPType = proto.String(IPTypeV4V6)
+	}
+}
+
+// GetServiceIPFamilies returns the IP families of the Service as V4, V6 or V4_V6, based on spec.ipFamilies and
+// spec.ipFamilyPolicy. An empty value is returned if the Service does not specify these, if these are the ipFamily
+// that AKO is configured with, or if the cloud does not support IPv6, in which case the ipFamily that AKO is
+// configured with applies.
+func GetServiceIPFamilies(svcObj *corev1.Service) string {
+	if svcObj == nil || len(svcObj.Spec.IPFamilies) == 0 || GetCloudType() != CLOUD_VCENTER {
+		return ""
+	}
+	families := svcObj.Spec.IPFamilies
+	if svcObj.Spec.IPFamilyPolicy == nil || *svcObj.Spec.IPFamilyPolicy == corev1.IPFamilyPolicySingleStack {
+		families = families[:1]
+	}
+	hasV4, hasV6 := false, false
+	for _, family := range families {
+		switch family {
+		case corev1.IPv4Protocol:
+			hasV4 = true
+		case corev1.IPv6Protocol:
+			hasV6 = true
+		}
+	}
+	ipFamily := ""
+	if hasV4 && hasV6 {
+		ipFamily = "V4_V6"
+	} else if hasV6 {
+		ipFamily = "V6"
+	} else if hasV4 {
+		ipFamily = "V4"
+	}
+	if ipFamily == GetIPFamily() {
+		return ""
+	}
+	return ipFamily
+}
+
+// GetServiceIPFamily returns the IP families of the servers of the pools of the Service, which are the IP families
+// of the Service, if it specifies these, or the ipFamily that AKO is configured with.
+func GetServiceIPFamily(namespace, serviceName string) string {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(serviceName)
+	if err == nil {
+		if ipFamily := GetServiceIPFamilies(svcObj); ipFamily != "" {
+			return ipFamily
+		}
+	}
+	return GetIPFamily()
+}
+
 var IPfamily string
 
 func SetIPFamily() {
@@ -1997,6 +2300,28 @@ func GetIPFromNode(node *v1.Node) (string, string) {
 	return nodeV4, nodeV6
 }
 
+// GetIngressBackendWeights returns the weights of the backend Services of an Ingress, specified in the
+// ako.vmware.com/backend-weights annotation as a JSON map of service name to weight. The weights are used
+// as the ratio of the pools in the poolgroup of a path, and must be in the range 0-1000.
+func GetIngressBackendWeights(annotations map[string]string) map[string]int32 {
+	backendWeights := make(map[string]int32)
+	value, ok := annotations[BackendWeightsAnnotation]
+	if !ok || value == "" {
+		return backendWeights
+	}
+	if err := json.Unmarshal([]byte(value), &backendWeights); err != nil {
+		utils.AviLog.Warnf("Invalid value %s for annotation %s, err: %v", value, BackendWeightsAnnotation, err)
+		return make(map[string]int32)
+	}
+	for svc, weight := range backendWeights {
+		if weight < 0 || weight > MaxBackendWeight {
+			utils.AviLog.Warnf("Invalid weight %d for service %s in annotation %s, allowed range is 0-%d", weight, svc, BackendWeightsAnnotation, MaxBackendWeight)
+			delete(backendWeights, svc)
+		}
+	}
+	return backendWeights
+}
+
 func init() {
 	seGroupToUse := os.Getenv(SEG_NAME)
 	if seGroupToUse == "" {
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1beta1"
+)
+
+const (
+	// IngressGroup and CoreGroup are the API groups of the Ingress and the Service, as referred in
+	// the from and to sections of a ReferenceGrant.
+	IngressGroup = "networking.k8s.io"
+	CoreGroup    = ""
+)
+
+// IsReferenceGrantEnabled returns true if the ReferenceGrants are being watched by AKO.
+func IsReferenceGrantEnabled() bool {
+	informers := GetDynamicInformers()
+	return informers != nil && informers.ReferenceGrantInformer != nil
+}
+
+// GetReferenceGrants returns the ReferenceGrants present in the namespace.
+func GetReferenceGrants(namespace string) ([]*gatewayv1beta1.ReferenceGrant, error) {
+	if !IsReferenceGrantEnabled() {
+		return nil, fmt.Errorf("referencegrant informer not initialized")
+	}
+	objs, err := GetDynamicInformers().ReferenceGrantInformer.Lister().ByNamespace(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var grants []*gatewayv1beta1.ReferenceGrant
+	for _, obj := range objs {
+		grant := &gatewayv1beta1.ReferenceGrant{}
+		if err := ConvertUnstructuredObj(obj, grant); err != nil {
+			utils.AviLog.Warnf("Unable to convert ReferenceGrant object, err: %v", err)
+			continue
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// IsReferenceGranted checks whether a ReferenceGrant in toNamespace allows objects of fromGroup/fromKind
+// in fromNamespace to refer to the object toGroup/toKind/toName.
+func IsReferenceGranted(fromGroup, fromKind, fromNamespace, toGroup, toKind, toNamespace, toName string) bool {
+	if fromNamespace == toNamespace {
+		return true
+	}
+	grants, err := GetReferenceGrants(toNamespace)
+	if err != nil {
+		utils.AviLog.Debugf("Unable to get ReferenceGrants in namespace %s, err: %v", toNamespace, err)
+		return false
+	}
+	for _, grant := range grants {
+		fromMatched := false
+		for _, from := range grant.Spec.From {
+			if string(from.Group) == fromGroup && string(from.Kind) == fromKind && string(from.Namespace) == fromNamespace {
+				fromMatched = true
+				break
+			}
+		}
+		if !fromMatched {
+			continue
+		}
+		for _, to := range grant.Spec.To {
+			if string(to.Group) != toGroup || string(to.Kind) != toKind {
+				continue
+			}
+			if to.Name == nil || *to.Name == "" || string(*to.Name) == toName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetIngressBackendNamespaces returns the namespaces of the backend Services of an Ingress, specified
+// in the ako.vmware.com/backend-namespaces annotation as a JSON map of service name to namespace.
+func GetIngressBackendNamespaces(annotations map[string]string) map[string]string {
+	backendNamespaces := make(map[string]string)
+	value, ok := annotations[BackendNamespacesAnnotation]
+	if !ok || value == "" {
+		return backendNamespaces
+	}
+	if err := json.Unmarshal([]byte(value), &backendNamespaces); err != nil {
+		utils.AviLog.Warnf("Invalid value %s for annotation %s, err: %v", value, BackendNamespacesAnnotation, err)
+		return make(map[string]string)
+	}
+	return backendNamespaces
+}
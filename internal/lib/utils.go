@@ -54,11 +54,23 @@ func ExtractTypeNameNamespace(key string) (string, string, string) {
 func isServiceLBType(svcObj *corev1.Service) bool {
 	// If we don't find a service or it is not of type loadbalancer - return false.
 	if svcObj.Spec.Type == "LoadBalancer" {
-		return true
+		return IsServiceLBClassAccepted(svcObj)
 	}
 	return false
 }
 
+// IsServiceLBClassAccepted returns true if the loadBalancerClass of the Service matches the loadBalancerClass
+// that AKO is configured with. The Services without a loadBalancerClass are accepted, unless AKO is configured
+// with a loadBalancerClass and to not handle these. When AKO is not configured with a loadBalancerClass, only
+// the Services without a loadBalancerClass are accepted, the others belong to other load balancer implementations.
+func IsServiceLBClassAccepted(svcObj *corev1.Service) bool {
+	lbClass := GetLoadBalancerClass()
+	if svcObj.Spec.LoadBalancerClass == nil || *svcObj.Spec.LoadBalancerClass == "" {
+		return lbClass == "" || AllowNoLoadBalancerClass()
+	}
+	return *svcObj.Spec.LoadBalancerClass == lbClass
+}
+
 func IsServiceNodPortType(svcObj *corev1.Service) bool {
 	if svcObj.Spec.Type == NodePort {
 		return true
@@ -165,6 +177,35 @@ func GetPodsFromService(namespace, serviceName string, targetPortName intstr.Int
 	return pods, targetPort
 }
 
+// HasPodReadinessGate returns true if the Pod has the pool server readiness gate of AKO.
+func HasPodReadinessGate(pod *corev1.Pod) bool {
+	for _, readinessGate := range pod.Spec.ReadinessGates {
+		if readinessGate.ConditionType == PodReadinessGateConditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPodPendingReadinessGate returns true if the containers of the Pod are ready, and the Pod is not ready
+// only because the pool server readiness gate is not yet True. The servers of such Pods are added to the
+// pools, so that their health can be reported by Avi.
+func IsPodPendingReadinessGate(namespace, podName string) bool {
+	if !IsPodReadinessGateEnabled() || utils.GetInformers().PodInformer == nil {
+		return false
+	}
+	pod, err := utils.GetInformers().PodInformer.Lister().Pods(namespace).Get(podName)
+	if err != nil || pod.GetDeletionTimestamp() != nil || !HasPodReadinessGate(pod) {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.ContainersReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func GetServicesForPod(pod *corev1.Pod) ([]string, []string) {
 	var svcList, lbList []string
 	services, err := utils.GetInformers().ServiceInformer.Lister().List(labels.Everything())
@@ -178,7 +219,7 @@ func GetServicesForPod(pod *corev1.Pod) ([]string, []string) {
 			continue
 		}
 		svcKey := svc.Namespace + "/" + svc.Name
-		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if isServiceLBType(svc) {
 			lbList = append(lbList, svcKey)
 		}
 		if svc.Spec.Type != corev1.ServiceTypeNodePort {
@@ -297,15 +297,27 @@ func (o *AviObjectGraph) ConstructSvcApiL4VsNode(gatewayName, namespace, key str
 
 func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, namespace, key string) {
 	var l4Policies []*AviL4PolicyNode
-	found, svcListeners := objects.ServiceGWLister().GetGwToSvcs(namespace + "/" + gwName)
-	foundGW, gwListeners := objects.ServiceGWLister().GetGWListeners(namespace + "/" + gwName)
-	if !found || !foundGW {
-		return
+	var svcListeners map[string][]string
+	var gwListeners []string
+	var svcPorts map[string]int32
+	isGatewayAPI := vsNode.ServiceMetadata.IsGatewayAPI
+	if isGatewayAPI {
+		svcListeners, gwListeners, svcPorts = getGatewayAPIL4Listeners(namespace + "/" + gwName)
+		if len(gwListeners) == 0 {
+			return
+		}
+	} else {
+		found, svcGwListeners := objects.ServiceGWLister().GetGwToSvcs(namespace + "/" + gwName)
+		foundGW, listeners := objects.ServiceGWLister().GetGWListeners(namespace + "/" + gwName)
+		if !found || !foundGW {
+			return
+		}
+		svcListeners, gwListeners = svcGwListeners, listeners
 	}
 
 	// create a mapping of portProto to hostname
 	gwListenerHostNameMapping := make(map[string]string)
-	if lib.UseServicesAPI() {
+	if lib.UseServicesAPI() && !isGatewayAPI {
 		// enable fqdn for gateway services only for non-advancedl4 usecases.
 		gw, err := lib.AKOControlConfig().SvcAPIInformers().GatewayInformer.Lister().Gateways(namespace).Get(gwName)
 		if err != nil {
@@ -320,7 +332,7 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 	}
 
 	var infraSetting *v1alpha1.AviInfraSetting
-	if lib.UseServicesAPI() {
+	if lib.UseServicesAPI() && !isGatewayAPI {
 		gw, err := lib.AKOControlConfig().SvcAPIInformers().GatewayInformer.Lister().Gateways(namespace).Get(gwName)
 		if err != nil {
 			utils.AviLog.Warnf("key: %s, msg: GatewayLister returned error for services APIs : %s", err)
@@ -334,6 +346,25 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 		}
 	}
 
+	if isGatewayAPI {
+		gw, err := lib.GetGatewayAPIGateway(namespace, gwName)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: Gateway deleted, not constructing the pool nodes", key)
+			return
+		}
+		for _, gwlistener := range gw.Spec.Listeners {
+			if gwlistener.Hostname != nil && string(*gwlistener.Hostname) != "" {
+				gwListenerHostNameMapping[fmt.Sprintf("%s/%d", gwlistener.Protocol, gwlistener.Port)] = string(*gwlistener.Hostname)
+			}
+		}
+		// configures the pool nodes using infraSetting object referred by the GatewayClass.
+		infraSetting, err = getGatewayAPIGatewayInfraSetting(key, gw)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: Error while fetching infrasetting for Gateway %s", key, err.Error())
+			return
+		}
+	}
+
 	t1lr := objects.SharedWCPLister().GetT1LrForNamespace(namespace)
 
 	var portPoolSet []AviHostPathPortPoolPG
@@ -355,7 +386,7 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 		}
 
 		poolName := lib.GetAdvL4PoolName(svcNSName[1], namespace, gwName, int32(port))
-		if lib.UseServicesAPI() {
+		if lib.UseServicesAPI() || isGatewayAPI {
 			poolName = lib.GetSvcApiL4PoolName(svcNSName[1], namespace, gwName, portProto[0], int32(port))
 		}
 
@@ -366,6 +397,7 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 			PortName: "",
 			ServiceMetadata: lib.ServiceMetadataObj{
 				NamespaceServiceName: []string{svc[0]},
+				IsGatewayAPI:         isGatewayAPI,
 			},
 			VrfContext: lib.GetVrf(),
 		}
@@ -388,9 +420,13 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 			utils.AviLog.Warnf("key: %s, msg: error while retrieving service: %s", key, err)
 			return
 		}
-		// Obtain the matching portname from the svcObj
+		// Obtain the matching portname from the svcObj, the backendRef port is used for the Gateway API routes.
+		targetPort := int32(port)
+		if isGatewayAPI {
+			targetPort = svcPorts[listener]
+		}
 		for _, svcPort := range svcObj.Spec.Ports {
-			if svcPort.Port == int32(port) {
+			if svcPort.Port == targetPort {
 				poolNode.PortName = svcPort.Name
 			}
 		}
@@ -410,7 +446,7 @@ func (o *AviObjectGraph) ConstructAdvL4PolPoolNodes(vsNode *AviVsNode, gwName, n
 			}
 		}
 
-		if lib.UseServicesAPI() {
+		if lib.UseServicesAPI() || isGatewayAPI {
 			poolNode.AviMarkers = lib.PopulateSvcApiL4PoolNodeMarkers(namespace, svcNSName[1], gwName, portProto[0], port)
 		} else {
 			poolNode.AviMarkers = lib.PopulateAdvL4PoolNodeMarkers(namespace, svcNSName[1], gwName, port)
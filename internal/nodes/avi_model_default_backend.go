@@ -0,0 +1,408 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+var defaultBackendListerInstance *DefaultBackendLister
+var dbonce sync.Once
+
+func SharedDefaultBackendLister() *DefaultBackendLister {
+	dbonce.Do(func() {
+		defaultBackendListerInstance = &DefaultBackendLister{
+			vsStore:      objects.NewObjectMapStore(),
+			ingressStore: objects.NewObjectMapStore(),
+		}
+	})
+	return defaultBackendListerInstance
+}
+
+// DefaultBackendLister keeps track of the Ingresses declaring spec.defaultBackend for a virtual service,
+// since a VS can have a single default backend.
+// cache sample: vsStore: admin/cluster--Shared-L7-0 -> [ns1/ingress1, ns2/ingress2]
+// ingressStore: ns1/ingress1 -> [{admin/cluster--Shared-L7-0 cluster--Shared-L7-0 }]
+type DefaultBackendLister struct {
+	sync.RWMutex
+	vsStore      *objects.ObjectMapStore
+	ingressStore *objects.ObjectMapStore
+}
+
+// DefaultBackendVS is the virtual service on which the default backend of an Ingress is applied, the EVH child
+// of a host when EvhNodeName is set.
+type DefaultBackendVS struct {
+	ModelName   string
+	VSName      string
+	EvhNodeName string
+	Dedicated   bool
+}
+
+func (d DefaultBackendVS) key() string {
+	if d.EvhNodeName != "" {
+		return d.ModelName + "/" + d.EvhNodeName
+	}
+	return d.ModelName
+}
+
+// UpdateIngressMappings replaces the virtual services for the default backend of the ingress, and returns
+// the virtual services stored earlier.
+func (d *DefaultBackendLister) UpdateIngressMappings(ingress string, vsList []DefaultBackendVS) []DefaultBackendVS {
+	d.Lock()
+	defer d.Unlock()
+	var oldVSList []DefaultBackendVS
+	if found, obj := d.ingressStore.Get(ingress); found {
+		oldVSList = obj.([]DefaultBackendVS)
+	}
+	for _, vs := range oldVSList {
+		_, ingresses := d.getVSIngresses(vs)
+		ingresses = utils.Remove(ingresses, ingress)
+		if len(ingresses) == 0 {
+			d.vsStore.Delete(vs.key())
+		} else {
+			d.vsStore.AddOrUpdate(vs.key(), ingresses)
+		}
+	}
+	for _, vs := range vsList {
+		_, ingresses := d.getVSIngresses(vs)
+		if !utils.HasElem(ingresses, ingress) {
+			ingresses = append(ingresses, ingress)
+		}
+		d.vsStore.AddOrUpdate(vs.key(), ingresses)
+	}
+	if len(vsList) == 0 {
+		d.ingressStore.Delete(ingress)
+	} else {
+		d.ingressStore.AddOrUpdate(ingress, vsList)
+	}
+	return oldVSList
+}
+
+// GetVSIngresses returns the ingresses declaring a default backend for the virtual service.
+func (d *DefaultBackendLister) GetVSIngresses(vs DefaultBackendVS) (bool, []string) {
+	d.RLock()
+	defer d.RUnlock()
+	return d.getVSIngresses(vs)
+}
+
+func (d *DefaultBackendLister) getVSIngresses(vs DefaultBackendVS) (bool, []string) {
+	found, obj := d.vsStore.Get(vs.key())
+	if !found {
+		return false, []string{}
+	}
+	ingresses := make([]string, len(obj.([]string)))
+	copy(ingresses, obj.([]string))
+	return true, ingresses
+}
+
+// ProcessDefaultBackend applies spec.defaultBackend of an ingress on the virtual services serving its hosts. For
+// an ingress without hosts, the shard VS derived for an empty hostname is used.
+func ProcessDefaultBackend(routeIgrObj RouteIngressModel, key string, parsedIng IngressConfig, modelList *[]string) {
+	if routeIgrObj.GetType() != utils.Ingress {
+		return
+	}
+	var vsList []DefaultBackendVS
+	if parsedIng.DefaultBackend != nil {
+		vsList = getDefaultBackendVSList(routeIgrObj, parsedIng, key)
+	}
+	ingress := routeIgrObj.GetNamespace() + "/" + routeIgrObj.GetName()
+	oldVSList := SharedDefaultBackendLister().UpdateIngressMappings(ingress, vsList)
+	updateDefaultBackendForVS(append(oldVSList, vsList...), key, modelList)
+}
+
+// DeleteDefaultBackend removes the default backend of a deleted ingress. If other ingresses declare a default
+// backend for the same virtual service, the oldest one is applied instead.
+func DeleteDefaultBackend(routeIgrObj RouteIngressModel, key string, fullsync bool, sharedQueue *utils.WorkerQueue) {
+	if routeIgrObj.GetType() != utils.Ingress {
+		return
+	}
+	var modelList []string
+	ingress := routeIgrObj.GetNamespace() + "/" + routeIgrObj.GetName()
+	oldVSList := SharedDefaultBackendLister().UpdateIngressMappings(ingress, nil)
+	updateDefaultBackendForVS(oldVSList, key, &modelList)
+	if !fullsync {
+		for _, modelName := range modelList {
+			PublishKeyToRestLayer(modelName, key, sharedQueue)
+		}
+	}
+}
+
+func getDefaultBackendVSList(routeIgrObj RouteIngressModel, parsedIng IngressConfig, key string) []DefaultBackendVS {
+	var hosts []string
+	for host := range parsedIng.IngressHostMap {
+		hosts = append(hosts, host)
+	}
+	for _, tlsSetting := range parsedIng.TlsCollection {
+		for host := range tlsSetting.Hosts {
+			if !utils.HasElem(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	var infraSettingName string
+	if infraSetting := routeIgrObj.GetAviInfraSetting(); infraSetting != nil {
+		infraSettingName = infraSetting.Name
+	}
+
+	var vsList []DefaultBackendVS
+	addVS := func(vs DefaultBackendVS) {
+		for _, existing := range vsList {
+			if existing.key() == vs.key() {
+				return
+			}
+		}
+		vsList = append(vsList, vs)
+	}
+	if len(hosts) == 0 {
+		var shardVsName lib.VSNameMetadata
+		if lib.IsEvhEnabled() {
+			_, shardVsName = DeriveShardVSForEvh("", key, routeIgrObj)
+		} else {
+			_, shardVsName = DeriveShardVS("", key, routeIgrObj)
+		}
+		if shardVsName.Dedicated {
+			utils.AviLog.Warnf("key: %s, msg: default backend of an ingress without hosts is not supported with dedicated virtual services", key)
+			return nil
+		}
+		addVS(DefaultBackendVS{ModelName: lib.GetModelName(lib.GetTenant(), shardVsName.Name), VSName: shardVsName.Name})
+		return vsList
+	}
+	for _, host := range hosts {
+		if lib.IsEvhEnabled() {
+			_, shardVsName := DeriveShardVSForEvh(host, key, routeIgrObj)
+			vs := DefaultBackendVS{ModelName: lib.GetModelName(lib.GetTenant(), shardVsName.Name), VSName: shardVsName.Name, Dedicated: shardVsName.Dedicated}
+			if !shardVsName.Dedicated {
+				vs.EvhNodeName = lib.GetEvhNodeName(host, infraSettingName)
+			}
+			addVS(vs)
+		} else {
+			_, shardVsName := DeriveShardVS(host, key, routeIgrObj)
+			addVS(DefaultBackendVS{ModelName: lib.GetModelName(lib.GetTenant(), shardVsName.Name), VSName: shardVsName.Name, Dedicated: shardVsName.Dedicated})
+		}
+	}
+	return vsList
+}
+
+// getDefaultBackendOwner returns the oldest ingress among the ones declaring a default backend for a virtual
+// service, along with the ingresses that are not applied.
+func getDefaultBackendOwner(ingresses []string, key string) (*networkingv1.Ingress, []*networkingv1.Ingress) {
+	var owner *networkingv1.Ingress
+	var conflicts []*networkingv1.Ingress
+	for _, ingress := range ingresses {
+		nsName := strings.Split(ingress, "/")
+		ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(nsName[0]).Get(nsName[1])
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: unable to get ingress %s: %v", key, ingress, err)
+			continue
+		}
+		if owner == nil {
+			owner = ingObj
+			continue
+		}
+		if ingObj.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(ingObj.CreationTimestamp.Equal(&owner.CreationTimestamp) && ingress < owner.Namespace+"/"+owner.Name) {
+			conflicts = append(conflicts, owner)
+			owner = ingObj
+		} else {
+			conflicts = append(conflicts, ingObj)
+		}
+	}
+	return owner, conflicts
+}
+
+func updateDefaultBackendForVS(vsList []DefaultBackendVS, key string, modelList *[]string) {
+	processed := make(map[string]bool)
+	for _, vs := range vsList {
+		if processed[vs.key()] {
+			continue
+		}
+		processed[vs.key()] = true
+
+		_, ingresses := SharedDefaultBackendLister().GetVSIngresses(vs)
+		owner, conflicts := getDefaultBackendOwner(ingresses, key)
+
+		found, aviModel := objects.SharedAviGraphLister().Get(vs.ModelName)
+		if (!found || aviModel == nil) && (owner == nil || vs.EvhNodeName != "" || vs.Dedicated) {
+			utils.AviLog.Debugf("key: %s, msg: model %s not found for the default backend", key, vs.ModelName)
+			continue
+		}
+
+		var ownerModel *K8sIngressModel
+		var backend *IngressHostPathSvc
+		if owner != nil {
+			ownerModel, _, _ = GetK8sIngressModel(owner.Name, owner.Namespace, key)
+			backend = NewNodesValidator().ParseDefaultBackendForIngress(owner.Namespace, owner.Spec, owner.GetAnnotations(), key)
+			for _, ingObj := range conflicts {
+				lib.AKOControlConfig().EventRecorder().Eventf(ingObj, corev1.EventTypeWarning, lib.DefaultBackendConflict,
+					"Default backend not applied on %s, default backend of ingress %s/%s is used", vs.key(), owner.Namespace, owner.Name)
+				utils.AviLog.Warnf("key: %s, msg: default backend of ingress %s/%s not applied on %s, default backend of ingress %s/%s is used",
+					key, ingObj.Namespace, ingObj.Name, vs.key(), owner.Namespace, owner.Name)
+			}
+		}
+
+		if !found || aviModel == nil {
+			// The shard VS of an ingress without hosts is built here, as no hosts are processed for it.
+			utils.AviLog.Infof("key: %s, msg: model not found, generating new model with name: %s", key, vs.ModelName)
+			aviModel = NewAviObjectGraph()
+			if lib.IsEvhEnabled() {
+				aviModel.(*AviObjectGraph).ConstructAviL7SharedVsNodeForEvh(vs.VSName, key, ownerModel, false, false)
+			} else {
+				aviModel.(*AviObjectGraph).ConstructAviL7VsNode(vs.VSName, key, ownerModel, false, false)
+			}
+		}
+
+		if lib.IsEvhEnabled() {
+			aviModel.(*AviObjectGraph).BuildDefaultBackendForEvh(vs, ownerModel, backend, key)
+		} else {
+			aviModel.(*AviObjectGraph).BuildDefaultBackend(vs, ownerModel, backend, key)
+		}
+		changedModel := saveAviModel(vs.ModelName, aviModel.(*AviObjectGraph), key)
+		if !utils.HasElem(modelList, vs.ModelName) && changedModel {
+			*modelList = append(*modelList, vs.ModelName)
+		}
+	}
+}
+
+// BuildDefaultBackend sets the pool group of the default backend on the shared or dedicated VS. The previous
+// default backend of the VS, if any, is removed when backend is nil.
+func (o *AviObjectGraph) BuildDefaultBackend(vs DefaultBackendVS, routeIgrObj RouteIngressModel, backend *IngressHostPathSvc, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+	vsNode := o.GetAviVS()
+	if len(vsNode) != 1 {
+		return
+	}
+	name := lib.GetDefaultBackendName(vsNode[0].Name)
+	o.RemovePoolNodeRefsFromSni(name, vsNode[0])
+	o.RemovePGNodeRefs(name, vsNode[0])
+	vsNode[0].DefaultPoolGroup = ""
+	if backend != nil {
+		poolNode, pgNode := buildDefaultBackendPoolPG(name, routeIgrObj, *backend, key)
+		vsNode[0].PoolRefs = append(vsNode[0].PoolRefs, poolNode)
+		vsNode[0].PoolGroupRefs = append(vsNode[0].PoolGroupRefs, pgNode)
+		vsNode[0].DefaultPoolGroup = pgNode.Name
+		utils.AviLog.Infof("key: %s, msg: default backend %s set on VS %s", key, name, vsNode[0].Name)
+	}
+	o.updateHTTPDataScript(vsNode[0])
+}
+
+// BuildDefaultBackendForEvh sets the pool of the default backend on the EVH child of a host, or the pool group
+// of the default backend on the EVH parent for ingresses without hosts.
+func (o *AviObjectGraph) BuildDefaultBackendForEvh(vs DefaultBackendVS, routeIgrObj RouteIngressModel, backend *IngressHostPathSvc, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+	vsNode := o.GetAviEvhVS()
+	if len(vsNode) != 1 {
+		return
+	}
+	if vs.EvhNodeName == "" {
+		name := lib.GetDefaultBackendName(vsNode[0].Name)
+		o.RemovePoolNodeRefsFromEvh(name, vsNode[0])
+		o.RemovePGNodeRefsForEvh(name, vsNode[0])
+		vsNode[0].DefaultPoolGroup = ""
+		if backend != nil {
+			poolNode, pgNode := buildDefaultBackendPoolPG(name, routeIgrObj, *backend, key)
+			vsNode[0].PoolRefs = append(vsNode[0].PoolRefs, poolNode)
+			vsNode[0].PoolGroupRefs = append(vsNode[0].PoolGroupRefs, pgNode)
+			vsNode[0].DefaultPoolGroup = pgNode.Name
+			utils.AviLog.Infof("key: %s, msg: default backend %s set on VS %s", key, name, vsNode[0].Name)
+		}
+		return
+	}
+
+	evhNode := vsNode[0].GetEvhNodeForName(vs.EvhNodeName)
+	if evhNode == nil {
+		utils.AviLog.Debugf("key: %s, msg: evh node %s not found for the default backend", key, vs.EvhNodeName)
+		return
+	}
+	name := lib.GetDefaultBackendName(evhNode.Name)
+	o.RemovePoolNodeRefsFromEvh(name, evhNode)
+	evhNode.DefaultPool = ""
+	if backend != nil {
+		poolNode, _ := buildDefaultBackendPoolPG(name, routeIgrObj, *backend, key)
+		evhNode.PoolRefs = append(evhNode.PoolRefs, poolNode)
+		evhNode.DefaultPool = poolNode.Name
+		utils.AviLog.Infof("key: %s, msg: default backend %s set on EVH node %s", key, name, evhNode.Name)
+	}
+}
+
+func buildDefaultBackendPoolPG(name string, routeIgrObj RouteIngressModel, backend IngressHostPathSvc, key string) (*AviPoolNode, *AviPoolGroupNode) {
+	poolNode := buildPoolNode(key, name, routeIgrObj.GetName(), routeIgrObj.GetNamespace(), "", "", routeIgrObj.GetAviInfraSetting(), "", nil, false, backend)
+	// The default backend is not selected through a priority label.
+	poolNode.PriorityLabel = ""
+
+	ratio := backend.weight
+	poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+	pgNode := &AviPoolGroupNode{
+		Name:    name,
+		Tenant:  lib.GetTenant(),
+		Members: []*avimodels.PoolGroupMember{{PoolRef: &poolRef, Ratio: &ratio}},
+	}
+	pgNode.AviMarkers = poolNode.AviMarkers
+	return poolNode, pgNode
+}
+
+// updateHTTPDataScript rebuilds the datascript of a shared VS. With a default backend, requests for hosts which
+// are not present in the shard poolgroup are sent to the poolgroup of the default backend.
+func (o *AviObjectGraph) updateHTTPDataScript(vsNode *AviVsNode) {
+	dsName := lib.GetL7InsecureDSName(vsNode.Name)
+	var dsNode *AviHTTPDataScriptNode
+	for _, ds := range vsNode.HTTPDSrefs {
+		if ds.Name == dsName {
+			dsNode = ds
+			break
+		}
+	}
+	if dsNode == nil || dsNode.DataScript == nil {
+		return
+	}
+	pgName := lib.GetL7SharedPGName(vsNode.Name)
+	if vsNode.DefaultPoolGroup == "" {
+		dsNode.PoolGroupRefs = []string{pgName}
+		dsNode.Script = fmt.Sprintf(utils.HTTP_DS_SCRIPT_MODIFIED, pgName)
+		return
+	}
+
+	var hosts []string
+	if pgNode := o.GetPoolGroupByName(pgName); pgNode != nil {
+		for _, member := range pgNode.Members {
+			if member.PriorityLabel == nil || *member.PriorityLabel == "" {
+				continue
+			}
+			host := strings.Split(*member.PriorityLabel, "/")[0]
+			if !utils.HasElem(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	sort.Strings(hosts)
+	var hostEntries []string
+	for _, host := range hosts {
+		hostEntries = append(hostEntries, fmt.Sprintf("[\"%s\"]=true", host))
+	}
+	dsNode.PoolGroupRefs = []string{pgName, vsNode.DefaultPoolGroup}
+	dsNode.Script = fmt.Sprintf(utils.HTTP_DS_SCRIPT_DEFAULT, strings.Join(hostEntries, ", "), pgName, vsNode.DefaultPoolGroup)
+}
@@ -93,6 +93,12 @@ type AviVsEvhSniModel interface {
 	GetAnalyticsPolicy() *avimodels.AnalyticsPolicy
 	SetAnalyticsPolicy(*avimodels.AnalyticsPolicy)
 
+	GetRequestsRateLimit() *AviRateLimit
+	SetRequestsRateLimit(*AviRateLimit)
+
+	GetClientAuth() *AviClientAuthNode
+	SetClientAuth(*AviClientAuthNode)
+
 	GetVSVIPLoadBalancerIP() string
 	SetVSVIPLoadBalancerIP(string)
 
@@ -143,6 +149,8 @@ type AviEvhVsNode struct {
 	Paths               []string
 	IngressNames        []string
 	AnalyticsPolicy     *avimodels.AnalyticsPolicy
+	RequestsRateLimit   *AviRateLimit
+	ClientAuth          *AviClientAuthNode
 	Dedicated           bool
 }
 
@@ -300,6 +308,22 @@ func (v *AviEvhVsNode) SetAnalyticsPolicy(policy *avimodels.AnalyticsPolicy) {
 	v.AnalyticsPolicy = policy
 }
 
+func (v *AviEvhVsNode) GetRequestsRateLimit() *AviRateLimit {
+	return v.RequestsRateLimit
+}
+
+func (v *AviEvhVsNode) SetRequestsRateLimit(rateLimit *AviRateLimit) {
+	v.RequestsRateLimit = rateLimit
+}
+
+func (v *AviEvhVsNode) GetClientAuth() *AviClientAuthNode {
+	return v.ClientAuth
+}
+
+func (v *AviEvhVsNode) SetClientAuth(clientAuth *AviClientAuthNode) {
+	v.ClientAuth = clientAuth
+}
+
 func (v *AviEvhVsNode) GetVSVIPLoadBalancerIP() string {
 	if len(v.VSVIPRefs) > 0 {
 		return v.VSVIPRefs[0].IPAddress
@@ -656,6 +680,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(vsRefs)
 	}
 
+	if v.DefaultPool != "" || v.DefaultPoolGroup != "" {
+		checksum += utils.Hash(v.DefaultPool + v.DefaultPoolGroup)
+	}
+
 	if v.Enabled != nil {
 		checksum += utils.Hash(utils.Stringify(v.Enabled))
 	}
@@ -670,6 +698,14 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		checksum += lib.GetAnalyticsPolicyChecksum(v.AnalyticsPolicy)
 	}
 
+	if v.RequestsRateLimit != nil {
+		checksum += utils.Hash(utils.Stringify(v.RequestsRateLimit))
+	}
+
+	if v.ClientAuth != nil {
+		checksum += utils.Hash(v.ClientAuth.Name)
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -836,8 +872,23 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 			httpPGPath.MatchCriteria = "BEGINS_WITH"
 		}
 
-		if path.Path != "" {
-			httpPGPath.Path = append(httpPGPath.Path, path.Path)
+		if matchPath := path.GetMatchPath(); matchPath != "" {
+			httpPGPath.Path = append(httpPGPath.Path, matchPath)
+		}
+		httpPGPath.PathRule = path.pathRule
+
+		if path.pathRule != nil && path.pathRule.Redirect != nil {
+			// Redirect rules do not select a pool, hence no pool/poolgroup is created for them.
+			pathSet.Insert(path.Path)
+			hppMapName := lib.GetSniHppMapName(ingName, namespace, hosts[0], path.Path, infraSettingName, vsNode[0].Dedicated)
+			httpPGPath.Name = hppMapName
+			httpPGPath.IngName = ingName
+			httpPGPath.CalculateCheckSum()
+			policyNode.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, hosts[0], infraSettingName, ingressNameSet.List(), pathSet.List())
+			if childNode.CheckHttpPolNameNChecksumForEvh(httppolname, hppMapName, httpPGPath.Checksum) {
+				childNode.ReplaceHTTPRefInNodeForEvh(httpPGPath, httppolname, key)
+			}
+			continue
 		}
 
 		pgName := lib.GetEvhPGName(ingName, namespace, hosts[0], path.Path, infraSettingName, vsNode[0].Dedicated)
@@ -866,6 +917,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 				Namespace:   namespace,
 				HostNames:   hostslice,
 				PoolRatio:   path.weight,
+				IsHTTPRoute: path.isHTTPRoute,
 			},
 		}
 
@@ -885,7 +937,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
-			if servers := PopulateServersForNPL(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNPL(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else if modelType == lib.MultiClusterIngress {
@@ -899,11 +951,11 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 				utils.AviLog.Errorf("key: %s, msg: Multi-cluster ingress is only supported for serviceType NodePort, not adding the servers", key)
 			}
 		} else if serviceType == lib.NodePort {
-			if servers := PopulateServersForNodePort(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNodePort(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else {
-			if servers := PopulateServers(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServers(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		}
@@ -935,6 +987,9 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 			if childNode.CheckHttpPolNameNChecksumForEvh(httppolname, hppMapName, httpPGPath.Checksum) {
 				childNode.ReplaceHTTPRefInNodeForEvh(httpPGPath, httppolname, key)
 			}
+			if modelType == utils.Ingress {
+				o.BuildHTTPRuleMatches(childNode, httppolname, httpPGPath, path, pgName, hosts[0], ingName, namespace, infraSetting, tlsSettings, key)
+			}
 		}
 	}
 	childNode.Paths = pathSet.List()
@@ -1804,6 +1859,7 @@ func (o *AviObjectGraph) manipulateEVHVsNode(vsNode *AviEvhVsNode, ingName, name
 				if len(pgNode.Members) == 0 {
 					o.RemovePGNodeRefsForEvh(pgName, vsNode)
 					httppolname := lib.GetSniHttpPolName(namespace, hostname, infraSettingName)
+					o.removeHTTPRuleMatches(vsNode, httppolname, pgName)
 					hppmapname := lib.GetEvhPGName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 					o.RemoveHTTPRefsFromEvh(httppolname, hppmapname, vsNode)
 				}
@@ -0,0 +1,302 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilsnet "k8s.io/utils/net"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+)
+
+// The TCP and UDP listeners of a gateway.networking.k8s.io Gateway are translated to a dedicated L4 virtual
+// service per Gateway, similar to the networking.x-k8s.io Gateways. Each listener with an attached TCPRoute
+// or UDPRoute is a port on the virtual service, and the L4 policyset maps the port to the pool of the backend.
+
+// gatewayAPIL4Route is the common representation of the TCPRoutes and UDPRoutes.
+type gatewayAPIL4Route struct {
+	metav1.ObjectMeta
+	kind        string
+	parentRefs  []gatewayv1.ParentReference
+	backendRefs [][]gatewayv1.BackendRef
+	status      gatewayv1.RouteStatus
+}
+
+func getGatewayAPIL4Route(kind, namespace, name string) (*gatewayAPIL4Route, error) {
+	route := &gatewayAPIL4Route{kind: kind}
+	switch kind {
+	case lib.TCPRoute:
+		tcpRoute, err := lib.GetTCPRoute(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		route.ObjectMeta = tcpRoute.ObjectMeta
+		route.parentRefs = tcpRoute.Spec.ParentRefs
+		for _, rule := range tcpRoute.Spec.Rules {
+			route.backendRefs = append(route.backendRefs, rule.BackendRefs)
+		}
+		route.status = tcpRoute.Status.RouteStatus
+	case lib.UDPRoute:
+		udpRoute, err := lib.GetUDPRoute(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		route.ObjectMeta = udpRoute.ObjectMeta
+		route.parentRefs = udpRoute.Spec.ParentRefs
+		for _, rule := range udpRoute.Spec.Rules {
+			route.backendRefs = append(route.backendRefs, rule.BackendRefs)
+		}
+		route.status = udpRoute.Status.RouteStatus
+	default:
+		return nil, fmt.Errorf("unsupported L4 route kind %s", kind)
+	}
+	return route, nil
+}
+
+// parseGatewayAPIL4Route validates the TCPRoute or UDPRoute, updates the route status and the parent Gateway
+// relationships, and returns the parents of the route along with its backend. Only the first valid backendRef
+// of the route is used, since a listener port maps to a single pool.
+func parseGatewayAPIL4Route(route *gatewayAPIL4Route, key string) ([]gatewayAPIRouteParent, *objects.GatewayAPIL4Backend) {
+	parents := getGatewayAPIRouteParents(route.kind, route.Namespace, route.parentRefs, key)
+	for i := range parents {
+		if len(parents[i].listeners) > 0 {
+			parents[i].setRouteAccepted()
+		}
+	}
+	routeKey := lib.GetGatewayAPIRouteKey(route.kind, route.Namespace, route.Name)
+	objects.GatewayAPIObjLister().UpdateRouteToGateways(routeKey, getAcceptedRouteParentGateways(parents))
+
+	resolvedRefsCondition := metav1.Condition{
+		Type:   gatewayv1.RouteConditionResolvedRefs,
+		Status: metav1.ConditionTrue,
+		Reason: gatewayv1.RouteReasonResolvedRefs,
+	}
+	var backend *objects.GatewayAPIL4Backend
+	var unsupported []string
+	for _, backendRefs := range route.backendRefs {
+		for _, backendRef := range backendRefs {
+			svcName, port, _, ok := parseGatewayAPIBackendRef(route.kind, route.Namespace, backendRef, &resolvedRefsCondition)
+			if !ok {
+				continue
+			}
+			if backend != nil {
+				unsupported = appendUnique(unsupported, "multiple backendRefs")
+				continue
+			}
+			backend = &objects.GatewayAPIL4Backend{
+				Route:   routeKey,
+				Service: route.Namespace + "/" + svcName,
+				Port:    port,
+			}
+		}
+	}
+
+	routeStatus := buildGatewayAPIRouteStatus(&route.status, parents, resolvedRefsCondition, unsupported, route.Generation)
+	status.UpdateGatewayAPIRouteStatusObject(key, route.kind, route.Namespace, route.Name, &route.status, routeStatus)
+	return parents, backend
+}
+
+// getGatewayAPIL4Backends processes the TCPRoutes and UDPRoutes attached to, or referring to the Gateway, and
+// returns the backends of the TCP and UDP listeners of the Gateway in PROTOCOL/port format. If multiple routes
+// are attached to a listener, the oldest route is used.
+func getGatewayAPIL4Backends(gw *gatewayv1.Gateway, key string) map[string]objects.GatewayAPIL4Backend {
+	gwNSName := gw.Namespace + "/" + gw.Name
+	var routes []*gatewayAPIL4Route
+	for _, kind := range []string{lib.TCPRoute, lib.UDPRoute} {
+		if lib.GetGatewayAPIRouteInformer(kind) == nil {
+			continue
+		}
+		routeNames := gatewayRoutesOfKind(kind, []string{gwNSName})
+		routeNames = appendUnique(routeNames, routesForParentGateways(kind, key, []string{gwNSName})...)
+		for _, routeName := range routeNames {
+			routeNS, name := strings.Split(routeName, "/")[0], strings.Split(routeName, "/")[1]
+			if !utils.CheckIfNamespaceAccepted(routeNS) {
+				continue
+			}
+			route, err := getGatewayAPIL4Route(kind, routeNS, name)
+			if err != nil || route.DeletionTimestamp != nil {
+				continue
+			}
+			routes = append(routes, route)
+		}
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if !routes[i].CreationTimestamp.Equal(&routes[j].CreationTimestamp) {
+			return routes[i].CreationTimestamp.Before(&routes[j].CreationTimestamp)
+		}
+		return routes[i].Namespace+"/"+routes[i].Name < routes[j].Namespace+"/"+routes[j].Name
+	})
+
+	backends := make(map[string]objects.GatewayAPIL4Backend)
+	for _, route := range routes {
+		parents, backend := parseGatewayAPIL4Route(route, key)
+		if backend == nil {
+			continue
+		}
+		for _, parent := range parents {
+			if parent.gw.Namespace != gw.Namespace || parent.gw.Name != gw.Name {
+				continue
+			}
+			for _, listener := range parent.listeners {
+				listenerKey := fmt.Sprintf("%s/%d", listener.Protocol, listener.Port)
+				if existing, ok := backends[listenerKey]; ok {
+					if existing.Route != backend.Route {
+						utils.AviLog.Warnf("key: %s, msg: listener %s of gateway %s is already used by %s, ignoring %s", key, listener.Name, gwNSName, existing.Route, backend.Route)
+					}
+					continue
+				}
+				backends[listenerKey] = *backend
+			}
+		}
+	}
+	objects.GatewayAPIObjLister().UpdateGatewayToL4Backends(gwNSName, backends)
+	return backends
+}
+
+// getGatewayAPIL4Listeners returns the listener to service mapping, the listeners and the listener to service port
+// mapping of the Gateway, in the format used for the L4 Gateways. Listeners with missing services are skipped.
+func getGatewayAPIL4Listeners(gwNSName string) (map[string][]string, []string, map[string]int32) {
+	svcListeners := make(map[string][]string)
+	svcPorts := make(map[string]int32)
+	var listeners []string
+	_, backends := objects.GatewayAPIObjLister().GetGatewayToL4Backends(gwNSName)
+	for listener, backend := range backends {
+		svcNSName := strings.Split(backend.Service, "/")
+		if _, err := utils.GetInformers().ServiceInformer.Lister().Services(svcNSName[0]).Get(svcNSName[1]); err != nil {
+			continue
+		}
+		listeners = append(listeners, listener)
+		svcListeners[listener] = []string{backend.Service}
+		svcPorts[listener] = backend.Port
+	}
+	sort.Strings(listeners)
+	return svcListeners, listeners, svcPorts
+}
+
+// BuildGatewayAPIL4Graph builds the L4 virtual service for the TCP and UDP listeners of the Gateway.
+// No nodes are added to the graph if the Gateway has no TCPRoutes or UDPRoutes attached to it.
+func (o *AviObjectGraph) BuildGatewayAPIL4Graph(namespace, gatewayName, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+	gw, err := lib.GetGatewayAPIGateway(namespace, gatewayName)
+	if err != nil || !lib.IsGatewayAPIGatewayValid(gw) {
+		objects.GatewayAPIObjLister().UpdateGatewayToL4Backends(namespace+"/"+gatewayName, nil)
+		return
+	}
+	getGatewayAPIL4Backends(gw, key)
+	vsNode := o.ConstructGatewayAPIL4VsNode(gw, key)
+	if vsNode != nil {
+		o.ConstructAdvL4PolPoolNodes(vsNode, gatewayName, namespace, key)
+		o.AddModelNode(vsNode)
+		utils.AviLog.Infof("key: %s, msg: checksum for AVI VS object %v", key, vsNode.GetCheckSum())
+	}
+}
+
+// ConstructGatewayAPIL4VsNode builds the L4 virtual service of the Gateway, where each TCP or UDP listener with a
+// valid backend is a port on the virtual service.
+func (o *AviObjectGraph) ConstructGatewayAPIL4VsNode(gw *gatewayv1.Gateway, key string) *AviVsNode {
+	namespace, gatewayName := gw.Namespace, gw.Name
+	_, listeners, _ := getGatewayAPIL4Listeners(namespace + "/" + gatewayName)
+	if len(listeners) == 0 {
+		return nil
+	}
+	vsName := lib.GetL4VSName(gatewayName, namespace)
+
+	var fqdns []string
+	if subDomains := GetDefaultSubDomain(); subDomains != nil && lib.GetL4FqdnFormat() != lib.AutoFQDNDisabled {
+		svcListeners, _, _ := getGatewayAPIL4Listeners(namespace + "/" + gatewayName)
+		for _, listener := range listeners {
+			svcNSName := strings.Split(svcListeners[listener][0], "/")
+			if fqdn := getAutoFQDNForService(svcNSName[0], svcNSName[1]); fqdn != "" && !utils.HasElem(fqdns, fqdn) {
+				fqdns = append(fqdns, fqdn)
+			}
+		}
+	}
+
+	avi_vs_meta := &AviVsNode{
+		Name:   vsName,
+		Tenant: lib.GetTenant(),
+		ServiceMetadata: lib.ServiceMetadataObj{
+			Gateway:      namespace + "/" + gatewayName,
+			HostNames:    fqdns,
+			IsGatewayAPI: true,
+		},
+		ServiceEngineGroup: lib.GetSEGName(),
+		EnableRhi:          proto.Bool(lib.GetEnableRHI()),
+	}
+
+	var vrfcontext string
+	t1lr := objects.SharedWCPLister().GetT1LrForNamespace(namespace)
+	if t1lr == "" {
+		vrfcontext = lib.GetVrf()
+		avi_vs_meta.VrfContext = vrfcontext
+	}
+
+	isTCP, isUDP := false, false
+	avi_vs_meta.AviMarkers = lib.PopulateAdvL4VSNodeMarkers(namespace, gatewayName)
+	var portProtocols []AviPortHostProtocol
+	for _, listener := range listeners {
+		portProto := strings.Split(listener, "/") // format: protocol/port
+		port, _ := utilsnet.ParsePort(portProto[1], true)
+		portProtocols = append(portProtocols, AviPortHostProtocol{Port: int32(port), Protocol: portProto[0]})
+		if portProto[0] == utils.UDP {
+			isUDP = true
+		} else {
+			isTCP = true
+		}
+	}
+	avi_vs_meta.PortProto = portProtocols
+	avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
+	if isTCP && !isUDP {
+		avi_vs_meta.NetworkProfile = utils.TCP_NW_FAST_PATH
+	} else if isUDP && !isTCP {
+		avi_vs_meta.NetworkProfile = utils.SYSTEM_UDP_FAST_PATH
+	} else {
+		avi_vs_meta.NetworkProfile = utils.MIXED_NET_PROFILE
+	}
+
+	vsVipNode := &AviVSVIPNode{
+		Name:        lib.GetL4VSVipName(gatewayName, namespace),
+		Tenant:      lib.GetTenant(),
+		VrfContext:  vrfcontext,
+		FQDNs:       fqdns,
+		VipNetworks: lib.GetVipNetworkList(),
+	}
+
+	if t1lr != "" {
+		vsVipNode.T1Lr = t1lr
+	}
+
+	if avi_vs_meta.EnableRhi != nil && *avi_vs_meta.EnableRhi {
+		vsVipNode.BGPPeerLabels = lib.GetGlobalBgpPeerLabels()
+	}
+
+	// configures VS and VsVip nodes using infraSetting object referred by the GatewayClass.
+	if infraSetting, err := getGatewayAPIGatewayInfraSetting(key, gw); err == nil {
+		buildWithInfraSetting(key, avi_vs_meta, vsVipNode, infraSetting)
+	}
+
+	avi_vs_meta.VSVIPRefs = append(avi_vs_meta.VSVIPRefs, vsVipNode)
+	return avi_vs_meta
+}
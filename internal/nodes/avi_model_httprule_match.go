@@ -0,0 +1,282 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// getHTTPRulePath returns the path settings of the accepted HTTPRule for the host, which targets the path. The
+// HTTPRule must be in the namespace of the ingress, since the backends of the matches are in its namespace.
+func getHTTPRulePath(host, path, namespace, key string) *akov1alpha1.HTTPRulePaths {
+	found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host)
+	if !found {
+		return nil
+	}
+	target := path
+	if target == "" {
+		target = "/"
+	}
+	rule, ok := pathRules[target]
+	if !ok {
+		return nil
+	}
+	ruleNSName := strings.Split(rule, "/")
+	if ruleNSName[0] != namespace {
+		return nil
+	}
+	httpRuleObj, err := lib.AKOControlConfig().CRDInformers().HTTPRuleInformer.Lister().HTTPRules(ruleNSName[0]).Get(ruleNSName[1])
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: httprule not found err: %+v", key, err)
+		return nil
+	} else if httpRuleObj.Status.Status == lib.StatusRejected {
+		return nil
+	}
+	for i := range httpRuleObj.Spec.Paths {
+		if httpRuleObj.Spec.Paths[i].Target == target {
+			return &httpRuleObj.Spec.Paths[i]
+		}
+	}
+	return nil
+}
+
+// getHTTPRuleMatches returns the matches of the accepted HTTPRule for the host, which targets the path.
+func getHTTPRuleMatches(host, path, namespace, key string) []akov1alpha1.HTTPRuleMatch {
+	if rulePath := getHTTPRulePath(host, path, namespace, key); rulePath != nil {
+		return rulePath.Matches
+	}
+	return nil
+}
+
+// getHTTPRulePathRule returns the header modifiers, the rewrite and the rate limit of the accepted HTTPRule for the
+// host, which targets the path, nil if these are not set.
+func getHTTPRulePathRule(host, path string, pathType networkingv1.PathType, namespace, key string) *AviHTTPPathRule {
+	rulePath := getHTTPRulePath(host, path, namespace, key)
+	if rulePath == nil {
+		return nil
+	}
+	pathRule := &AviHTTPPathRule{
+		RequestHeaders:  buildHTTPRuleHeaderActions(rulePath.RequestHeaders),
+		ResponseHeaders: buildHTTPRuleHeaderActions(rulePath.ResponseHeaders),
+	}
+	if rewrite := rulePath.Rewrite; rewrite != nil && (rewrite.Host != "" || rewrite.PathPrefix != "") {
+		pathRule.RewriteURL = &AviHTTPRewriteURL{Host: rewrite.Host}
+		if rewrite.PathPrefix != "" {
+			if pathType == networkingv1.PathTypeExact {
+				pathRule.RewriteURL.Path = rewrite.PathPrefix
+			} else {
+				pathRule.RewriteURL.PathPrefix = rewrite.PathPrefix
+				pathRule.RewriteURL.MatchPrefix = path
+			}
+		}
+	}
+	if rulePath.RateLimit != nil && rulePath.RateLimit.Key != lib.RateLimitKeyHeader {
+		pathRule.RateLimit = buildRateLimit(rulePath.RateLimit)
+	}
+	if len(pathRule.RequestHeaders) == 0 && len(pathRule.ResponseHeaders) == 0 && pathRule.RewriteURL == nil && pathRule.RateLimit == nil {
+		return nil
+	}
+	return pathRule
+}
+
+func buildHTTPRuleHeaderActions(headers *akov1alpha1.HTTPRuleHeaders) []AviHTTPHeaderAction {
+	if headers == nil {
+		return nil
+	}
+	var actions []AviHTTPHeaderAction
+	for _, header := range headers.Replace {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_REPLACE_HDR", Name: header.Name, Value: header.Value})
+	}
+	for _, header := range headers.Add {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_ADD_HDR", Name: header.Name, Value: header.Value})
+	}
+	for _, header := range headers.Remove {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_REMOVE_HDR", Name: header})
+	}
+	return actions
+}
+
+// getHTTPRuleMatchServices returns the backend services of the HTTPRule matches for the paths of an ingress.
+func getHTTPRuleMatchServices(ingSpec networkingv1.IngressSpec, namespace, key string) []string {
+	var services []string
+	for _, rule := range ingSpec.Rules {
+		if rule.Host == "" || rule.IngressRuleValue.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.IngressRuleValue.HTTP.Paths {
+			for _, match := range getHTTPRuleMatches(rule.Host, path.Path, namespace, key) {
+				if !utils.HasElem(services, match.Backend.Service) {
+					services = append(services, match.Backend.Service)
+				}
+			}
+		}
+	}
+	return services
+}
+
+func getHdrMatchCriteria(matchType string) string {
+	switch matchType {
+	case lib.MatchTypePrefix:
+		return "HDR_BEGINS_WITH"
+	case lib.MatchTypeContains:
+		return "HDR_CONTAINS"
+	case lib.MatchTypeExists:
+		return "HDR_EXISTS"
+	default:
+		return "HDR_EQUALS"
+	}
+}
+
+// buildHTTPRuleMatchPathRule translates the headers and the cookie of an HTTPRule match.
+func buildHTTPRuleMatchPathRule(match akov1alpha1.HTTPRuleMatch) *AviHTTPPathRule {
+	pathRule := &AviHTTPPathRule{}
+	for _, header := range match.Headers {
+		hdrMatch := AviHTTPHeaderMatch{
+			Name:          header.Name,
+			MatchCriteria: getHdrMatchCriteria(header.MatchType),
+		}
+		if header.MatchType != lib.MatchTypeExists {
+			hdrMatch.Values = []string{header.Value}
+			hdrMatch.MatchCase = "SENSITIVE"
+		}
+		pathRule.HeaderMatches = append(pathRule.HeaderMatches, hdrMatch)
+	}
+	if match.Cookie != nil {
+		pathRule.CookieMatch = &AviHTTPCookieMatch{
+			Name:          match.Cookie.Name,
+			MatchCriteria: getHdrMatchCriteria(match.Cookie.MatchType),
+		}
+		if match.Cookie.MatchType != lib.MatchTypeExists {
+			pathRule.CookieMatch.Value = match.Cookie.Value
+			pathRule.CookieMatch.MatchCase = "SENSITIVE"
+		}
+	}
+	return pathRule
+}
+
+// BuildHTTPRuleMatches builds a pool, a poolgroup and an http policy rule for each match of the HTTPRule on an ingress
+// path, replacing the ones built earlier. The rules switch the requests for the path, which match the headers and the
+// cookie of a match, to the poolgroup of its backend. httpPGPath is the http policy rule of the path.
+func (o *AviObjectGraph) BuildHTTPRuleMatches(vsNode AviVsEvhSniModel, httpPolName string, httpPGPath AviHostPathPortPoolPG, path IngressHostPathSvc, pgName, host, ingName, namespace string, infraSetting *akov1alpha1.AviInfraSetting, tlsSettings *TlsSettings, key string) {
+	o.removeHTTPRuleMatches(vsNode, httpPolName, pgName)
+	var policyNode *AviHttpPolicySetNode
+	for _, policy := range vsNode.GetHttpPolicyRefs() {
+		if policy.Name == httpPolName {
+			policyNode = policy
+		}
+	}
+	if policyNode == nil {
+		return
+	}
+
+	var infraSettingName string
+	if infraSetting != nil {
+		infraSettingName = infraSetting.Name
+	}
+	validator := NewNodesValidator()
+	for i, match := range getHTTPRuleMatches(host, path.Path, namespace, key) {
+		name := lib.GetHTTPRuleMatchName(pgName, i)
+		backend, _ := validator.parseIngressBackend(namespace, networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{
+				Name: match.Backend.Service,
+				Port: networkingv1.ServiceBackendPort{Number: match.Backend.Port},
+			},
+		}, nil, key)
+		backend.Path = path.Path
+
+		// The pools of the matches are selected only through the http policy rules.
+		poolNode := buildPoolNode(key, name, ingName, namespace, "", host, infraSetting, backend.ServiceName, []string{host}, false, backend)
+		if tlsSettings != nil && tlsSettings.reencrypt {
+			o.BuildPoolSecurity(poolNode, *tlsSettings, key, poolNode.AviMarkers)
+		}
+		poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		ratio := backend.weight
+		pgNode := &AviPoolGroupNode{
+			Name:    name,
+			Tenant:  lib.GetTenant(),
+			Members: []*avimodels.PoolGroupMember{{PoolRef: &poolRef, Ratio: &ratio}},
+		}
+		pgNode.AviMarkers = lib.PopulatePGNodeMarkers(namespace, host, infraSettingName, []string{ingName}, []string{path.Path})
+
+		matchPGPath := httpPGPath
+		matchPGPath.Name = name
+		matchPGPath.Pool = ""
+		matchPGPath.PoolGroup = pgNode.Name
+		matchPGPath.IngName = ingName
+		matchPGPath.PathRule = buildHTTPRuleMatchPathRule(match)
+		if httpPGPath.PathRule != nil {
+			// The header modifiers and the rewrite of the path also apply to the requests switched by the matches. The
+			// rate limit of the path is not copied, since its security rule matches these requests as well.
+			matchPGPath.PathRule.RequestHeaders = httpPGPath.PathRule.RequestHeaders
+			matchPGPath.PathRule.ResponseHeaders = httpPGPath.PathRule.ResponseHeaders
+			matchPGPath.PathRule.RewriteURL = httpPGPath.PathRule.RewriteURL
+		}
+		matchPGPath.CalculateCheckSum()
+
+		vsNode.SetPoolRefs(append(vsNode.GetPoolRefs(), poolNode))
+		vsNode.SetPoolGroupRefs(append(vsNode.GetPoolGroupRefs(), pgNode))
+		policyNode.HppMap = append(policyNode.HppMap, matchPGPath)
+		utils.AviLog.Infof("key: %s, msg: added httprule match %s for path %s of host %s, backend service %s", key, name, path.Path, host, backend.ServiceName)
+	}
+}
+
+// removeHTTPRuleMatches removes the pools, poolgroups and http policy rules built for the HTTPRule matches on the
+// path with the poolgroup pgName.
+func (o *AviObjectGraph) removeHTTPRuleMatches(vsNode AviVsEvhSniModel, httpPolName, pgName string) {
+	for i := 0; ; i++ {
+		name := lib.GetHTTPRuleMatchName(pgName, i)
+		removed := false
+		poolRefs := vsNode.GetPoolRefs()
+		for j, pool := range poolRefs {
+			if pool.Name == name {
+				vsNode.SetPoolRefs(append(poolRefs[:j], poolRefs[j+1:]...))
+				removed = true
+				break
+			}
+		}
+		pgRefs := vsNode.GetPoolGroupRefs()
+		for j, pg := range pgRefs {
+			if pg.Name == name {
+				vsNode.SetPoolGroupRefs(append(pgRefs[:j], pgRefs[j+1:]...))
+				removed = true
+				break
+			}
+		}
+		for _, policy := range vsNode.GetHttpPolicyRefs() {
+			if policy.Name != httpPolName {
+				continue
+			}
+			for j, hppmap := range policy.HppMap {
+				if hppmap.Name == name {
+					policy.HppMap = append(policy.HppMap[:j], policy.HppMap[j+1:]...)
+					removed = true
+					break
+				}
+			}
+		}
+		if !removed {
+			return
+		}
+	}
+}
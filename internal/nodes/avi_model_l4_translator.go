@@ -16,6 +16,8 @@ package nodes
 
 import (
 	"fmt"
+	"math"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,6 +34,8 @@ import (
 	"github.com/vmware/alb-sdk/go/models"
 	avimodels "github.com/vmware/alb-sdk/go/models"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -75,6 +79,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		avi_vs_meta.VrfContext = vrfcontext
 	}
 	avi_vs_meta.AviMarkers = lib.PopulateL4VSNodeMarkers(svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name)
+	avi_vs_meta.NetworkSecurityPolicy = buildL4NetworkSecurityPolicy(svcObj, vsName, key)
 	isTCP, isSCTP := false, false
 	var portProtocols []AviPortHostProtocol
 	for _, port := range svcObj.Spec.Ports {
@@ -141,6 +146,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 	} else if lib.HasLoadBalancerIPAnnotation(svcObj) {
 		vsVipNode.IPAddress = svcObj.Annotations[lib.LoadBalancerIP]
 	}
+	vsVipNode.IPFamily = getVipIPFamily(svcObj, vsVipNode.VipNetworks, key)
 
 	avi_vs_meta.VSVIPRefs = append(avi_vs_meta.VSVIPRefs, vsVipNode)
 	return avi_vs_meta
@@ -155,6 +161,7 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 		utils.AviLog.Warnf("key: %s, msg: Error while fetching infrasetting for Gateway %s", key, err.Error())
 		return
 	}
+	vsNode.PersistenceProfile = buildL4PersistenceProfile(svcObj, vsNode.Name, key)
 	protocolSet := sets.NewString()
 	for _, portProto := range vsNode.PortProto {
 		filterPort := portProto.Port
@@ -204,12 +211,26 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 		}
 
 		poolNode.AviMarkers = lib.PopulateL4PoolNodeMarkers(svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, strconv.Itoa(int(filterPort)))
-		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
-		portPool := AviHostPathPortPoolPG{Port: uint32(filterPort), Pool: pool_ref, Protocol: portProto.Protocol}
-		portPoolSet = append(portPoolSet, portPool)
-
+		if vsNode.PersistenceProfile != nil {
+			poolNode.ApplicationPersistence = fmt.Sprintf("/api/applicationpersistenceprofile?name=%s", vsNode.PersistenceProfile.Name)
+		}
 		buildPoolWithInfraSetting(key, poolNode, infraSetting)
 
+		portPool := AviHostPathPortPoolPG{Port: uint32(filterPort), Protocol: portProto.Protocol}
+		if infraSetting != nil && len(infraSetting.Spec.ZoneAwareness.Zones) > 0 {
+			// The port selects the pool group of the pools per zone, instead of the pool.
+			if zonePools, pgNode := buildZonePoolGroup(poolNode, infraSetting.Spec.ZoneAwareness.Zones, key); pgNode != nil {
+				portPool.PoolGroup = fmt.Sprintf("/api/poolgroup?name=%s", pgNode.Name)
+				portPoolSet = append(portPoolSet, portPool)
+				vsNode.PoolRefs = append(vsNode.PoolRefs, zonePools...)
+				vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, pgNode)
+				utils.AviLog.Infof("key: %s, msg: evaluated L4 pool group values :%v", key, utils.Stringify(pgNode))
+				continue
+			}
+		}
+		portPool.Pool = fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		portPoolSet = append(portPoolSet, portPool)
+
 		vsNode.PoolRefs = append(vsNode.PoolRefs, poolNode)
 		utils.AviLog.Infof("key: %s, msg: evaluated L4 pool values :%v", key, utils.Stringify(poolNode))
 	}
@@ -225,8 +246,107 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 	utils.AviLog.Infof("key: %s, msg: evaluated L4 pool policies :%v", key, utils.Stringify(vsNode.L4PolicyRefs))
 }
 
+// getVipIPFamily returns the IP families of the VIP of the Service, which are the IP families of the Service that the
+// VIP networks can allocate. An IPv6 VIP requires a VIP network with a v6cidr, and an IPv4 VIP a VIP network which is
+// not IPv6 only. The IP families which cannot be allocated are dropped, and reported as an event on the Service. An
+// empty value is returned if the ipFamily that AKO is configured with applies.
+func getVipIPFamily(svcObj *corev1.Service, vipNetworks []akov1alpha1.AviInfraSettingVipNetwork, key string) string {
+	ipFamily := lib.GetServiceIPFamilies(svcObj)
+	if ipFamily == "" {
+		return ""
+	}
+	hasV4, hasV6 := false, false
+	for _, vipNetwork := range vipNetworks {
+		if vipNetwork.Cidr != "" || vipNetwork.V6Cidr == "" {
+			hasV4 = true
+		}
+		if vipNetwork.V6Cidr != "" {
+			hasV6 = true
+		}
+	}
+	vipIPFamily := ipFamily
+	switch {
+	case ipFamily == "V4_V6" && hasV4 && !hasV6:
+		vipIPFamily = "V4"
+	case ipFamily == "V4_V6" && !hasV4 && hasV6:
+		vipIPFamily = "V6"
+	case (ipFamily == "V4" || ipFamily == "V4_V6") && !hasV4, (ipFamily == "V6" || ipFamily == "V4_V6") && !hasV6:
+		vipIPFamily = ""
+	}
+	if vipIPFamily != ipFamily {
+		appliedIPFamily := vipIPFamily
+		if appliedIPFamily == "" {
+			appliedIPFamily = lib.GetIPFamily()
+		}
+		utils.AviLog.Warnf("key: %s, msg: IP families %s of the Service are not supported by the VIP networks, using %s", key, ipFamily, appliedIPFamily)
+		lib.AKOControlConfig().EventRecorder().Eventf(svcObj, corev1.EventTypeWarning, lib.IPFamilyNotSupported,
+			"IP families %s are not supported by the VIP networks, using %s", ipFamily, appliedIPFamily)
+	}
+	if vipIPFamily == lib.GetIPFamily() {
+		return ""
+	}
+	return vipIPFamily
+}
+
+// buildL4NetworkSecurityPolicy returns the network security policy that allows the clients only from the
+// loadBalancerSourceRanges of the Service. The invalid source ranges are skipped, and reported as events on the
+// Service. If none of the source ranges are valid, the policy denies all the clients.
+func buildL4NetworkSecurityPolicy(svcObj *corev1.Service, vsName string, key string) *AviNetworkSecurityPolicyNode {
+	if len(svcObj.Spec.LoadBalancerSourceRanges) == 0 {
+		return nil
+	}
+	sourceRanges := sets.NewString()
+	for _, sourceRange := range svcObj.Spec.LoadBalancerSourceRanges {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(sourceRange))
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: invalid loadBalancerSourceRange %s: %v", key, sourceRange, err)
+			lib.AKOControlConfig().EventRecorder().Eventf(svcObj, corev1.EventTypeWarning, lib.InvalidSourceRange,
+				"Invalid loadBalancerSourceRange %s is not allowed: %v", sourceRange, err)
+			continue
+		}
+		sourceRanges.Insert(ipNet.String())
+	}
+	if sourceRanges.Len() == 0 {
+		utils.AviLog.Warnf("key: %s, msg: none of the loadBalancerSourceRanges are valid, denying all the clients", key)
+	}
+	return &AviNetworkSecurityPolicyNode{
+		Name:         lib.GetNetworkSecurityPolicyName(vsName),
+		Tenant:       lib.GetTenant(),
+		SourceRanges: sourceRanges.List(),
+		AviMarkers:   lib.PopulateL4VSNodeMarkers(svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name),
+	}
+}
+
+// buildL4PersistenceProfile returns the client IP persistence profile for the pools of a Service with the
+// ClientIP session affinity. The timeout of the session affinity is rounded up to minutes, and capped at the
+// maximum timeout of the persistence profile.
+func buildL4PersistenceProfile(svcObj *corev1.Service, vsName string, key string) *AviPersistenceProfileNode {
+	if svcObj.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return nil
+	}
+	timeoutSeconds := corev1.DefaultClientIPServiceAffinitySeconds
+	if svcObj.Spec.SessionAffinityConfig != nil && svcObj.Spec.SessionAffinityConfig.ClientIP != nil &&
+		svcObj.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds != nil {
+		timeoutSeconds = *svcObj.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+	}
+	timeout := int32(math.Ceil(float64(timeoutSeconds) / 60))
+	if timeout < 1 {
+		timeout = 1
+	} else if timeout > lib.MaxClientIPPersistenceTimeout {
+		utils.AviLog.Warnf("key: %s, msg: session affinity timeout of %d seconds exceeds the maximum persistence timeout, using %d minutes",
+			key, timeoutSeconds, lib.MaxClientIPPersistenceTimeout)
+		timeout = lib.MaxClientIPPersistenceTimeout
+	}
+	return &AviPersistenceProfileNode{
+		Name:       lib.GetPersistenceProfileName(vsName),
+		Tenant:     lib.GetTenant(),
+		Timeout:    timeout,
+		AviMarkers: lib.PopulateL4VSNodeMarkers(svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name),
+	}
+}
+
 func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
-	ipFamily := lib.GetIPFamily()
+	ipFamily := lib.GetServiceIPFamily(ns, serviceName)
 	if ingress {
 		found, _ := objects.SharedClusterIpLister().Get(ns + "/" + serviceName)
 		if !found {
@@ -234,6 +354,10 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 			return nil
 		}
 	}
+	if svcObj, ok := getExternalNameService(ns, serviceName); ok {
+		return populateServersForExternalName(poolNode, svcObj, key)
+	}
+	poolNode.HealthMonitor = buildReadinessProbeHealthMonitor(poolNode, ns, serviceName, false, key)
 	pods, targetPort := lib.GetPodsFromService(ns, serviceName, poolNode.TargetPort)
 	if len(pods) == 0 {
 		utils.AviLog.Infof("key: %s, msg: got no Pod for Service %s", key, serviceName)
@@ -242,23 +366,40 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 
 	var poolMeta []AviPoolMetaServer
 
+	// The servers of the pods which are being deleted are drained, if a drain period is configured.
+	drainCandidates := make(map[string]string)
+	if lib.GetServerDrainPeriod() != 0 {
+		for _, pod := range pods {
+			podObj, err := utils.GetInformers().PodInformer.Lister().Pods(pod.Namespace).Get(pod.Name)
+			if err == nil && podObj.GetDeletionTimestamp() != nil {
+				drainCandidates[pod.Name] = utils.Pod + "/" + pod.Namespace + "/" + pod.Name
+			}
+		}
+	}
+	drainingPods := getDrainingServers(poolNode.Name, drainCandidates, key)
+
 	for _, pod := range pods {
 		var annotations []lib.NPLAnnotation
 		found, obj := objects.SharedNPLLister().Get(ns + "/" + pod.Name)
 		if !found {
 			continue
 		}
+		_, terminating := drainCandidates[pod.Name]
+		if terminating && !drainingPods.Has(pod.Name) {
+			utils.AviLog.Infof("key: %s, msg: drain period of Pod %s has ended", key, pod.Name)
+			continue
+		}
 		annotations = obj.([]lib.NPLAnnotation)
 		for _, a := range annotations {
 			var atype string
 			if utils.IsV4(a.NodeIP) {
-				if ipFamily != "V4" {
+				if ipFamily == "V6" {
 					utils.AviLog.Infof("Skipping server %s, ipFamily is %s", a.NodeIP, ipFamily)
 					continue
 				}
 				atype = "V4"
 			} else {
-				if ipFamily != "V6" {
+				if ipFamily == "V4" {
 					utils.AviLog.Infof("Skipping server %s, ipFamily is %s", a.NodeIP, ipFamily)
 					continue
 				}
@@ -271,7 +412,9 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 					Ip: models.IPAddr{
 						Addr: &a.NodeIP,
 						Type: &atype,
-					}}
+					},
+					Draining: terminating,
+				}
 				poolMeta = append(poolMeta, server)
 			}
 		}
@@ -282,7 +425,7 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 
 func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
 
-	ipFamily := lib.GetIPFamily()
+	ipFamily := lib.GetServiceIPFamily(ns, serviceName)
 	// Get all nodes which match nodePortSelector
 	nodePortSelector := lib.GetNodePortsSelector()
 	nodePortFilter := map[string]string{}
@@ -299,11 +442,32 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 		utils.AviLog.Warnf("key: %s, msg: error in obtaining the object for service: %s", key, serviceName)
 		return poolMeta
 	}
+	if svcObj.Spec.Type == corev1.ServiceTypeExternalName {
+		return populateServersForExternalName(poolNode, svcObj, key)
+	}
+	poolNode.HealthMonitor = buildReadinessProbeHealthMonitor(poolNode, ns, serviceName, false, key)
 	// Populate pool servers
 	if lib.IsServiceClusterIPType(svcObj) {
 		utils.AviLog.Debugf("key: %s, msg: ClusterIP is not processed in NodePort: %s", key, serviceName)
 		return poolMeta
 	}
+	// With the Local external traffic policy, only the nodes which host a ready endpoint of the Service are
+	// added, as the traffic is not forwarded to the endpoints on the other nodes.
+	var localNodes sets.String
+	if svcObj.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+		localNodes = getNodesWithReadyEndpoints(ns, serviceName, key)
+	}
+	// The nodes which are cordoned or being deleted are drained, if a drain period is configured.
+	drainCandidates := make(map[string]string)
+	if lib.GetServerDrainPeriod() != 0 {
+		for _, nodeIntf := range allNodes {
+			if node, ok := nodeIntf.(*corev1.Node); ok && (node.Spec.Unschedulable || node.GetDeletionTimestamp() != nil) {
+				drainCandidates[node.Name] = utils.NodeObj + "/" + node.Name
+			}
+		}
+	}
+	drainingNodes := getDrainingServers(poolNode.Name, drainCandidates, key)
+
 	for _, port := range svcObj.Spec.Ports {
 		if port.Name != poolNode.PortName && len(svcObj.Spec.Ports) != 1 {
 			// continue only if port name does not match and its multiport svcobj
@@ -328,38 +492,90 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 				}
 
 			}
+			if localNodes != nil && !localNodes.Has(node.Name) {
+				continue
+			}
 			nodeIP, nodeIP6 := lib.GetIPFromNode(node)
-			var atype string
-			var serverIP avimodels.IPAddr
-			if ipFamily == "V4" {
-				if nodeIP == "" {
+			var serverIPs []avimodels.IPAddr
+			// With both the IP families, a node without an address of one of these is added with the other.
+			if ipFamily != "V6" {
+				if nodeIP != "" {
+					atype := "V4"
+					serverIPs = append(serverIPs, avimodels.IPAddr{Type: &atype, Addr: &nodeIP})
+				} else if ipFamily == "V4" {
 					utils.AviLog.Warnf("key: %s,msg: NodeIP not found for node: %s", key, node.Name)
 					return nil
 				} else {
-					atype = "V4"
-					serverIP = avimodels.IPAddr{Type: &atype, Addr: &nodeIP}
+					utils.AviLog.Warnf("key: %s,msg: NodeIP not found for node: %s, skipping its IPv4 server", key, node.Name)
 				}
-			} else {
-				if nodeIP6 == "" {
+			}
+			if ipFamily != "V4" {
+				if nodeIP6 != "" {
+					atype := "V6"
+					serverIPs = append(serverIPs, avimodels.IPAddr{Type: &atype, Addr: &nodeIP6})
+				} else if ipFamily == "V6" {
 					utils.AviLog.Warnf("key: %s,msg: NodeIP6 not found for node: %s", key, node.Name)
 					return nil
 				} else {
-					atype = "V6"
-					serverIP = avimodels.IPAddr{Type: &atype, Addr: &nodeIP6}
+					utils.AviLog.Warnf("key: %s,msg: NodeIP6 not found for node: %s, skipping its IPv6 server", key, node.Name)
 				}
 			}
 
-			server := AviPoolMetaServer{Ip: serverIP}
-			poolMeta = append(poolMeta, server)
+			_, draining := drainCandidates[node.Name]
+			if draining && !drainingNodes.Has(node.Name) {
+				continue
+			}
+			for _, serverIP := range serverIPs {
+				server := AviPoolMetaServer{Ip: serverIP, Draining: draining}
+				poolMeta = append(poolMeta, server)
+			}
 		}
 	}
 
 	return poolMeta
 }
 
+// getNodesWithReadyEndpoints returns the names of the nodes which host a ready endpoint of the Service.
+func getNodesWithReadyEndpoints(ns string, serviceName string, key string) sets.String {
+	nodeNames := sets.NewString()
+	if utils.GetInformers().EpSlicesInformer != nil {
+		selector := labels.SelectorFromSet(labels.Set{discovery.LabelServiceName: serviceName})
+		epSlices, err := utils.GetInformers().EpSlicesInformer.Lister().EndpointSlices(ns).List(selector)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: error while retrieving endpointslices: %s", key, err)
+			return nodeNames
+		}
+		for _, epSlice := range epSlices {
+			for _, endpoint := range epSlice.Endpoints {
+				// An endpoint with an unknown ready condition is considered ready.
+				if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+					continue
+				}
+				if endpoint.NodeName != nil {
+					nodeNames.Insert(*endpoint.NodeName)
+				}
+			}
+		}
+		return nodeNames
+	}
+	epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(ns).Get(serviceName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: error while retrieving endpoints: %s", key, err)
+		return nodeNames
+	}
+	for _, ss := range epObj.Subsets {
+		for _, addr := range ss.Addresses {
+			if addr.NodeName != nil {
+				nodeNames.Insert(*addr.NodeName)
+			}
+		}
+	}
+	return nodeNames
+}
+
 func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
 
-	ipFamily := lib.GetIPFamily()
+	ipFamily := lib.GetServiceIPFamily(ns, serviceName)
 	// Find the servers that match the port.
 	if ingress {
 		// If it's an ingress case, check if the service of type clusterIP or not.
@@ -369,6 +585,13 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 			return nil
 		}
 	}
+	if svcObj, ok := getExternalNameService(ns, serviceName); ok {
+		return populateServersForExternalName(poolNode, svcObj, key)
+	}
+	poolNode.HealthMonitor = buildReadinessProbeHealthMonitor(poolNode, ns, serviceName, true, key)
+	if utils.GetInformers().EpSlicesInformer != nil {
+		return populateServersFromEndpointSlices(poolNode, ns, serviceName, key)
+	}
 	epObj, err := utils.GetInformers().EpInformer.Lister().Endpoints(ns).Get(serviceName)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: error while retrieving endpoints: %s", key, err)
@@ -390,19 +613,18 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 			poolNode.Port = ss.Ports[0].Port
 		}
 		if port_match {
-			var atype string
 			utils.AviLog.Infof("key: %s, msg: found port match for port %v", key, poolNode.Port)
 			for _, addr := range ss.Addresses {
-
+				var atype string
 				ip := addr.IP
 				if utils.IsV4(addr.IP) {
-					if ipFamily != "V4" {
+					if ipFamily == "V6" {
 						utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr.IP, ipFamily)
 						continue
 					}
 					atype = "V4"
 				} else {
-					if ipFamily != "V6" {
+					if ipFamily == "V4" {
 						utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr.IP, ipFamily)
 						continue
 					}
@@ -415,12 +637,207 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 				}
 				pool_meta = append(pool_meta, server)
 			}
+			if lib.IsPodReadinessGateEnabled() {
+				pool_meta = append(pool_meta, populateServersPendingReadinessGate(ss.NotReadyAddresses, ns, ipFamily, key)...)
+			}
 		}
 	}
 	utils.AviLog.Infof("key: %s, msg: servers for port: %v, are: %v", key, poolNode.Port, utils.Stringify(pool_meta))
 	return pool_meta
 }
 
+// getExternalNameService returns the Service, if it is of type ExternalName.
+func getExternalNameService(ns string, serviceName string) (*corev1.Service, bool) {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(serviceName)
+	if err != nil || svcObj.Spec.Type != corev1.ServiceTypeExternalName {
+		return nil, false
+	}
+	return svcObj, true
+}
+
+// populateServersForExternalName returns the server for the external name of a Service of type ExternalName,
+// which is resolved by the Service Engines. The server uses the port of the Service that the pool refers to.
+func populateServersForExternalName(poolNode *AviPoolNode, svcObj *corev1.Service, key string) []AviPoolMetaServer {
+	externalName := strings.TrimSuffix(svcObj.Spec.ExternalName, ".")
+	if externalName == "" {
+		utils.AviLog.Warnf("key: %s, msg: external name of the service %s is empty", key, svcObj.Name)
+		return make([]AviPoolMetaServer, 0)
+	}
+	for _, port := range svcObj.Spec.Ports {
+		if port.Name == poolNode.PortName || port.Port == poolNode.Port || len(svcObj.Spec.Ports) == 1 {
+			poolNode.Port = port.Port
+			break
+		}
+	}
+	atype := "DNS"
+	server := AviPoolMetaServer{
+		Ip:       avimodels.IPAddr{Type: &atype, Addr: &externalName},
+		Hostname: externalName,
+	}
+	utils.AviLog.Infof("key: %s, msg: server for port: %v, is the external name %s", key, poolNode.Port, externalName)
+	return []AviPoolMetaServer{server}
+}
+
+// populateServersPendingReadinessGate returns the servers for the not ready addresses of the Endpoints, whose
+// Pods are not ready only because the pool server readiness gate is not yet True.
+func populateServersPendingReadinessGate(addresses []corev1.EndpointAddress, ns string, ipFamily string, key string) []AviPoolMetaServer {
+	var servers []AviPoolMetaServer
+	for _, addr := range addresses {
+		if addr.TargetRef == nil || addr.TargetRef.Kind != "Pod" || !lib.IsPodPendingReadinessGate(ns, addr.TargetRef.Name) {
+			continue
+		}
+		var atype string
+		ip := addr.IP
+		if utils.IsV4(addr.IP) {
+			if ipFamily == "V6" {
+				utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr.IP, ipFamily)
+				continue
+			}
+			atype = "V4"
+		} else {
+			if ipFamily == "V4" {
+				utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr.IP, ipFamily)
+				continue
+			}
+			atype = "V6"
+		}
+		utils.AviLog.Debugf("key: %s, msg: adding server %s of Pod %s pending readiness gate", key, addr.IP, addr.TargetRef.Name)
+		server := AviPoolMetaServer{Ip: avimodels.IPAddr{Type: &atype, Addr: &ip}}
+		if addr.NodeName != nil {
+			server.ServerNode = *addr.NodeName
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// populateServersFromEndpointSlices merges the ready endpoints of all the EndpointSlices of the Service,
+// which match the port of the pool. The terminating endpoints are added as draining servers, until the
+// drain period ends.
+func populateServersFromEndpointSlices(poolNode *AviPoolNode, ns string, serviceName string, key string) []AviPoolMetaServer {
+	ipFamily := lib.GetServiceIPFamily(ns, serviceName)
+	drainEnabled := lib.GetServerDrainPeriod() != 0
+	selector := labels.SelectorFromSet(labels.Set{discovery.LabelServiceName: serviceName})
+	epSlices, err := utils.GetInformers().EpSlicesInformer.Lister().EndpointSlices(ns).List(selector)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: error while retrieving endpointslices: %s", key, err)
+		return nil
+	}
+	sort.Slice(epSlices, func(i, j int) bool {
+		return epSlices[i].Name < epSlices[j].Name
+	})
+
+	// All the slices of a single port Service have the same port.
+	slicePorts := sets.NewInt32()
+	for _, epSlice := range epSlices {
+		for _, epp := range epSlice.Ports {
+			if epp.Port != nil {
+				slicePorts.Insert(*epp.Port)
+			}
+		}
+	}
+
+	var poolMeta, terminatingServers []AviPoolMetaServer
+	serverIPs := sets.NewString()
+	for _, epSlice := range epSlices {
+		if epSlice.AddressType == discovery.AddressTypeFQDN {
+			continue
+		}
+		portMatch := false
+		for _, epp := range epSlice.Ports {
+			if epp.Port == nil {
+				continue
+			}
+			var portName string
+			if epp.Name != nil {
+				portName = *epp.Name
+			}
+			if poolNode.PortName == portName || int32(poolNode.TargetPort.IntValue()) == *epp.Port {
+				portMatch = true
+				poolNode.Port = *epp.Port
+				break
+			}
+		}
+		if len(epSlice.Ports) == 1 && epSlice.Ports[0].Port != nil && slicePorts.Len() == 1 {
+			// If it's just a single port then we make that as the server port.
+			portMatch = true
+			poolNode.Port = *epSlice.Ports[0].Port
+		}
+		if !portMatch {
+			continue
+		}
+		utils.AviLog.Infof("key: %s, msg: found port match for port %v in endpointslice %s", key, poolNode.Port, epSlice.Name)
+		for _, endpoint := range epSlice.Endpoints {
+			// An endpoint with an unknown ready condition is considered ready.
+			terminating := false
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+					if !drainEnabled {
+						continue
+					}
+					terminating = true
+				} else if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" ||
+					!lib.IsPodPendingReadinessGate(ns, endpoint.TargetRef.Name) {
+					continue
+				}
+			}
+			for _, addr := range endpoint.Addresses {
+				if serverIPs.Has(addr) {
+					// An endpoint can be present in more than one slice while the slices are being updated.
+					continue
+				}
+				var atype string
+				ip := addr
+				if utils.IsV4(addr) {
+					if ipFamily == "V6" {
+						utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr, ipFamily)
+						continue
+					}
+					atype = "V4"
+				} else {
+					if ipFamily == "V4" {
+						utils.AviLog.Infof("Skipping server %s, ipFamily is %s", addr, ipFamily)
+						continue
+					}
+					atype = "V6"
+				}
+				server := AviPoolMetaServer{Ip: avimodels.IPAddr{Type: &atype, Addr: &ip}}
+				if endpoint.NodeName != nil {
+					server.ServerNode = *endpoint.NodeName
+				}
+				if terminating {
+					terminatingServers = append(terminatingServers, server)
+					continue
+				}
+				serverIPs.Insert(addr)
+				poolMeta = append(poolMeta, server)
+			}
+		}
+	}
+
+	if drainEnabled {
+		// An endpoint which is terminating in one slice, and ready in another, is not drained.
+		syncKey := utils.Endpoints + "/" + ns + "/" + serviceName
+		drainCandidates := make(map[string]string)
+		for _, server := range terminatingServers {
+			if !serverIPs.Has(*server.Ip.Addr) {
+				drainCandidates[*server.Ip.Addr] = syncKey
+			}
+		}
+		drainingServers := getDrainingServers(poolNode.Name, drainCandidates, key)
+		for _, server := range terminatingServers {
+			if !drainingServers.Has(*server.Ip.Addr) || serverIPs.Has(*server.Ip.Addr) {
+				continue
+			}
+			serverIPs.Insert(*server.Ip.Addr)
+			server.Draining = true
+			poolMeta = append(poolMeta, server)
+		}
+	}
+	utils.AviLog.Infof("key: %s, msg: servers for port: %v, are: %v", key, poolNode.Port, utils.Stringify(poolMeta))
+	return poolMeta
+}
+
 func PopulateServersForMultiClusterIngress(poolNode *AviPoolNode, ns, cluster, serviceNamespace, serviceName string, key string) []AviPoolMetaServer {
 
 	ipFamily := lib.GetIPFamily()
@@ -140,9 +140,14 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 	}
 
 	utils.AviLog.Infof("key: %s, msg: The pathsvc mapping: %v", key, paths)
+	ingressPools := make(map[string]string)
 	for _, obj := range paths {
 		var pgNode *AviPoolGroupNode
 		httpPGPath := AviHostPathPortPoolPG{Host: pathFQDNs}
+		if obj.alternateBackend && lib.GetNoPGForSNI() {
+			utils.AviLog.Warnf("key: %s, msg: alternate backends are not supported without poolgroups, skipping service %s for path %s of host %s", key, obj.ServiceName, obj.Path, hostname)
+			continue
+		}
 
 		if obj.PathType == networkingv1.PathTypeExact {
 			httpPGPath.MatchCriteria = "EQUALS"
@@ -153,12 +158,29 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 		}
 
 		if obj.Path != "" {
-			httpPGPath.Path = append(httpPGPath.Path, obj.Path)
 			priorityLabel = hostname + obj.Path
 		} else {
 			priorityLabel = hostname
 		}
-		if isIngr {
+		if matchPath := obj.GetMatchPath(); matchPath != "" {
+			httpPGPath.Path = append(httpPGPath.Path, matchPath)
+		}
+		httpPGPath.PathRule = obj.pathRule
+
+		if obj.pathRule != nil && obj.pathRule.Redirect != nil {
+			// Redirect rules do not select a pool, hence no pool/poolgroup is created for them.
+			pathSet.Insert(obj.Path)
+			hppMapName := lib.GetSniHppMapName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated)
+			httpPGPath.Name = hppMapName
+			httpPGPath.IngName = ingName
+			policyNode.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, hostname, infraSettingName, ingressNameSet.List(), pathSet.List())
+			httpPGPath.CalculateCheckSum()
+			if vsNode[0].CheckHttpPolNameNChecksum(httpPolName, hppMapName, httpPGPath.Checksum) {
+				vsNode[0].ReplaceSniHTTPRefInSNINode(httpPGPath, httpPolName, key)
+			}
+			continue
+		}
+		if isIngr && !obj.alternateBackend {
 			poolName = lib.GetSniPoolName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated)
 		} else {
 			poolName = lib.GetSniPoolName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated, obj.ServiceName)
@@ -197,6 +219,7 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 			// Replace the poolNode.
 			vsNode[0].ReplaceSniPoolInSNINode(poolNode, key)
 		}
+		ingressPools[poolNode.Name] = poolNode.PriorityLabel
 		if !pgfound {
 			pathSet.Insert(obj.Path)
 			hppMapName := lib.GetSniHppMapName(ingName, namespace, hostname, obj.Path, infraSettingName, vsNode[0].Dedicated)
@@ -207,9 +230,17 @@ func (o *AviObjectGraph) BuildPoolPGPolicyForDedicatedVS(vsNode []*AviVsNode, na
 			if vsNode[0].CheckHttpPolNameNChecksum(httpPolName, hppMapName, httpPGPath.Checksum) {
 				vsNode[0].ReplaceSniHTTPRefInSNINode(httpPGPath, httpPolName, key)
 			}
+			if isIngr && !lib.GetNoPGForSNI() {
+				o.BuildHTTPRuleMatches(vsNode[0], httpPolName, httpPGPath, obj, pgNode.Name, hostname, ingName, namespace, infraSetting, nil, key)
+			}
 		}
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, infraSettingName, key, vsNode[0], true, vsNode[0].Dedicated)
 	}
+	if isIngr && !lib.GetNoPGForSNI() {
+		for _, poolName := range getStaleAlternatePools(vsNode[0].PoolRefs, ingName, namespace, ingressPools) {
+			o.RemovePoolNodeRefsFromSni(poolName, vsNode[0])
+		}
+	}
 	vsNode[0].Paths = pathSet.List()
 	vsNode[0].IngressNames = ingressNameSet.List()
 	utils.AviLog.Infof("key: %s, msg: added pools and poolgroups. NodeChecksum for Insecure Dedicated Vs :%s is :%v", key, vsNode[0].Name, vsNode[0].GetCheckSum())
@@ -244,7 +275,20 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	}
 
 	utils.AviLog.Infof("key: %s, msg: The pathsvc mapping: %v", key, pathsvc)
+	isIngr := routeIgrObj.GetType() == utils.Ingress
+	ingressPools := make(map[string]string)
 	for _, obj := range pathsvc {
+		if obj.pathRule != nil {
+			if obj.pathRule.Redirect != nil || len(obj.pathRule.HeaderMatches) > 0 {
+				// Pools of the shared insecure VS are selected using priority labels, which can only match on host and path.
+				utils.AviLog.Warnf("key: %s, msg: header matches and redirects are not supported for insecure hosts on shared VS, skipping path %s for host %s", key, obj.GetMatchPath(), hostname)
+				continue
+			}
+			utils.AviLog.Warnf("key: %s, msg: header modifiers, rewrites and rate limits are not supported for insecure hosts on shared VS, ignoring them for path %s of host %s", key, obj.GetMatchPath(), hostname)
+		}
+		if isIngr && !obj.alternateBackend && len(getHTTPRuleMatches(hostname, obj.Path, namespace, key)) > 0 {
+			utils.AviLog.Warnf("key: %s, msg: httprule matches are not supported for insecure hosts on shared VS, ignoring them for path %s of host %s", key, obj.Path, hostname)
+		}
 		if obj.Path != "" {
 			priorityLabel = hostname + obj.Path
 		} else {
@@ -253,7 +297,7 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 
 		// Using servicename in poolname for routes, but not in ingress for consistency with existing naming convention.
 		// If possible, we would make this uniform
-		if routeIgrObj.GetType() == utils.Ingress {
+		if isIngr && !obj.alternateBackend {
 			poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName)
 			serviceName = ""
 		} else {
@@ -281,10 +325,16 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 			}
 			poolNode := buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname, infraSetting, serviceName, storedHosts, insecureEdgeTermAllow, obj)
 			vsNode[0].PoolRefs = append(vsNode[0].PoolRefs, poolNode)
+			ingressPools[poolNode.Name] = poolNode.PriorityLabel
 			utils.AviLog.Debugf("key: %s, msg: the pools after append are: %v", key, utils.Stringify(vsNode[0].PoolRefs))
 		}
 
 	}
+	if isIngr {
+		for _, poolName := range getStaleAlternatePools(vsNode[0].PoolRefs, ingName, namespace, ingressPools) {
+			o.RemovePoolNodeRefs(poolName)
+		}
+	}
 	for _, obj := range pathsvc {
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, infraSettingName, key, vsNode[0], false, vsNode[0].Dedicated)
 	}
@@ -292,11 +342,15 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	// Reset the PG Node members and rebuild them
 	pgNode.Members = nil
 	for _, poolNode := range vsNode[0].PoolRefs {
+		if poolNode.Name == lib.GetDefaultBackendName(vsName) {
+			continue
+		}
 		ratio := poolNode.ServiceMetadata.PoolRatio
 		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
 
 	}
+	o.updateHTTPDataScript(vsNode[0])
 }
 
 func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname string, infraSetting *akov1alpha1.AviInfraSetting, serviceName string, storedHosts []string, insecureEdgeTermAllow bool, obj IngressHostPathSvc) *AviPoolNode {
@@ -314,6 +368,7 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 			HostNames:             storedHosts,
 			PoolRatio:             obj.weight,
 			InsecureEdgeTermAllow: insecureEdgeTermAllow,
+			IsHTTPRoute:           obj.isHTTPRoute,
 		},
 		VrfContext: lib.GetVrf(),
 	}
@@ -329,15 +384,15 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 
 	serviceType := lib.GetServiceType()
 	if serviceType == lib.NodePortLocal {
-		if servers := PopulateServersForNPL(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+		if servers := PopulateServersForNPL(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 			poolNode.Servers = servers
 		}
 	} else if serviceType == lib.NodePort {
-		if servers := PopulateServersForNodePort(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+		if servers := PopulateServersForNodePort(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 			poolNode.Servers = servers
 		}
 	} else {
-		if servers := PopulateServers(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+		if servers := PopulateServers(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 			poolNode.Servers = servers
 		}
 	}
@@ -355,6 +410,29 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 	return poolNode
 }
 
+// getStaleAlternatePools returns the pools of the ingress in poolRefs, which have the priority label of one of the pools
+// built for the ingress, but are not among them. The pools of the alternate backends of an ingress path are named after
+// the service, and are not removed by the stale data cleanup as long as the path is present in the ingress.
+func getStaleAlternatePools(poolRefs []*AviPoolNode, ingName, namespace string, ingressPools map[string]string) []string {
+	priorityLabels := sets.NewString()
+	for _, priorityLabel := range ingressPools {
+		priorityLabels.Insert(strings.ToLower(priorityLabel))
+	}
+	var stalePools []string
+	for _, pool := range poolRefs {
+		if pool.IngressName != ingName || pool.ServiceMetadata.Namespace != namespace {
+			continue
+		}
+		if _, ok := ingressPools[pool.Name]; ok {
+			continue
+		}
+		if priorityLabels.Has(strings.ToLower(pool.PriorityLabel)) {
+			stalePools = append(stalePools, pool.Name)
+		}
+	}
+	return stalePools
+}
+
 func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgrObj RouteIngressModel, pathSvc map[string][]string, key string, infraSettingName string, removeFqdn, removeRedir, secure bool) bool {
 	o.Lock.Lock()
 	defer o.Lock.Unlock()
@@ -379,10 +457,10 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 					priorityLabel = hostname
 				}
 				for _, svcName := range services {
-					if routeIgrObj.GetType() == utils.Ingress {
+					poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName, svcName)
+					// The pools of an ingress path are named after the service only for the alternate backends.
+					if routeIgrObj.GetType() == utils.Ingress && pool.Name != poolName {
 						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName)
-					} else {
-						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, infraSettingName, svcName)
 					}
 					if poolName == pool.Name {
 						o.RemovePoolNodeRefs(poolName)
@@ -396,10 +474,14 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 		if pgNode != nil {
 			pgNode.Members = nil
 			for _, poolNode := range vsNode[0].PoolRefs {
+				if poolNode.Name == lib.GetDefaultBackendName(vsName) {
+					continue
+				}
 				ratio := poolNode.ServiceMetadata.PoolRatio
 				pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 				pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
 			}
+			o.updateHTTPDataScript(vsNode[0])
 		}
 		// Remove the httpredirect policy if any
 		if len(vsNode) > 0 {
@@ -452,6 +534,12 @@ func (o *AviObjectGraph) manipulateVsNode(vsNode *AviVsNode, ingName, namespace,
 			var sniPool string
 			if isIngr {
 				sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
+				// The pools of an ingress path are named after the service only for the alternate backends.
+				alternatePool := lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated, svc)
+				o.RemovePoolNodeRefsFromSni(alternatePool, vsNode)
+				if pgNode != nil {
+					o.RemovePoolRefsFromPG(alternatePool, pgNode)
+				}
 			} else {
 				sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated, svc)
 			}
@@ -468,6 +556,7 @@ func (o *AviObjectGraph) manipulateVsNode(vsNode *AviVsNode, ingName, namespace,
 				o.RemovePGNodeRefs(pgName, vsNode)
 				hppmapname := lib.GetSniHppMapName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 				httppolname := lib.GetSniHttpPolName(namespace, hostname, infraSettingName)
+				o.removeHTTPRuleMatches(vsNode, httppolname, pgName)
 				o.RemoveHTTPRefsFromSni(httppolname, hppmapname, vsNode)
 			}
 		}
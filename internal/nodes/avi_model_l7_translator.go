@@ -319,6 +319,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 
 	ingressNameSet := sets.NewString(tlsNode.IngressNames...)
 	ingressNameSet.Insert(ingName)
+	ingressPools := make(map[string]string)
 	for host, paths := range hostpath.Hosts {
 		var pathFQDNs []string
 		pathFQDNs = append(pathFQDNs, host)
@@ -356,6 +357,10 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 		for _, path := range paths.ingressHPSvc {
 
 			httpPGPath := AviHostPathPortPoolPG{Host: pathFQDNs}
+			if path.alternateBackend && lib.GetNoPGForSNI() {
+				utils.AviLog.Warnf("key: %s, msg: alternate backends are not supported without poolgroups, skipping service %s for path %s of host %s", key, path.ServiceName, path.Path, host)
+				continue
+			}
 
 			if path.PathType == networkingv1.PathTypeExact {
 				httpPGPath.MatchCriteria = "EQUALS"
@@ -366,16 +371,33 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			}
 
 			if path.Path != "" {
-				httpPGPath.Path = append(httpPGPath.Path, path.Path)
 				priorityLabel = host + path.Path
 			} else {
 				priorityLabel = host
 			}
+			if matchPath := path.GetMatchPath(); matchPath != "" {
+				httpPGPath.Path = append(httpPGPath.Path, matchPath)
+			}
+			httpPGPath.PathRule = path.pathRule
+
+			if path.pathRule != nil && path.pathRule.Redirect != nil {
+				// Redirect rules do not select a pool, hence no pool/poolgroup is created for them.
+				pathSet.Insert(path.Path)
+				hppMapName := lib.GetSniHppMapName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated)
+				httpPGPath.Name = hppMapName
+				httpPGPath.IngName = ingName
+				policyNode.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, infraSettingName, ingressNameSet.List(), pathSet.List())
+				httpPGPath.CalculateCheckSum()
+				if tlsNode.CheckHttpPolNameNChecksum(httpPolName, hppMapName, httpPGPath.Checksum) {
+					tlsNode.ReplaceSniHTTPRefInSNINode(httpPGPath, httpPolName, key)
+				}
+				continue
+			}
 			var poolName string
 			var pgfound bool
 			var pgNode *AviPoolGroupNode
 			// Do not use serviceName in SNI Pool Name for ingress for backward compatibility
-			if isIngr {
+			if isIngr && !path.alternateBackend {
 				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated)
 			} else {
 				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated, path.ServiceName)
@@ -415,6 +437,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 					Namespace:   namespace,
 					HostNames:   hostSlice,
 					PoolRatio:   path.weight,
+					IsHTTPRoute: path.isHTTPRoute,
 				},
 				VrfContext: lib.GetVrf(),
 			}
@@ -436,15 +459,15 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 
 			serviceType := lib.GetServiceType()
 			if serviceType == lib.NodePortLocal {
-				if servers := PopulateServersForNPL(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNPL(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else if serviceType == lib.NodePort {
-				if servers := PopulateServersForNodePort(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNodePort(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else {
-				if servers := PopulateServers(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServers(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			}
@@ -465,6 +488,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				// Replace the poolNode.
 				tlsNode.ReplaceSniPoolInSNINode(poolNode, key)
 			}
+			ingressPools[poolNode.Name] = poolNode.PriorityLabel
 			if !pgfound {
 				pathSet.Insert(path.Path)
 				hppMapName := lib.GetSniHppMapName(ingName, namespace, host, path.Path, infraSettingName, vsNode[0].Dedicated)
@@ -476,6 +500,10 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				if tlsNode.CheckHttpPolNameNChecksum(httpPolName, hppMapName, httpPGPath.Checksum) {
 					tlsNode.ReplaceSniHTTPRefInSNINode(httpPGPath, httpPolName, key)
 				}
+				// The matches switch to poolgroups, hence they are not built when poolgroups are not used for SNI.
+				if isIngr && !lib.GetNoPGForSNI() {
+					o.BuildHTTPRuleMatches(tlsNode, httpPolName, httpPGPath, path, pgNode.Name, host, ingName, namespace, infraSetting, &hostpath, key)
+				}
 			}
 			BuildPoolHTTPRule(host, path.Path, ingName, namespace, infraSettingName, key, tlsNode, true, vsNode[0].Dedicated)
 			if lib.IsIstioEnabled() {
@@ -484,6 +512,11 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 		}
 		sniFQDNs = append(sniFQDNs, pathFQDNs...)
 	}
+	if isIngr && !lib.GetNoPGForSNI() {
+		for _, poolName := range getStaleAlternatePools(tlsNode.PoolRefs, ingName, namespace, ingressPools) {
+			o.RemovePoolNodeRefsFromSni(poolName, tlsNode)
+		}
+	}
 	tlsNode.Paths = pathSet.List()
 	tlsNode.IngressNames = ingressNameSet.List()
 
@@ -413,6 +413,10 @@ type AviVsNode struct {
 	Paths                 []string
 	IngressNames          []string
 	AnalyticsPolicy       *avimodels.AnalyticsPolicy
+	RequestsRateLimit     *AviRateLimit
+	ClientAuth            *AviClientAuthNode
+	PersistenceProfile    *AviPersistenceProfileNode
+	NetworkSecurityPolicy *AviNetworkSecurityPolicyNode
 	Dedicated             bool
 	IsL4VS                bool
 }
@@ -571,6 +575,22 @@ func (v *AviVsNode) SetAnalyticsPolicy(policy *avimodels.AnalyticsPolicy) {
 	v.AnalyticsPolicy = policy
 }
 
+func (v *AviVsNode) GetRequestsRateLimit() *AviRateLimit {
+	return v.RequestsRateLimit
+}
+
+func (v *AviVsNode) SetRequestsRateLimit(rateLimit *AviRateLimit) {
+	v.RequestsRateLimit = rateLimit
+}
+
+func (v *AviVsNode) GetClientAuth() *AviClientAuthNode {
+	return v.ClientAuth
+}
+
+func (v *AviVsNode) SetClientAuth(clientAuth *AviClientAuthNode) {
+	v.ClientAuth = clientAuth
+}
+
 func (v *AviVsNode) GetVSVIPLoadBalancerIP() string {
 	if len(v.VSVIPRefs) > 0 {
 		return v.VSVIPRefs[0].IPAddress
@@ -603,6 +623,32 @@ func (o *AviObjectGraph) GetAviVS() []*AviVsNode {
 	return aviVs
 }
 
+// GetAviPoolNodes returns the pools of all the virtualservices in the model, including the pools of
+// their child virtualservices. The caller must hold the read lock of the model, while accessing the pools.
+func (o *AviObjectGraph) GetAviPoolNodes() []*AviPoolNode {
+	var pools []*AviPoolNode
+	var addVsPools func(vsNode *AviVsNode)
+	addVsPools = func(vsNode *AviVsNode) {
+		pools = append(pools, vsNode.PoolRefs...)
+		for _, childNode := range vsNode.SniNodes {
+			addVsPools(childNode)
+		}
+		for _, childNode := range vsNode.PassthroughChildNodes {
+			addVsPools(childNode)
+		}
+	}
+	for _, vsNode := range o.GetAviVS() {
+		addVsPools(vsNode)
+	}
+	for _, evhNode := range o.GetAviEvhVS() {
+		pools = append(pools, evhNode.PoolRefs...)
+		for _, childNode := range evhNode.EvhNodes {
+			pools = append(pools, childNode.PoolRefs...)
+		}
+	}
+	return pools
+}
+
 func (v *AviVsNode) GetCheckSum() uint32 {
 	// Calculate checksum and return
 	v.CalculateCheckSum()
@@ -963,6 +1009,10 @@ func (v *AviVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(vsRefs)
 	}
 
+	if v.DefaultPool != "" || v.DefaultPoolGroup != "" {
+		checksum += utils.Hash(v.DefaultPool + v.DefaultPoolGroup)
+	}
+
 	if v.Enabled != nil {
 		checksum += utils.Hash(utils.Stringify(v.Enabled))
 	}
@@ -977,6 +1027,22 @@ func (v *AviVsNode) CalculateCheckSum() {
 		checksum += lib.GetAnalyticsPolicyChecksum(v.AnalyticsPolicy)
 	}
 
+	if v.RequestsRateLimit != nil {
+		checksum += utils.Hash(utils.Stringify(v.RequestsRateLimit))
+	}
+
+	if v.ClientAuth != nil {
+		checksum += utils.Hash(v.ClientAuth.Name)
+	}
+
+	if v.PersistenceProfile != nil {
+		checksum += v.PersistenceProfile.GetCheckSum()
+	}
+
+	if v.NetworkSecurityPolicy != nil {
+		checksum += v.NetworkSecurityPolicy.GetCheckSum()
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -1012,13 +1078,17 @@ func (v *AviL4PolicyNode) CalculateCheckSum() {
 	var checksum uint32
 	var ports []int64
 	var protocols []string
+	var poolGroups []string
 
 	for _, hpp := range v.PortPool {
 		ports = append(ports, int64(hpp.Port))
 		protocols = append(protocols, hpp.Protocol)
+		if hpp.PoolGroup != "" {
+			poolGroups = append(poolGroups, strings.TrimPrefix(hpp.PoolGroup, "/api/poolgroup?name="))
+		}
 	}
 	if len(v.PortPool) > 0 {
-		checksum = lib.L4PolicyChecksum(ports, protocols, v.AviMarkers, nil, false)
+		checksum = lib.L4PolicyChecksum(ports, protocols, poolGroups, v.AviMarkers, nil, false)
 	}
 	v.CloudConfigCksum = checksum
 }
@@ -1072,6 +1142,9 @@ func (v *AviHttpPolicySetNode) CalculateCheckSum() {
 	for _, sec_rule := range v.SecurityRules {
 		checksum = checksum + utils.Hash(sec_rule.Action) + utils.Hash(sec_rule.MatchCriteria)
 		checksum = checksum + uint32(sec_rule.Port)
+		if sec_rule.RateLimit != nil {
+			checksum = checksum + utils.Hash(utils.Stringify(sec_rule.RateLimit))
+		}
 	}
 	if v.HeaderReWrite != nil {
 		checksum = checksum + utils.Hash(utils.Stringify(v.HeaderReWrite))
@@ -1111,6 +1184,7 @@ type AviHostPathPortPoolPG struct {
 	MatchCriteria string
 	Protocol      string
 	IngName       string
+	PathRule      *AviHTTPPathRule
 }
 
 func (v *AviHostPathPortPoolPG) GetCheckSum() uint32 {
@@ -1140,6 +1214,20 @@ type AviHTTPSecurity struct {
 	MatchCriteria string
 	Enable        bool
 	Port          int64
+	RateLimit     *AviRateLimit
+}
+
+// AviRateLimit permits Count requests, in addition to Burst, in each Period seconds, per client IP if PerClientIP
+// is set or per value of the Header if it is set, and takes the Action on the requests above the limit. Redirect is
+// the target of the RL_ACTION_REDIRECT action.
+type AviRateLimit struct {
+	Count       int32
+	Period      int32
+	Burst       int32
+	PerClientIP bool
+	Header      string
+	Action      string
+	Redirect    *AviHTTPRedirect
 }
 type AviHostHeaderRewrite struct {
 	Name       string
@@ -1147,6 +1235,57 @@ type AviHostHeaderRewrite struct {
 	TargetHost string
 }
 
+// AviHTTPPathRule holds the match criteria and actions, in addition to the path match and the
+// pool/poolgroup switching, that are applied on the http request rule of a host path.
+type AviHTTPPathRule struct {
+	HeaderMatches   []AviHTTPHeaderMatch
+	CookieMatch     *AviHTTPCookieMatch
+	RequestHeaders  []AviHTTPHeaderAction
+	ResponseHeaders []AviHTTPHeaderAction
+	RewriteURL      *AviHTTPRewriteURL
+	Redirect        *AviHTTPRedirect
+	RateLimit       *AviRateLimit
+}
+
+type AviHTTPHeaderMatch struct {
+	Name          string
+	Values        []string
+	MatchCriteria string
+	MatchCase     string
+}
+
+type AviHTTPCookieMatch struct {
+	Name          string
+	Value         string
+	MatchCriteria string
+	MatchCase     string
+}
+
+type AviHTTPHeaderAction struct {
+	Action string
+	Name   string
+	Value  string
+}
+
+// AviHTTPRewriteURL rewrites the host header and/or the path of the request. MatchPrefix is replaced
+// by PathPrefix when the path match is a prefix match, otherwise the full path is replaced by Path.
+type AviHTTPRewriteURL struct {
+	Host        string
+	Path        string
+	PathPrefix  string
+	MatchPrefix string
+}
+
+type AviHTTPRedirect struct {
+	Protocol    string
+	Host        string
+	Port        int32
+	Path        string
+	PathPrefix  string
+	MatchPrefix string
+	StatusCode  string
+}
+
 type AviTLSKeyCertNode struct {
 	Name             string
 	Tenant           string
@@ -1208,6 +1347,7 @@ type AviVSVIPNode struct {
 	FQDNs                   []string
 	VrfContext              string
 	IPAddress               string
+	IPFamily                string
 	VipNetworks             []akov1alpha1.AviInfraSettingVipNetwork
 	EnablePublicIP          *bool
 	BGPPeerLabels           []string
@@ -1233,6 +1373,10 @@ func (v *AviVSVIPNode) CalculateCheckSum() {
 		checksum += utils.Hash(v.IPAddress)
 	}
 
+	if v.IPFamily != "" {
+		checksum += utils.Hash(v.IPFamily)
+	}
+
 	if len(v.VipNetworks) > 0 {
 		var vipNetworkStringList []string
 		for _, vipNetwork := range v.VipNetworks {
@@ -1359,6 +1503,9 @@ func (v *AviHTTPDataScriptNode) CalculateCheckSum() {
 	checksum := lib.DSChecksum(v.PoolGroupRefs, nil, false)
 	if len(v.PoolGroupRefs) == 1 {
 		checksum += utils.Hash(fmt.Sprintf(utils.HTTP_DS_SCRIPT_MODIFIED, v.PoolGroupRefs[0]))
+	} else if len(v.PoolGroupRefs) > 1 && v.DataScript != nil {
+		// The script selecting the default backend depends on the hosts of the VS.
+		checksum += utils.Hash(v.Script)
 	}
 	v.CloudConfigCksum = checksum
 }
@@ -1431,6 +1578,120 @@ func (v *AviPkiProfileNode) CalculateCheckSum() {
 	v.CloudConfigCksum = checksum
 }
 
+// AviClientAuthNode is the application profile that validates the client certificates for a virtualservice,
+// with the Mode of client certificate validation. The client certificates are validated against the PkiProfile
+// created from the CA bundle of the Secret, or against the existing PKI profile when PkiProfile is not set.
+type AviClientAuthNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	Mode             string
+	PkiProfileName   string
+	PkiProfile       *AviPkiProfileNode
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviClientAuthNode) GetNodeType() string {
+	return "ClientAuthNode"
+}
+
+func (v *AviClientAuthNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviClientAuthNode) CalculateCheckSum() {
+	checksum := lib.ApplicationProfileChecksum(v.Name, v.Mode, v.PkiProfileName, v.AviMarkers, nil, false)
+	v.CloudConfigCksum = checksum
+}
+
+// AviPersistenceProfileNode is the client IP persistence profile of the pools of a virtualservice, with the
+// Timeout in minutes after which the persistence of a client to a server expires.
+type AviPersistenceProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	Timeout          int32
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviPersistenceProfileNode) GetNodeType() string {
+	return "PersistenceProfileNode"
+}
+
+func (v *AviPersistenceProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviPersistenceProfileNode) CalculateCheckSum() {
+	checksum := lib.PersistenceProfileChecksum(v.Name, v.Timeout, v.AviMarkers, nil, false)
+	v.CloudConfigCksum = checksum
+}
+
+// AviHealthMonitorNode is a health monitor of a pool, which is derived from the readiness probe of the Pods of
+// the backend Service, or defined inline in an HTTPRule. The MonitorPort is set only if the port to be checked
+// differs from the port of the servers.
+type AviHealthMonitorNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	Type             string
+	HTTPRequest      string
+	ResponseCodes    []string
+	TCPRequest       string
+	MonitorPort      int32
+	SendInterval     int32
+	ReceiveTimeout   int32
+	SuccessfulChecks int32
+	FailedChecks     int32
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviHealthMonitorNode) GetNodeType() string {
+	return "HealthMonitorNode"
+}
+
+func (v *AviHealthMonitorNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviHealthMonitorNode) CalculateCheckSum() {
+	checksum := lib.HealthMonitorChecksum(v.Name, v.Type, v.HTTPRequest, v.TCPRequest, v.ResponseCodes, v.MonitorPort, v.SendInterval, v.ReceiveTimeout,
+		v.SuccessfulChecks, v.FailedChecks, v.AviMarkers, nil, false)
+	v.CloudConfigCksum = checksum
+}
+
+// AviNetworkSecurityPolicyNode is the network security policy of a virtualservice, which allows the clients only
+// from the SourceRanges. All the clients are denied when the SourceRanges are empty, which is the case when none
+// of the source ranges of the Service are valid.
+type AviNetworkSecurityPolicyNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	SourceRanges     []string
+	AviMarkers       utils.AviObjectMarkers
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetNodeType() string {
+	return "NetworkSecurityPolicyNode"
+}
+
+func (v *AviNetworkSecurityPolicyNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviNetworkSecurityPolicyNode) CalculateCheckSum() {
+	checksum := lib.NetworkSecurityPolicyChecksum(v.Name, v.SourceRanges, v.AviMarkers, nil, false)
+	v.CloudConfigCksum = checksum
+}
+
 type AviPoolNode struct {
 	Name                     string
 	Tenant                   string
@@ -1453,6 +1714,8 @@ type AviPoolNode struct {
 	PkiProfile               *AviPkiProfileNode
 	NetworkPlacementSettings map[string][]string
 	HealthMonitors           []string
+	HealthMonitor            *AviHealthMonitorNode
+	HTTPRuleHealthMonitors   []*AviHealthMonitorNode
 	ApplicationPersistence   string
 	VrfContext               string
 	T1Lr                     string // Only applicable to NSX-T cloud, if this value is set, we automatically should unset the VRF context value.
@@ -1516,6 +1779,14 @@ func (v *AviPoolNode) CalculateCheckSum() {
 		checksum += v.PkiProfile.GetCheckSum()
 	}
 
+	if v.HealthMonitor != nil {
+		checksum += v.HealthMonitor.GetCheckSum()
+	}
+
+	for _, healthMonitor := range v.HTTPRuleHealthMonitors {
+		checksum += healthMonitor.GetCheckSum()
+	}
+
 	if v.ApplicationPersistence != "" {
 		checksum += utils.Hash(v.ApplicationPersistence)
 	}
@@ -1583,18 +1854,45 @@ type AviPoolMetaServer struct {
 	Ip         avimodels.IPAddr
 	ServerNode string
 	Port       int32
+	// Draining servers are kept in the pool as disabled until their drain period ends.
+	Draining bool `json:",omitempty"`
+	// Hostname is set for the servers which are resolved by DNS on the Service Engines.
+	Hostname string `json:",omitempty"`
 }
 
 type IngressHostPathSvc struct {
-	ServiceName    string
-	Path           string
-	PathType       networkingv1.PathType
-	Port           int32
-	weight         int32 //required for alternate backends in openshift route
-	PortName       string
-	TargetPort     intstr.IntOrString
-	clusterContext string // required for Multi-cluster ingress
-	svcNamespace   string // required for Multi-cluster ingress
+	ServiceName      string
+	Path             string
+	PathType         networkingv1.PathType
+	Port             int32
+	weight           int32 //required for alternate backends in openshift route
+	PortName         string
+	TargetPort       intstr.IntOrString
+	clusterContext   string // required for Multi-cluster ingress
+	svcNamespace     string // required for Multi-cluster ingress
+	serviceNamespace string // namespace of the Service, if it differs from the namespace of the Ingress
+	isHTTPRoute      bool   // required for Gateway API HTTPRoute
+	isTLSRoute       bool   // required for Gateway API TLSRoute
+	matchPath        string // path to match in the http policy, if Path is made unique per HTTPRoute rule
+	alternateBackend bool   // backend which shares the path with an earlier backend of the same Ingress
+	pathRule         *AviHTTPPathRule
+}
+
+// getServiceNamespace returns the namespace of the Service backing this host path, which defaults to
+// the namespace of the Ingress.
+func (p IngressHostPathSvc) getServiceNamespace(namespace string) string {
+	if p.serviceNamespace != "" {
+		return p.serviceNamespace
+	}
+	return namespace
+}
+
+// GetMatchPath returns the path to be matched in the http policy rule for this host path.
+func (p IngressHostPathSvc) GetMatchPath() string {
+	if p.matchPath != "" {
+		return p.matchPath
+	}
+	return p.Path
 }
 
 type IngressHostMap map[string]HostMetadata
@@ -1630,6 +1928,7 @@ type IngressConfig struct {
 	TlsCollection         []TlsSettings
 	IngressHostMap
 	InsecureEdgeTermAllow bool
+	DefaultBackend        *IngressHostPathSvc
 }
 
 type SecureHostNameMapProp struct {
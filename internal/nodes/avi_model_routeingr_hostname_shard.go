@@ -47,6 +47,18 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 			return
 		}
 		routeIgrObj, err, processObj = GetMultiClusterIngressModel(objname, namespace, key)
+	case lib.HTTPRoute:
+		if lib.GetDynamicInformers() == nil || lib.GetDynamicInformers().HTTPRouteInformer == nil {
+			utils.AviLog.Warnf("key: %s, gateway api informers are not initialized for object type: %s", key, objType)
+			return
+		}
+		routeIgrObj, err, processObj = GetHTTPRouteModel(objname, namespace, key)
+	case lib.TLSRoute:
+		if lib.GetDynamicInformers() == nil || lib.GetDynamicInformers().TLSRouteInformer == nil {
+			utils.AviLog.Warnf("key: %s, gateway api informers are not initialized for object type: %s", key, objType)
+			return
+		}
+		routeIgrObj, err, processObj = GetTLSRouteModel(objname, namespace, key)
 	default:
 		utils.AviLog.Infof("key: %s, starting unsupported object type: %s", key, objType)
 		return
@@ -81,6 +93,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 			} else {
 				RouteIngrDeletePoolsByHostname(routeIgrObj, namespace, objname, key, fullsync, sharedQueue)
 			}
+			DeleteDefaultBackend(routeIgrObj, key, fullsync, sharedQueue)
 		}
 		return
 	}
@@ -105,6 +118,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		ProcessPassthroughHosts(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
 		// delete stale data
 		DeleteStaleDataForEvh(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
+		ProcessDefaultBackend(routeIgrObj, key, parsedIng, &modelList)
 		// hostNamePathStore cache operation
 		_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 		updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
@@ -130,6 +144,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 
 	utils.AviLog.Debugf("key: %s, msg: Stored hosts: %v, hosts map: %v", key, Storedhosts, hostsMap)
 	DeleteStaleData(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
+	ProcessDefaultBackend(routeIgrObj, key, parsedIng, &modelList)
 
 	// hostNamePathStore cache operation
 	_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
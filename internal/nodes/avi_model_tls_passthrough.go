@@ -158,21 +158,21 @@ func (o *AviObjectGraph) BuildGraphForPassthrough(svclist []IngressHostPathSvc,
 		poolNode.TargetPort = obj.TargetPort
 		poolNode.ServiceMetadata = lib.ServiceMetadataObj{
 			IngressName: objName, Namespace: namespace, PoolRatio: obj.weight,
-			HostNames: []string{hostname},
+			HostNames: []string{hostname}, IsTLSRoute: obj.isTLSRoute,
 		}
 
 		poolNode.Servers = []AviPoolMetaServer{}
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
-			if servers := PopulateServersForNPL(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNPL(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else if serviceType == lib.NodePort {
-			if servers := PopulateServersForNodePort(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNodePort(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else {
-			if servers := PopulateServers(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+			if servers := PopulateServers(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		}
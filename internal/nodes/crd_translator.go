@@ -64,6 +64,9 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 	vsHTTPPolicySets := []string{}
 	vsDatascripts := []string{}
 	var analyticsPolicy *models.AnalyticsPolicy
+	var rateLimit *akov1alpha1.RateLimit
+	var clientAuth *akov1alpha1.HostRuleClientAuth
+	var hrNamespace string
 
 	// Get the existing VH domain names and then manipulate it based on the aliases in Hostrule CRD.
 	VHDomainNames := vsNode.GetVHDomainNames()
@@ -166,6 +169,10 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 			}
 		}
 
+		rateLimit = hostrule.Spec.VirtualHost.RateLimit
+		clientAuth = hostrule.Spec.VirtualHost.TLS.ClientAuth
+		hrNamespace = hostrule.Namespace
+
 		for _, alias := range hostrule.Spec.VirtualHost.Aliases {
 			if !utils.HasElem(VHDomainNames, alias) {
 				VHDomainNames = append(VHDomainNames, alias)
@@ -197,12 +204,134 @@ func BuildL7HostRule(host, key string, vsNode AviVsEvhSniModel) {
 	vsNode.SetPortProtocols(portProtocols)
 	vsNode.SetVSVIPLoadBalancerIP(lbIP)
 	vsNode.SetVHDomainNames(VHDomainNames)
+	buildHostRateLimit(vsNode, rateLimit, host, hrNamespace, key)
+	buildHostClientAuth(vsNode, clientAuth, host, hrNamespace, key)
 
 	serviceMetadataObj := vsNode.GetServiceMetadata()
 	serviceMetadataObj.CRDStatus = crdStatus
 	vsNode.SetServiceMetadata(serviceMetadataObj)
 }
 
+// buildHostRateLimit applies the rate limit of the HostRule on the vs. The rate limit per header value is set on the
+// requests rate limit of the vs, while the rate limit per client IP requires an http security rule, which is added in
+// a separate http policyset.
+func buildHostRateLimit(vsNode AviVsEvhSniModel, rateLimit *akov1alpha1.RateLimit, host, namespace, key string) {
+	var requestsRateLimit *AviRateLimit
+	var securityRules []AviHTTPSecurity
+	if rateLimit != nil {
+		if rateLimit.Key == lib.RateLimitKeyHeader {
+			requestsRateLimit = buildRateLimit(rateLimit)
+		} else {
+			securityRules = []AviHTTPSecurity{{
+				Action:    lib.RATE_LIMIT,
+				Enable:    true,
+				RateLimit: buildRateLimit(rateLimit),
+			}}
+		}
+	}
+	vsNode.SetRequestsRateLimit(requestsRateLimit)
+
+	policyName := lib.GetRateLimitPolicy(vsNode.GetName())
+	httpPolicyRefs := vsNode.GetHttpPolicyRefs()
+	for i, policy := range httpPolicyRefs {
+		if policy.Name == policyName {
+			httpPolicyRefs = append(httpPolicyRefs[:i:i], httpPolicyRefs[i+1:]...)
+			break
+		}
+	}
+	if len(securityRules) > 0 {
+		rateLimitPolicy := &AviHttpPolicySetNode{
+			Name:               policyName,
+			Tenant:             lib.GetTenant(),
+			SecurityRules:      securityRules,
+			AttachedToSharedVS: vsNode.IsSharedVS(),
+		}
+		if !vsNode.IsSharedVS() {
+			rateLimitPolicy.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, "", nil, nil)
+		}
+		rateLimitPolicy.CalculateCheckSum()
+		httpPolicyRefs = append(httpPolicyRefs, rateLimitPolicy)
+		utils.AviLog.Infof("key: %s, msg: added rate limit policy %s for host %s", key, policyName, host)
+	}
+	vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+}
+
+// buildRateLimit translates the rate limit of a HostRule or an HTTPRule.
+// buildHostClientAuth sets the application profile that validates the client certificates of the host on the
+// virtualservice. The client certificates can not be validated per host on a shared virtualservice.
+func buildHostClientAuth(vsNode AviVsEvhSniModel, clientAuth *akov1alpha1.HostRuleClientAuth, host, namespace, key string) {
+	if clientAuth == nil {
+		vsNode.SetClientAuth(nil)
+		return
+	}
+	if vsNode.IsSharedVS() {
+		utils.AviLog.Warnf("key: %s, msg: client certificate validation is not supported on shared virtualservice %s, ignoring it for host %s", key, vsNode.GetName(), host)
+		vsNode.SetClientAuth(nil)
+		return
+	}
+
+	clientAuthNode := &AviClientAuthNode{
+		Name:       lib.GetClientAuthName(vsNode.GetName()),
+		Tenant:     lib.GetTenant(),
+		Mode:       "SSL_CLIENT_CERTIFICATE_REQUIRE",
+		AviMarkers: lib.PopulateVSNodeMarkers(namespace, host, ""),
+	}
+	if clientAuth.Mode == lib.ClientAuthModeRequest {
+		clientAuthNode.Mode = "SSL_CLIENT_CERTIFICATE_REQUEST"
+	}
+	if clientAuth.CA.Type == akov1alpha1.HostRuleSecretTypeSecretReference {
+		secret, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(clientAuth.CA.Name)
+		if err != nil || len(secret.Data[lib.ClientAuthCAKey]) == 0 {
+			utils.AviLog.Warnf("key: %s, msg: CA bundle not found in secret %s/%s for host %s, err: %v", key, namespace, clientAuth.CA.Name, host, err)
+			vsNode.SetClientAuth(nil)
+			return
+		}
+		clientAuthNode.PkiProfile = &AviPkiProfileNode{
+			Name:       clientAuthNode.Name,
+			Tenant:     lib.GetTenant(),
+			CACert:     string(secret.Data[lib.ClientAuthCAKey]),
+			AviMarkers: clientAuthNode.AviMarkers,
+		}
+		clientAuthNode.PkiProfileName = clientAuthNode.PkiProfile.Name
+	} else {
+		clientAuthNode.PkiProfileName = clientAuth.CA.Name
+	}
+	vsNode.SetClientAuth(clientAuthNode)
+}
+
+func buildRateLimit(rateLimit *akov1alpha1.RateLimit) *AviRateLimit {
+	aviRateLimit := &AviRateLimit{
+		Count:  rateLimit.Requests,
+		Period: rateLimit.Period,
+		Burst:  rateLimit.Burst,
+	}
+	if rateLimit.Key == lib.RateLimitKeyHeader {
+		aviRateLimit.Header = rateLimit.Header
+	} else {
+		aviRateLimit.PerClientIP = true
+	}
+	switch rateLimit.Action.Type {
+	case lib.RateLimitActionReport:
+		aviRateLimit.Action = "RL_ACTION_NONE"
+	case lib.RateLimitActionRedirect:
+		aviRateLimit.Action = "RL_ACTION_REDIRECT"
+		aviRateLimit.Redirect = &AviHTTPRedirect{
+			Protocol:   "HTTPS",
+			StatusCode: lib.STATUS_REDIRECT,
+		}
+		if redirect := rateLimit.Action.Redirect; redirect != nil {
+			if redirect.Protocol != "" {
+				aviRateLimit.Redirect.Protocol = redirect.Protocol
+			}
+			aviRateLimit.Redirect.Host = redirect.Host
+			aviRateLimit.Redirect.Path = redirect.Path
+		}
+	default:
+		aviRateLimit.Action = "RL_ACTION_DROP_CONN"
+	}
+	return aviRateLimit
+}
+
 // BuildPoolHTTPRule notes
 // when we get an ingress update and we are building the corresponding pools of that ingress
 // we need to get all httprules which match ingress's host/path
@@ -255,6 +384,7 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 			pathPkiProfile := pool.PkiProfileRef
 			destinationCertNode := pool.PkiProfile
 			pathHMs := pool.HealthMonitors
+			pathInlineHMs := pool.HTTPRuleHealthMonitors
 			if poolPath == "" && path == "/" {
 				// In case of openfhit Route, the path could be empty, in that case, treat
 				// httprule targt path / as that of empty path, to match the pool appropriately.
@@ -319,11 +449,25 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 					}
 				}
 
+				for _, inlineHM := range httpRulePath.InlineHealthMonitors {
+					hmNode := buildHTTPRuleHealthMonitor(pool.Name, inlineHM)
+					hmNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, host, "", pool.AviMarkers.ServiceName, []string{ingName}, []string{path})
+					if !utils.HasElem(pathHMs, fmt.Sprintf("/api/healthmonitor?name=%s", hmNode.Name)) {
+						pathHMs = append(pathHMs, fmt.Sprintf("/api/healthmonitor?name=%s", hmNode.Name))
+						pathInlineHMs = append(pathInlineHMs, hmNode)
+					}
+				}
+
 				pool.SniEnabled = isPathSniEnabled
 				pool.SslProfileRef = pathSslProfile
 				pool.PkiProfileRef = pathPkiProfile
 				pool.PkiProfile = destinationCertNode
 				pool.HealthMonitors = pathHMs
+				pool.HTTPRuleHealthMonitors = pathInlineHMs
+				if len(pathHMs) > 0 {
+					// The health monitors of the HTTPRule take precedence over the one derived from the readiness probe.
+					pool.HealthMonitor = nil
+				}
 				pool.ApplicationPersistence = persistenceProfile
 
 				// from this path, generate refs to this pool node
@@ -356,3 +500,51 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 	}
 
 }
+
+// buildHTTPRuleHealthMonitor returns a health monitor of the pool, which is defined inline in the path of an HTTPRule.
+// The fields which are not set take the default values of the Avi health monitors.
+func buildHTTPRuleHealthMonitor(poolName string, inlineHM akov1alpha1.HTTPRuleHealthMonitor) *AviHealthMonitorNode {
+	healthMonitor := &AviHealthMonitorNode{
+		Name:             lib.GetHTTPRuleHealthMonitorName(poolName, inlineHM.Name),
+		Tenant:           lib.GetTenant(),
+		MonitorPort:      inlineHM.MonitorPort,
+		SendInterval:     inlineHM.SendInterval,
+		ReceiveTimeout:   inlineHM.ReceiveTimeout,
+		SuccessfulChecks: inlineHM.SuccessfulChecks,
+		FailedChecks:     inlineHM.FailedChecks,
+	}
+	switch inlineHM.Type {
+	case lib.HealthMonitorTypeHTTP, lib.HealthMonitorTypeHTTPS:
+		healthMonitor.Type = healthMonitorTypeHTTP
+		if inlineHM.Type == lib.HealthMonitorTypeHTTPS {
+			healthMonitor.Type = healthMonitorTypeHTTPS
+		}
+		healthMonitor.HTTPRequest = inlineHM.SendString
+		if healthMonitor.HTTPRequest == "" {
+			healthMonitor.HTTPRequest = "GET / HTTP/1.0"
+		}
+		healthMonitor.ResponseCodes = inlineHM.ResponseCodes
+		if len(healthMonitor.ResponseCodes) == 0 {
+			healthMonitor.ResponseCodes = []string{"HTTP_2XX", "HTTP_3XX"}
+		}
+	case lib.HealthMonitorTypeTCP:
+		healthMonitor.Type = healthMonitorTypeTCP
+		healthMonitor.TCPRequest = inlineHM.SendString
+	}
+	if healthMonitor.SendInterval == 0 {
+		healthMonitor.SendInterval = lib.DefaultHealthMonitorSendInterval
+	}
+	if healthMonitor.ReceiveTimeout == 0 {
+		healthMonitor.ReceiveTimeout = lib.DefaultHealthMonitorReceiveTimeout
+		if healthMonitor.ReceiveTimeout >= healthMonitor.SendInterval {
+			healthMonitor.ReceiveTimeout = healthMonitor.SendInterval - 1
+		}
+	}
+	if healthMonitor.SuccessfulChecks == 0 {
+		healthMonitor.SuccessfulChecks = lib.DefaultHealthMonitorChecks
+	}
+	if healthMonitor.FailedChecks == 0 {
+		healthMonitor.FailedChecks = lib.DefaultHealthMonitorChecks
+	}
+	return healthMonitor
+}
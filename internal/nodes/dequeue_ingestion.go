@@ -33,8 +33,8 @@ import (
 func DequeueIngestion(key string, fullsync bool) {
 	// The key format expected here is: objectType/Namespace/ObjKey
 	// The assumption is that an update either affects an LB service type or an ingress. It cannot be both.
-	var ingressFound, routeFound, mciFound bool
-	var ingressNames, routeNames, mciNames []string
+	var ingressFound, routeFound, mciFound, httpRouteFound, tlsRouteFound, gatewayAPIGatewayFound bool
+	var ingressNames, routeNames, mciNames, httpRouteNames, tlsRouteNames, gatewayAPIGatewayNames []string
 	utils.AviLog.Infof("key: %s, msg: starting graph Sync", key)
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 
@@ -81,8 +81,21 @@ func DequeueIngestion(key string, fullsync bool) {
 		if utils.GetInformers().MultiClusterIngressInformer != nil && schema.GetParentMultiClusterIngresses != nil {
 			mciNames, mciFound = schema.GetParentMultiClusterIngresses(name, namespace, key)
 		}
+		if lib.UseGatewayAPI() && schema.GetParentHTTPRoutes != nil {
+			httpRouteNames, httpRouteFound = schema.GetParentHTTPRoutes(name, namespace, key)
+		}
+		if lib.UseGatewayAPI() && schema.GetParentTLSRoutes != nil {
+			tlsRouteNames, tlsRouteFound = schema.GetParentTLSRoutes(name, namespace, key)
+		}
+		if lib.UseGatewayAPI() && schema.GetParentGatewayAPIGateways != nil {
+			gatewayAPIGatewayNames, gatewayAPIGatewayFound = schema.GetParentGatewayAPIGateways(name, namespace, key)
+		}
 	}
 
+	// The objects affected by the key are known, the graphs of their models are built from here on.
+	graphSpan := utils.StartSpan("graph", key)
+	defer graphSpan.End()
+
 	if objType == lib.HostRule &&
 		((utils.GetInformers().IngressInformer != nil && len(ingressNames) == 0) ||
 			(utils.GetInformers().RouteInformer != nil && len(routeNames) == 0)) {
@@ -124,6 +137,17 @@ func DequeueIngestion(key string, fullsync bool) {
 					}
 					handleMultiClusterIngress(svcl7Key, fullsync, filteredMCINames)
 				}
+				if lib.UseGatewayAPI() {
+					if filteredHTTPRouteNames, filteredHTTPRouteFound := SvcToHTTPRoute(svcName, namespace, svcl7Key); filteredHTTPRouteFound {
+						handleHTTPRoute(svcl7Key, fullsync, filteredHTTPRouteNames)
+					}
+					if filteredTLSRouteNames, filteredTLSRouteFound := SvcToTLSRoute(svcName, namespace, svcl7Key); filteredTLSRouteFound {
+						handleTLSRoute(svcl7Key, fullsync, filteredTLSRouteNames)
+					}
+					if filteredGateways, filteredGatewaysFound := SvcToGatewayAPIGateway(svcName, namespace, svcl7Key); filteredGatewaysFound {
+						handleGatewayAPIL4Gateways(svcl7Key, fullsync, filteredGateways)
+					}
+				}
 			}
 		}
 		return
@@ -148,6 +172,18 @@ func DequeueIngestion(key string, fullsync bool) {
 		handleRoute(key, fullsync, routeNames)
 	}
 
+	if httpRouteFound {
+		handleHTTPRoute(key, fullsync, httpRouteNames)
+	}
+
+	if tlsRouteFound {
+		handleTLSRoute(key, fullsync, tlsRouteNames)
+	}
+
+	if gatewayAPIGatewayFound {
+		handleGatewayAPIL4Gateways(key, fullsync, gatewayAPIGatewayNames)
+	}
+
 	// Push Services from InfraSetting updates. Valid for annotation based approach.
 	if objType == lib.AviInfraSetting && !lib.UseServicesAPI() {
 		svcNames, svcFound := schema.GetParentServices(name, namespace, key)
@@ -178,7 +214,7 @@ func DequeueIngestion(key string, fullsync bool) {
 			}
 
 			// Do not handle service update if it belongs to unaccepted namespace
-			if svcObj.Spec.Type == utils.LoadBalancer && !lib.GetLayer7Only() && utils.CheckIfNamespaceAccepted(namespace) {
+			if svcObj.Spec.Type == utils.LoadBalancer && lib.IsServiceLBClassAccepted(svcObj) && !lib.GetLayer7Only() && utils.CheckIfNamespaceAccepted(namespace) {
 				// This endpoint update affects a LB service.
 				aviModelGraph := NewAviObjectGraph()
 				if sharedVipKey, ok := svcObj.Annotations[lib.SharedVipSvcLBAnnotation]; ok && sharedVipKey != "" {
@@ -700,13 +736,71 @@ func handleMultiClusterIngress(key string, fullsync bool, ingressNames []string)
 	}
 }
 
+// handleHTTPRoute processes the HTTPRoutes, the routeNames are in namespace/name format.
+func handleHTTPRoute(key string, fullsync bool, routeNames []string) {
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, route := range routeNames {
+		nsRoute, nameRoute := getIngressNSNameForIngestion(lib.HTTPRoute, "", route)
+		utils.AviLog.Debugf("key: %s, msg: processing HTTPRoute: %s", key, route)
+		HostNameShardAndPublish(lib.HTTPRoute, nameRoute, nsRoute, key, fullsync, sharedQueue)
+	}
+}
+
+// handleTLSRoute processes the TLSRoutes, the routeNames are in namespace/name format.
+func handleTLSRoute(key string, fullsync bool, routeNames []string) {
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, route := range routeNames {
+		nsRoute, nameRoute := getIngressNSNameForIngestion(lib.TLSRoute, "", route)
+		utils.AviLog.Debugf("key: %s, msg: processing TLSRoute: %s", key, route)
+		HostNameShardAndPublish(lib.TLSRoute, nameRoute, nsRoute, key, fullsync, sharedQueue)
+	}
+}
+
+// handleGatewayAPIL4Gateways builds the L4 models of the gateway.networking.k8s.io Gateways, the gateways are in
+// namespace/name format. The model is removed if the Gateway no longer has any TCPRoutes or UDPRoutes attached.
+func handleGatewayAPIL4Gateways(key string, fullsync bool, gateways []string) {
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, gateway := range gateways {
+		gwNSName := strings.Split(gateway, "/")
+		if len(gwNSName) != 2 {
+			continue
+		}
+		namespace, gwName := gwNSName[0], gwNSName[1]
+		utils.AviLog.Debugf("key: %s, msg: processing L4 listeners of gateway: %s", key, gateway)
+		modelName := lib.GetModelName(lib.GetTenant(), lib.Encode(lib.GetNamePrefix()+namespace+"-"+gwName, lib.ADVANCED_L4))
+		aviModelGraph := NewAviObjectGraph()
+		if utils.CheckIfNamespaceAccepted(namespace) {
+			aviModelGraph.BuildGatewayAPIL4Graph(namespace, gwName, key)
+		}
+		if len(aviModelGraph.GetOrderedNodes()) == 0 {
+			if found, _ := objects.SharedAviGraphLister().Get(modelName); found {
+				objects.SharedAviGraphLister().Save(modelName, nil)
+				if !fullsync {
+					PublishKeyToRestLayer(modelName, key, sharedQueue)
+				}
+			}
+			continue
+		}
+		ok := saveAviModel(modelName, aviModelGraph, key)
+		if ok && !fullsync {
+			PublishKeyToRestLayer(modelName, key, sharedQueue)
+		}
+	}
+}
+
 func getIngressNSNameForIngestion(objType, namespace, nsname string) (string, string) {
-	if objType == lib.HostRule || objType == lib.HTTPRule || objType == utils.Secret {
+	if objType == lib.HostRule || objType == lib.HTTPRule || objType == utils.Secret || objType == lib.HTTPRoute || objType == lib.TLSRoute {
 		arr := strings.Split(nsname, "/")
 		return arr[0], arr[1]
 	}
 
-	if objType == utils.IngressClass || objType == lib.AviInfraSetting {
+	if objType == utils.IngressClass || objType == lib.AviInfraSetting || objType == lib.ReferenceGrant {
+		arr := strings.Split(nsname, "/")
+		return arr[0], arr[1]
+	}
+
+	// Ingresses referring to Services in other namespaces are stored in namespace/name format.
+	if (objType == utils.Service || objType == utils.Endpoints) && strings.Contains(nsname, "/") {
 		arr := strings.Split(nsname, "/")
 		return arr[0], arr[1]
 	}
@@ -787,6 +881,7 @@ func processNodeObj(key, nodename string, sharedQueue *utils.WorkerQueue, fullsy
 
 func PublishKeyToRestLayer(modelName string, key string, sharedQueue *utils.WorkerQueue) {
 	bkt := utils.Bkt(modelName, sharedQueue.NumWorkers)
+	utils.TraceEnqueue(sharedQueue.WorkqueueName, modelName, key)
 	sharedQueue.Workqueue[bkt].AddRateLimited(modelName)
 	utils.AviLog.Infof("key: %s, msg: Published key with modelName: %s", key, modelName)
 }
@@ -807,6 +902,12 @@ func isServiceDelete(svcName string, namespace string, key string) bool {
 		return true
 	}
 
+	// The loadBalancerClass of the service might not be handled by AKO anymore.
+	if !lib.IsServiceLBClassAccepted(svc) {
+		utils.AviLog.Infof("key: %s, msg: loadBalancerClass of the service is not handled by AKO", key)
+		return true
+	}
+
 	return false
 }
 
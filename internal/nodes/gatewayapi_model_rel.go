@@ -0,0 +1,184 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1alpha2"
+)
+
+// The TLSRoute functions in this file return the TLSRoutes affected by a change, in namespace/name format.
+// The TCPRoutes and UDPRoutes are translated per Gateway, hence the L4 functions return the affected
+// gateway.networking.k8s.io Gateways, in namespace/name format.
+
+func TLSRouteChanges(routeName string, namespace string, key string) ([]string, bool) {
+	routeNSName := namespace + "/" + routeName
+	routeObj, err := lib.GetTLSRoute(namespace, routeName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: getting TLSRoute with name: %s", key, routeName)
+		// Detect a delete condition here.
+		if k8serrors.IsNotFound(err) {
+			objects.SharedTLSRouteSvcLister().IngressMappings(namespace).RemoveIngressMappings(routeName)
+			objects.GatewayAPIObjLister().DeleteRouteToGateways(lib.GetGatewayAPIRouteKey(lib.TLSRoute, namespace, routeName))
+		}
+		return []string{routeNSName}, true
+	}
+
+	_, oldSvcs := objects.SharedTLSRouteSvcLister().IngressMappings(namespace).GetIngToSvc(routeName)
+	currSvcs := parseServicesForTLSRoute(routeObj, key)
+	for _, svc := range lib.Difference(oldSvcs, currSvcs) {
+		utils.AviLog.Debugf("key: %s, msg: removing TLSRoute relationship for service: %s", key, svc)
+		objects.SharedTLSRouteSvcLister().IngressMappings(namespace).RemoveSvcFromIngressMappings(routeName, svc)
+	}
+	for _, svc := range lib.Difference(currSvcs, oldSvcs) {
+		utils.AviLog.Debugf("key: %s, msg: updating TLSRoute relationship for service: %s", key, svc)
+		objects.SharedTLSRouteSvcLister().IngressMappings(namespace).UpdateIngressMappings(routeName, svc)
+	}
+	return []string{routeNSName}, true
+}
+
+// parseServicesForTLSRoute returns the core Services in the namespace of the TLSRoute, referred by the backendRefs.
+func parseServicesForTLSRoute(route *gatewayv1alpha2.TLSRoute, key string) []string {
+	var services []string
+	for _, rule := range route.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			if !isServiceBackendRef(backendRef.BackendObjectReference) {
+				continue
+			}
+			if backendRef.Namespace != nil && string(*backendRef.Namespace) != route.Namespace {
+				continue
+			}
+			services = appendUnique(services, string(backendRef.Name))
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: total services retrieved from TLSRoute: %s", key, services)
+	return services
+}
+
+func SvcToTLSRoute(svcName string, namespace string, key string) ([]string, bool) {
+	_, routes := objects.SharedTLSRouteSvcLister().IngressMappings(namespace).GetSvcToIng(svcName)
+	if len(routes) == 0 {
+		return nil, false
+	}
+	var routeNSNames []string
+	for _, route := range routes {
+		routeNSNames = append(routeNSNames, namespace+"/"+route)
+	}
+	utils.AviLog.Debugf("key: %s, msg: TLSRoutes retrieved for service %s: %s", key, svcName, routeNSNames)
+	return routeNSNames, true
+}
+
+// GatewayAPIGatewayToTLSRoute returns the TLSRoutes attached to the Gateway, along with the TLSRoutes
+// which refer to the Gateway as parent but are yet to be attached to it.
+func GatewayAPIGatewayToTLSRoute(gwName string, namespace string, key string) ([]string, bool) {
+	if lib.GetDynamicInformers().TLSRouteInformer == nil {
+		return nil, false
+	}
+	gwNSName := namespace + "/" + gwName
+	routes := gatewayRoutesOfKind(lib.TLSRoute, []string{gwNSName})
+	routes = appendUnique(routes, routesForParentGateways(lib.TLSRoute, key, []string{gwNSName})...)
+	utils.AviLog.Debugf("key: %s, msg: TLSRoutes retrieved for gateway %s: %s", key, gwNSName, routes)
+	return routes, len(routes) != 0
+}
+
+func GatewayAPIGatewayClassToTLSRoute(gwClassName string, namespace string, key string) ([]string, bool) {
+	if lib.GetDynamicInformers().TLSRouteInformer == nil {
+		return nil, false
+	}
+	gateways := gatewaysForGatewayClass(key, gwClassName)
+	_, storedGateways := objects.GatewayAPIObjLister().GetGatewayClassToGateways(gwClassName)
+	gateways = appendUnique(gateways, storedGateways...)
+
+	routes := gatewayRoutesOfKind(lib.TLSRoute, gateways)
+	routes = appendUnique(routes, routesForParentGateways(lib.TLSRoute, key, gateways)...)
+	utils.AviLog.Debugf("key: %s, msg: TLSRoutes retrieved for gatewayclass %s: %s", key, gwClassName, routes)
+	return routes, len(routes) != 0
+}
+
+func AviSettingToTLSRoute(infraSettingName string, namespace string, key string) ([]string, bool) {
+	var routes []string
+	for _, gwClassName := range gatewayClassesForInfraSetting(key, infraSettingName) {
+		gwClassRoutes, _ := GatewayAPIGatewayClassToTLSRoute(gwClassName, namespace, key)
+		routes = appendUnique(routes, gwClassRoutes...)
+	}
+	return routes, len(routes) != 0
+}
+
+func TCPRouteChanges(routeName string, namespace string, key string) ([]string, bool) {
+	return l4RouteChanges(lib.TCPRoute, routeName, namespace, key)
+}
+
+func UDPRouteChanges(routeName string, namespace string, key string) ([]string, bool) {
+	return l4RouteChanges(lib.UDPRoute, routeName, namespace, key)
+}
+
+// l4RouteChanges returns the Gateways the TCPRoute or UDPRoute was attached to, along with the Gateways
+// currently referred by the route as parents.
+func l4RouteChanges(kind, routeName, namespace, key string) ([]string, bool) {
+	routeKey := lib.GetGatewayAPIRouteKey(kind, namespace, routeName)
+	_, gateways := objects.GatewayAPIObjLister().GetRouteToGateways(routeKey)
+	gateways = appendUnique([]string{}, gateways...)
+	route, err := getGatewayAPIL4Route(kind, namespace, routeName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: getting %s with name: %s", key, kind, routeName)
+		// Detect a delete condition here.
+		if k8serrors.IsNotFound(err) {
+			objects.GatewayAPIObjLister().DeleteRouteToGateways(routeKey)
+		}
+		return gateways, len(gateways) != 0
+	}
+	for _, parentRef := range route.parentRefs {
+		if !lib.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gateways = appendUnique(gateways, lib.GetParentGatewayNamespace(parentRef, namespace)+"/"+string(parentRef.Name))
+	}
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved for %s %s: %s", key, kind, routeName, gateways)
+	return gateways, len(gateways) != 0
+}
+
+// SvcToGatewayAPIGateway returns the Gateways with a TCP or UDP listener backed by the Service.
+func SvcToGatewayAPIGateway(svcName string, namespace string, key string) ([]string, bool) {
+	_, gateways := objects.GatewayAPIObjLister().GetServiceToL4Gateways(namespace + "/" + svcName)
+	if len(gateways) == 0 {
+		return nil, false
+	}
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved for service %s: %s", key, svcName, gateways)
+	return gateways, true
+}
+
+func GatewayAPIGatewayToL4Gateway(gwName string, namespace string, key string) ([]string, bool) {
+	return []string{namespace + "/" + gwName}, true
+}
+
+func GatewayAPIGatewayClassToL4Gateway(gwClassName string, namespace string, key string) ([]string, bool) {
+	gateways := gatewaysForGatewayClass(key, gwClassName)
+	_, storedGateways := objects.GatewayAPIObjLister().GetGatewayClassToGateways(gwClassName)
+	gateways = appendUnique(gateways, storedGateways...)
+	return gateways, len(gateways) != 0
+}
+
+func AviSettingToGatewayAPIGateway(infraSettingName string, namespace string, key string) ([]string, bool) {
+	var gateways []string
+	for _, gwClassName := range gatewayClassesForInfraSetting(key, infraSettingName) {
+		gwClassGateways, _ := GatewayAPIGatewayClassToL4Gateway(gwClassName, namespace, key)
+		gateways = appendUnique(gateways, gwClassGateways...)
+	}
+	return gateways, len(gateways) != 0
+}
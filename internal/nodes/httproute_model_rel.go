@@ -0,0 +1,237 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+)
+
+// The functions in this file return the HTTPRoutes affected by a change, in namespace/name format.
+
+func HTTPRouteChanges(routeName string, namespace string, key string) ([]string, bool) {
+	routeNSName := namespace + "/" + routeName
+	routeObj, err := lib.GetHTTPRoute(namespace, routeName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: getting HTTPRoute with name: %s", key, routeName)
+		// Detect a delete condition here.
+		if k8serrors.IsNotFound(err) {
+			objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).RemoveIngressMappings(routeName)
+			objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).RemoveIngressSecretMappings(routeName)
+			objects.GatewayAPIObjLister().DeleteRouteToGateways(lib.GetGatewayAPIRouteKey(lib.HTTPRoute, namespace, routeName))
+		}
+		return []string{routeNSName}, true
+	}
+
+	_, oldSvcs := objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).GetIngToSvc(routeName)
+	currSvcs := parseServicesForHTTPRoute(routeObj, key)
+	for _, svc := range lib.Difference(oldSvcs, currSvcs) {
+		utils.AviLog.Debugf("key: %s, msg: removing HTTPRoute relationship for service: %s", key, svc)
+		objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).RemoveSvcFromIngressMappings(routeName, svc)
+	}
+	for _, svc := range lib.Difference(currSvcs, oldSvcs) {
+		utils.AviLog.Debugf("key: %s, msg: updating HTTPRoute relationship for service: %s", key, svc)
+		objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).UpdateIngressMappings(routeName, svc)
+	}
+	return []string{routeNSName}, true
+}
+
+// parseServicesForHTTPRoute returns the core Services in the namespace of the HTTPRoute, referred by the backendRefs.
+func parseServicesForHTTPRoute(route *gatewayv1.HTTPRoute, key string) []string {
+	var services []string
+	for _, rule := range route.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			if !isServiceBackendRef(backendRef.BackendObjectReference) {
+				continue
+			}
+			if backendRef.Namespace != nil && string(*backendRef.Namespace) != route.Namespace {
+				continue
+			}
+			if !utils.HasElem(services, string(backendRef.Name)) {
+				services = append(services, string(backendRef.Name))
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: total services retrieved from HTTPRoute: %s", key, services)
+	return services
+}
+
+func isServiceBackendRef(backendRef gatewayv1.BackendObjectReference) bool {
+	if backendRef.Group != nil && *backendRef.Group != "" {
+		return false
+	}
+	if backendRef.Kind != nil && *backendRef.Kind != utils.Service {
+		return false
+	}
+	return true
+}
+
+func SvcToHTTPRoute(svcName string, namespace string, key string) ([]string, bool) {
+	_, routes := objects.SharedHTTPRouteSvcLister().IngressMappings(namespace).GetSvcToIng(svcName)
+	if len(routes) == 0 {
+		return nil, false
+	}
+	var routeNSNames []string
+	for _, route := range routes {
+		routeNSNames = append(routeNSNames, namespace+"/"+route)
+	}
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved for service %s: %s", key, svcName, routeNSNames)
+	return routeNSNames, true
+}
+
+func SecretToHTTPRoute(secretName string, namespace string, key string) ([]string, bool) {
+	_, gateways := objects.GatewayAPIObjLister().GetSecretToGateways(namespace + "/" + secretName)
+	routes := gatewayRoutesOfKind(lib.HTTPRoute, gateways)
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved for secret %s: %s", key, secretName, routes)
+	return routes, len(routes) != 0
+}
+
+// GatewayAPIGatewayToHTTPRoute returns the HTTPRoutes attached to the Gateway, along with the HTTPRoutes
+// which refer to the Gateway as parent but are yet to be attached to it.
+func GatewayAPIGatewayToHTTPRoute(gwName string, namespace string, key string) ([]string, bool) {
+	gwNSName := namespace + "/" + gwName
+	routes := gatewayRoutesOfKind(lib.HTTPRoute, []string{gwNSName})
+	routes = appendUnique(routes, routesForParentGateways(lib.HTTPRoute, key, []string{gwNSName})...)
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved for gateway %s: %s", key, gwNSName, routes)
+	return routes, len(routes) != 0
+}
+
+func GatewayAPIGatewayClassToHTTPRoute(gwClassName string, namespace string, key string) ([]string, bool) {
+	gateways := gatewaysForGatewayClass(key, gwClassName)
+	_, storedGateways := objects.GatewayAPIObjLister().GetGatewayClassToGateways(gwClassName)
+	gateways = appendUnique(gateways, storedGateways...)
+
+	routes := gatewayRoutesOfKind(lib.HTTPRoute, gateways)
+	routes = appendUnique(routes, routesForParentGateways(lib.HTTPRoute, key, gateways)...)
+	utils.AviLog.Debugf("key: %s, msg: HTTPRoutes retrieved for gatewayclass %s: %s", key, gwClassName, routes)
+	return routes, len(routes) != 0
+}
+
+func AviSettingToHTTPRoute(infraSettingName string, namespace string, key string) ([]string, bool) {
+	var routes []string
+	for _, gwClassName := range gatewayClassesForInfraSetting(key, infraSettingName) {
+		gwClassRoutes, _ := GatewayAPIGatewayClassToHTTPRoute(gwClassName, namespace, key)
+		routes = appendUnique(routes, gwClassRoutes...)
+	}
+	return routes, len(routes) != 0
+}
+
+// gatewayClassesForInfraSetting lists the GatewayClasses which refer to the AviInfraSetting as parameters.
+func gatewayClassesForInfraSetting(key, infraSettingName string) []string {
+	gwClasses, err := lib.GetDynamicInformers().GatewayClassInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Unable to list GatewayClasses: %v", key, err)
+		return nil
+	}
+	var gwClassNames []string
+	for _, obj := range gwClasses {
+		gwClass := &gatewayv1.GatewayClass{}
+		if err := lib.ConvertUnstructuredObj(obj, gwClass); err != nil {
+			continue
+		}
+		paramsRef := gwClass.Spec.ParametersRef
+		if paramsRef == nil || string(paramsRef.Kind) != lib.AviInfraSetting || paramsRef.Name != infraSettingName {
+			continue
+		}
+		gwClassNames = append(gwClassNames, gwClass.Name)
+	}
+	return gwClassNames
+}
+
+func gatewaysForGatewayClass(key, gwClassName string) []string {
+	gwObjs, err := lib.GetDynamicInformers().GatewayInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Unable to list Gateways: %v", key, err)
+		return nil
+	}
+	var gateways []string
+	for _, obj := range gwObjs {
+		gw := &gatewayv1.Gateway{}
+		if err := lib.ConvertUnstructuredObj(obj, gw); err != nil {
+			continue
+		}
+		if string(gw.Spec.GatewayClassName) == gwClassName {
+			gateways = append(gateways, gw.Namespace+"/"+gw.Name)
+		}
+	}
+	return gateways
+}
+
+// gatewayRoutesOfKind returns the routes of the given kind attached to any of the gateways, in namespace/name format.
+func gatewayRoutesOfKind(kind string, gateways []string) []string {
+	var routes []string
+	for _, gwNSName := range gateways {
+		_, gwRoutes := objects.GatewayAPIObjLister().GetGatewayToRoutes(gwNSName)
+		for _, routeKey := range gwRoutes {
+			routeKind, namespace, name := lib.ParseGatewayAPIRouteKey(routeKey)
+			if routeKind == kind {
+				routes = appendUnique(routes, namespace+"/"+name)
+			}
+		}
+	}
+	return routes
+}
+
+// routesForParentGateways lists the routes of the given kind which refer to any of the gateways as a parent.
+func routesForParentGateways(kind, key string, gateways []string) []string {
+	informer := lib.GetGatewayAPIRouteInformer(kind)
+	if len(gateways) == 0 || informer == nil {
+		return nil
+	}
+	routeObjs, err := informer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Unable to list %ss: %v", key, kind, err)
+		return nil
+	}
+	var routes []string
+	for _, obj := range routeObjs {
+		// All the route kinds share the CommonRouteSpec, which is sufficient to find the parents.
+		route := &struct {
+			metav1.ObjectMeta `json:"metadata,omitempty"`
+			Spec              struct {
+				gatewayv1.CommonRouteSpec `json:",inline"`
+			} `json:"spec"`
+		}{}
+		if err := lib.ConvertUnstructuredObj(obj, route); err != nil {
+			continue
+		}
+		for _, parentRef := range route.Spec.ParentRefs {
+			if !lib.IsGatewayParentRef(parentRef) {
+				continue
+			}
+			gwNSName := lib.GetParentGatewayNamespace(parentRef, route.Namespace) + "/" + string(parentRef.Name)
+			if utils.HasElem(gateways, gwNSName) {
+				routes = appendUnique(routes, route.Namespace+"/"+route.Name)
+				break
+			}
+		}
+	}
+	return routes
+}
+
+func appendUnique(list []string, elems ...string) []string {
+	for _, elem := range elems {
+		if !utils.HasElem(list, elem) {
+			list = append(list, elem)
+		}
+	}
+	return list
+}
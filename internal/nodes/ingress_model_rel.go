@@ -44,6 +44,9 @@ var (
 		GetParentRoutes:                SvcToRoute,
 		GetParentGateways:              SvcToGateway,
 		GetParentMultiClusterIngresses: SvcToMultiClusterIng,
+		GetParentHTTPRoutes:            SvcToHTTPRoute,
+		GetParentTLSRoutes:             SvcToTLSRoute,
+		GetParentGatewayAPIGateways:    SvcToGatewayAPIGateway,
 	}
 	SharedVipService = GraphSchema{
 		Type:              "SharedVipService",
@@ -58,10 +61,13 @@ var (
 		GetParentIngresses: IngClassToIng,
 	}
 	Endpoint = GraphSchema{
-		Type:               "Endpoints",
-		GetParentIngresses: EPToIng,
-		GetParentRoutes:    EPToRoute,
-		GetParentGateways:  EPToGateway,
+		Type:                        "Endpoints",
+		GetParentIngresses:          EPToIng,
+		GetParentRoutes:             EPToRoute,
+		GetParentGateways:           EPToGateway,
+		GetParentHTTPRoutes:         SvcToHTTPRoute,
+		GetParentTLSRoutes:          SvcToTLSRoute,
+		GetParentGatewayAPIGateways: SvcToGatewayAPIGateway,
 	}
 	Pod = GraphSchema{
 		Type:               "Pod",
@@ -78,6 +84,7 @@ var (
 		GetParentRoutes:                SecretToRoute,
 		GetParentGateways:              SecretToGateway,
 		GetParentMultiClusterIngresses: SecretToMultiClusterIng,
+		GetParentHTTPRoutes:            SecretToHTTPRoute,
 	}
 	Route = GraphSchema{
 		Type:            utils.OshiftRoute,
@@ -102,11 +109,14 @@ var (
 		GetParentGateways: GWClassToGateway,
 	}
 	AviInfraSetting = GraphSchema{
-		Type:               "AviInfraSetting",
-		GetParentIngresses: AviSettingToIng,
-		GetParentGateways:  AviSettingToGateway,
-		GetParentServices:  AviSettingToSvc,
-		GetParentRoutes:    AviSettingToRoute,
+		Type:                        "AviInfraSetting",
+		GetParentIngresses:          AviSettingToIng,
+		GetParentGateways:           AviSettingToGateway,
+		GetParentServices:           AviSettingToSvc,
+		GetParentRoutes:             AviSettingToRoute,
+		GetParentHTTPRoutes:         AviSettingToHTTPRoute,
+		GetParentTLSRoutes:          AviSettingToTLSRoute,
+		GetParentGatewayAPIGateways: AviSettingToGatewayAPIGateway,
 	}
 	MultiClusterIngress = GraphSchema{
 		Type:                           lib.MultiClusterIngress,
@@ -116,6 +126,38 @@ var (
 		Type:                           lib.ServiceImport,
 		GetParentMultiClusterIngresses: ServiceImportToMultiClusterIng,
 	}
+	HTTPRoute = GraphSchema{
+		Type:                lib.HTTPRoute,
+		GetParentHTTPRoutes: HTTPRouteChanges,
+	}
+	GatewayAPIGateway = GraphSchema{
+		Type:                        lib.GatewayAPIGateway,
+		GetParentHTTPRoutes:         GatewayAPIGatewayToHTTPRoute,
+		GetParentTLSRoutes:          GatewayAPIGatewayToTLSRoute,
+		GetParentGatewayAPIGateways: GatewayAPIGatewayToL4Gateway,
+	}
+	GatewayAPIGatewayClass = GraphSchema{
+		Type:                        lib.GatewayAPIGatewayClass,
+		GetParentHTTPRoutes:         GatewayAPIGatewayClassToHTTPRoute,
+		GetParentTLSRoutes:          GatewayAPIGatewayClassToTLSRoute,
+		GetParentGatewayAPIGateways: GatewayAPIGatewayClassToL4Gateway,
+	}
+	TLSRoute = GraphSchema{
+		Type:               lib.TLSRoute,
+		GetParentTLSRoutes: TLSRouteChanges,
+	}
+	TCPRoute = GraphSchema{
+		Type:                        lib.TCPRoute,
+		GetParentGatewayAPIGateways: TCPRouteChanges,
+	}
+	UDPRoute = GraphSchema{
+		Type:                        lib.UDPRoute,
+		GetParentGatewayAPIGateways: UDPRouteChanges,
+	}
+	ReferenceGrant = GraphSchema{
+		Type:               lib.ReferenceGrant,
+		GetParentIngresses: ReferenceGrantToIng,
+	}
 	SupportedGraphTypes = GraphDescriptor{
 		Ingress,
 		IngressClass,
@@ -133,6 +175,13 @@ var (
 		AviInfraSetting,
 		MultiClusterIngress,
 		ServiceImport,
+		HTTPRoute,
+		GatewayAPIGateway,
+		GatewayAPIGatewayClass,
+		TLSRoute,
+		TCPRoute,
+		UDPRoute,
+		ReferenceGrant,
 	}
 )
 
@@ -143,6 +192,9 @@ type GraphSchema struct {
 	GetParentGateways              func(string, string, string) ([]string, bool)
 	GetParentServices              func(string, string, string) ([]string, bool)
 	GetParentMultiClusterIngresses func(string, string, string) ([]string, bool)
+	GetParentHTTPRoutes            func(string, string, string) ([]string, bool)
+	GetParentTLSRoutes             func(string, string, string) ([]string, bool)
+	GetParentGatewayAPIGateways    func(string, string, string) ([]string, bool)
 }
 
 type GraphDescriptor []GraphSchema
@@ -310,6 +362,7 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 		if k8serrors.IsNotFound(err) {
 			// Remove all the Ingress to Services mapping.
 			// Remove the references of this ingress from the Services
+			removeCrossNamespaceIngressMappings(ingName, namespace, key)
 			svcToDel := objects.SharedSvcLister().IngressMappings(namespace).RemoveIngressMappings(ingName)
 			if lib.AutoAnnotateNPLSvc() {
 				for _, svc := range svcToDel {
@@ -349,6 +402,7 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 		// If the Ingress Class is not found or is not valid, then return.
 		// When the correct Ingress Class is added, then the Ingress would be processed again.
 		if !lib.ValidateIngressForClass(key, ingObj) {
+			removeCrossNamespaceIngressMappings(ingName, namespace, key)
 			svcToDel := objects.SharedSvcLister().IngressMappings(namespace).RemoveIngressMappings(ingName)
 			if lib.AutoAnnotateNPLSvc() {
 				for _, svc := range svcToDel {
@@ -366,10 +420,25 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 		}
 
 		_, oldSvcs := objects.SharedSvcLister().IngressMappings(namespace).GetIngToSvc(ingName)
-		currSvcs := parseServicesForIngress(ingObj.Spec, key)
+		currSvcs := parseServicesForIngress(ingObj.Spec, ingObj.Annotations, namespace, key)
 
 		svcToDel := lib.Difference(oldSvcs, currSvcs)
 		for _, svc := range svcToDel {
+			if svcNS, svcName := utils.ExtractNamespaceObjectName(svc); svcNS != "" {
+				// Service in a different namespace, referred through the backend namespaces annotation.
+				ingrforSvc := objects.SharedSvcLister().RemoveCrossNamespaceIngressMappings(namespace, ingName, svcNS, svcName)
+				if lib.AutoAnnotateNPLSvc() && len(ingrforSvc) == 0 {
+					statusOption := status.StatusOptions{
+						ObjType:   lib.NPLService,
+						Op:        lib.DeleteStatus,
+						ObjName:   svcName,
+						Namespace: svcNS,
+						Key:       key,
+					}
+					status.PublishToStatusQueue(svcName, statusOption)
+				}
+				continue
+			}
 			_, ingrforSvc := objects.SharedSvcLister().IngressMappings(namespace).GetSvcToIng(svc)
 			ingrforSvc = utils.Remove(ingrforSvc, ingName)
 			if lib.AutoAnnotateNPLSvc() && len(ingrforSvc) == 0 {
@@ -388,18 +457,24 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 		svcToAdd := lib.Difference(currSvcs, oldSvcs)
 		for _, svc := range svcToAdd {
 			utils.AviLog.Debugf("key: %s, msg: updating ingress relationship for service:  %s", key, svc)
-			objects.SharedSvcLister().IngressMappings(namespace).UpdateIngressMappings(ingName, svc)
+			svcNS, svcName := utils.ExtractNamespaceObjectName(svc)
+			if svcNS != "" {
+				objects.SharedSvcLister().UpdateCrossNamespaceIngressMappings(namespace, ingName, svcNS, svcName)
+			} else {
+				svcNS, svcName = namespace, svc
+				objects.SharedSvcLister().IngressMappings(namespace).UpdateIngressMappings(ingName, svc)
+			}
 			// Check and update NPl annotation for svc
 			if lib.AutoAnnotateNPLSvc() {
-				if !status.CheckNPLSvcAnnotation(key, namespace, svc) {
+				if !status.CheckNPLSvcAnnotation(key, svcNS, svcName) {
 					statusOption := status.StatusOptions{
 						ObjType:   lib.NPLService,
 						Op:        lib.UpdateStatus,
-						ObjName:   svc,
-						Namespace: namespace,
+						ObjName:   svcName,
+						Namespace: svcNS,
 						Key:       key,
 					}
-					status.PublishToStatusQueue(svc, statusOption)
+					status.PublishToStatusQueue(svcName, statusOption)
 				}
 			}
 		}
@@ -414,6 +489,28 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 	return ingresses, true
 }
 
+// removeCrossNamespaceIngressMappings removes the mappings of the ingress with the services in other namespaces.
+func removeCrossNamespaceIngressMappings(ingName, namespace, key string) {
+	_, svcs := objects.SharedSvcLister().IngressMappings(namespace).GetIngToSvc(ingName)
+	for _, svc := range svcs {
+		svcNS, svcName := utils.ExtractNamespaceObjectName(svc)
+		if svcNS == "" {
+			continue
+		}
+		ingrforSvc := objects.SharedSvcLister().RemoveCrossNamespaceIngressMappings(namespace, ingName, svcNS, svcName)
+		if lib.AutoAnnotateNPLSvc() && len(ingrforSvc) == 0 {
+			statusOption := status.StatusOptions{
+				ObjType:   lib.NPLService,
+				Op:        lib.DeleteStatus,
+				ObjName:   svcName,
+				Namespace: svcNS,
+				Key:       key,
+			}
+			status.PublishToStatusQueue(svcName, statusOption)
+		}
+	}
+}
+
 func IngClassToIng(ingClassName string, namespace string, key string) ([]string, bool) {
 	found, ingresses := objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).GetClassToIng(ingClassName)
 	// Go through the list of ingresses again to populate the ingress Service mapping and annotate services if needed
@@ -424,6 +521,14 @@ func IngClassToIng(ingClassName string, namespace string, key string) ([]string,
 	return ingresses, found
 }
 
+// ReferenceGrantToIng returns the ingresses from other namespaces, in namespace/name format, which refer
+// to the services in the namespace of the ReferenceGrant.
+func ReferenceGrantToIng(grantName string, namespace string, key string) ([]string, bool) {
+	ingresses := objects.SharedSvcLister().IngressMappings(namespace).GetCrossNamespaceIngresses()
+	utils.AviLog.Debugf("key: %s, msg: ingresses retrieved for ReferenceGrant %s: %s", key, grantName, ingresses)
+	return ingresses, len(ingresses) != 0
+}
+
 func SvcToIng(svcName string, namespace string, key string) ([]string, bool) {
 	svc, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(svcName)
 	if err != nil {
@@ -725,10 +830,37 @@ func HTTPRuleToIng(rrname string, namespace string, key string) ([]string, bool)
 		}
 	}
 
+	if httprule != nil && err == nil {
+		updateHTTPRuleMatchServiceMappings(httprule, allIngresses, key)
+	}
+
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, allIngresses)
 	return allIngresses, true
 }
 
+// updateHTTPRuleMatchServiceMappings maps the backend services of the HTTPRule matches to the ingresses in the
+// namespace of the HTTPRule, so that the ingresses are processed on changes to the services and their endpoints.
+func updateHTTPRuleMatchServiceMappings(httprule *akov1alpha1.HTTPRule, ingresses []string, key string) {
+	if utils.GetInformers().IngressInformer == nil {
+		return
+	}
+	for _, ingress := range ingresses {
+		ns, ingName := utils.ExtractNamespaceObjectName(ingress)
+		if ns != httprule.Namespace {
+			continue
+		}
+		if _, err := utils.GetInformers().IngressInformer.Lister().Ingresses(ns).Get(ingName); err != nil {
+			continue
+		}
+		for _, path := range httprule.Spec.Paths {
+			for _, match := range path.Matches {
+				utils.AviLog.Debugf("key: %s, msg: updating ingress relationship for service: %s", key, match.Backend.Service)
+				objects.SharedSvcLister().IngressMappings(ns).UpdateIngressMappings(ingName, match.Backend.Service)
+			}
+		}
+	}
+}
+
 func AviSettingToIng(infraSettingName, namespace, key string) ([]string, bool) {
 	allIngresses := make([]string, 0)
 
@@ -840,7 +972,7 @@ func ServiceChanges(serviceName, namespace, key string) ([]string, bool) {
 			objects.SharedlbLister().RemoveSharedVipKeyServiceMappings(serviceNamespaceName)
 		}
 
-		if currentKey, ok := serviceObj.Annotations[lib.SharedVipSvcLBAnnotation]; ok {
+		if currentKey, ok := serviceObj.Annotations[lib.SharedVipSvcLBAnnotation]; ok && lib.IsServiceLBClassAccepted(serviceObj) {
 			if currentKey != oldKey {
 				vipKeys = append(vipKeys, serviceObj.Namespace+"/"+currentKey)
 			}
@@ -850,16 +982,36 @@ func ServiceChanges(serviceName, namespace, key string) ([]string, bool) {
 	return vipKeys, true
 }
 
-func parseServicesForIngress(ingSpec networkingv1.IngressSpec, key string) []string {
-	// Figure out the service names that are part of this ingress
+func parseServicesForIngress(ingSpec networkingv1.IngressSpec, annotations map[string]string, namespace, key string) []string {
+	// Figure out the service names that are part of this ingress, the services in other namespaces
+	// are returned in namespace/name format.
 	var services []string
+	backendNamespaces := lib.GetIngressBackendNamespaces(annotations)
 	for _, rule := range ingSpec.Rules {
 		if rule.IngressRuleValue.HTTP != nil {
 			for _, path := range rule.IngressRuleValue.HTTP.Paths {
-				services = append(services, path.Backend.Service.Name)
+				svcName := path.Backend.Service.Name
+				if svcNS, ok := backendNamespaces[svcName]; ok && svcNS != "" && svcNS != namespace {
+					svcName = svcNS + "/" + svcName
+				}
+				services = append(services, svcName)
 			}
 		}
 	}
+	if ingSpec.DefaultBackend != nil && ingSpec.DefaultBackend.Service != nil {
+		svcName := ingSpec.DefaultBackend.Service.Name
+		if svcNS, ok := backendNamespaces[svcName]; ok && svcNS != "" && svcNS != namespace {
+			svcName = svcNS + "/" + svcName
+		}
+		if !utils.HasElem(services, svcName) {
+			services = append(services, svcName)
+		}
+	}
+	for _, svcName := range getHTTPRuleMatchServices(ingSpec, namespace, key) {
+		if !utils.HasElem(services, svcName) {
+			services = append(services, svcName)
+		}
+	}
 	utils.AviLog.Debugf("key: %s, msg: total services retrieved from corev1: %s", key, services)
 	return services
 }
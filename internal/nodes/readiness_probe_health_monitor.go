@@ -0,0 +1,273 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	// The defaults of the readiness probe fields, as set by Kubernetes.
+	defaultProbePeriodSeconds    = 10
+	defaultProbeTimeoutSeconds   = 1
+	defaultProbeSuccessThreshold = 1
+	defaultProbeFailureThreshold = 3
+
+	// The limits of the health monitor fields.
+	maxHealthMonitorSendInterval   = 3600
+	maxHealthMonitorReceiveTimeout = 2400
+	maxHealthMonitorChecks         = 50
+
+	healthMonitorTypeHTTP  = "HEALTH_MONITOR_HTTP"
+	healthMonitorTypeHTTPS = "HEALTH_MONITOR_HTTPS"
+	healthMonitorTypeTCP   = "HEALTH_MONITOR_TCP"
+)
+
+// buildReadinessProbeHealthMonitor returns the health monitor of the pool, which is derived from the readiness probe
+// of the container that serves the target port of the pool, in the Pods of the Service. The most recently created
+// Pod is used, so that the probe of the new Pods is used during a rolling update. The health monitor is not built if
+// the probe is not an HTTP, TCP or gRPC probe, or if the probe port differs from the target port while the servers
+// are not the Pods, which is the case in NodePort and NodePortLocal modes.
+func buildReadinessProbeHealthMonitor(poolNode *AviPoolNode, ns, serviceName string, podServers bool, key string) *AviHealthMonitorNode {
+	if !lib.IsReadinessProbeHealthMonitorEnabled() || utils.GetInformers().PodInformer == nil ||
+		poolNode.Protocol == utils.UDP || poolNode.Protocol == utils.SCTP {
+		return nil
+	}
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(serviceName)
+	if err != nil || len(svcObj.Spec.Selector) == 0 {
+		return nil
+	}
+	pods, err := utils.GetInformers().PodInformer.Lister().Pods(ns).List(labels.SelectorFromSet(svcObj.Spec.Selector))
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the Pods of Service %s: %v", key, serviceName, err)
+		return nil
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	targetPort := getPoolTargetPort(poolNode, svcObj)
+	for _, pod := range pods {
+		container, containerPort, found := getTargetContainer(pod, targetPort)
+		if !found {
+			continue
+		}
+		if container.ReadinessProbe == nil {
+			return nil
+		}
+		return healthMonitorFromProbe(poolNode, svcObj, pod, container, containerPort, podServers, key)
+	}
+	return nil
+}
+
+// getPoolTargetPort returns the target port of the pool, which is the target port of the Service port of the pool,
+// if it is not set on the pool.
+func getPoolTargetPort(poolNode *AviPoolNode, svcObj *corev1.Service) intstr.IntOrString {
+	if poolNode.TargetPort.Type == intstr.String || poolNode.TargetPort.IntValue() != 0 {
+		return poolNode.TargetPort
+	}
+	for _, port := range svcObj.Spec.Ports {
+		if port.Name != poolNode.PortName && len(svcObj.Spec.Ports) != 1 {
+			continue
+		}
+		if port.TargetPort.Type == intstr.String || port.TargetPort.IntValue() != 0 {
+			return port.TargetPort
+		}
+		return intstr.FromInt(int(port.Port))
+	}
+	return poolNode.TargetPort
+}
+
+// getTargetContainer returns the container of the Pod which serves the target port, and the port number. A Pod with
+// a single container is assumed to serve a numeric target port, which the container does not declare.
+func getTargetContainer(pod *corev1.Pod, targetPort intstr.IntOrString) (*corev1.Container, int32, bool) {
+	for i := range pod.Spec.Containers {
+		for _, port := range pod.Spec.Containers[i].Ports {
+			if (targetPort.Type == intstr.Int && port.ContainerPort == targetPort.IntVal) ||
+				(targetPort.Type == intstr.String && port.Name == targetPort.StrVal) {
+				return &pod.Spec.Containers[i], port.ContainerPort, true
+			}
+		}
+	}
+	if targetPort.Type == intstr.Int && targetPort.IntValue() != 0 && len(pod.Spec.Containers) == 1 {
+		return &pod.Spec.Containers[0], targetPort.IntVal, true
+	}
+	return nil, 0, false
+}
+
+// getProbePort returns the port number of the probe port, which can be the name of a port of the container.
+func getProbePort(port intstr.IntOrString, container *corev1.Container) (int32, bool) {
+	if port.Type == intstr.Int {
+		return port.IntVal, true
+	}
+	for _, containerPort := range container.Ports {
+		if containerPort.Name == port.StrVal {
+			return containerPort.ContainerPort, true
+		}
+	}
+	return 0, false
+}
+
+// getGRPCProbePort returns the port of the readiness probe of the container, if it is a gRPC probe. The gRPC probes
+// are not decoded into the Pods of the Pod informer by this version of the Kubernetes API, hence the probe is read
+// from the Pod cached by the dynamic Pod informer, which keeps the Pods unstructured.
+func getGRPCProbePort(pod *corev1.Pod, containerName, key string) (int32, bool) {
+	dynamicInformers := lib.GetDynamicInformers()
+	if dynamicInformers == nil || dynamicInformers.PodInformer == nil {
+		return 0, false
+	}
+	obj, err := dynamicInformers.PodInformer.Lister().ByNamespace(pod.Namespace).Get(pod.Name)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: unable to get Pod %s/%s for its readiness probe: %v", key, pod.Namespace, pod.Name, err)
+		return 0, false
+	}
+	podObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return 0, false
+	}
+	containers, _, _ := unstructured.NestedSlice(podObj.Object, "spec", "containers")
+	for _, container := range containers {
+		containerObj, ok := container.(map[string]interface{})
+		if !ok || containerObj["name"] != containerName {
+			continue
+		}
+		grpcProbe, found, err := unstructured.NestedMap(containerObj, "readinessProbe", "grpc")
+		if err != nil || !found {
+			return 0, false
+		}
+		port, found, err := unstructured.NestedInt64(grpcProbe, "port")
+		if err != nil || !found {
+			return 0, false
+		}
+		return int32(port), true
+	}
+	return 0, false
+}
+
+// getHTTPRequest returns the request sent by the health monitor for the HTTP probe, with the host and the headers
+// of the probe. The Service Engines add the Host header with the server address if the probe does not set the host,
+// as the kubelet does with the Pod IP.
+func getHTTPRequest(httpGet *corev1.HTTPGetAction) string {
+	path := httpGet.Path
+	if path == "" {
+		path = "/"
+	}
+	host := httpGet.Host
+	var headers []string
+	for _, header := range httpGet.HTTPHeaders {
+		if strings.EqualFold(header.Name, "Host") {
+			host = header.Value
+			continue
+		}
+		headers = append(headers, header.Name+": "+header.Value)
+	}
+	request := []string{"GET " + path + " HTTP/1.0"}
+	if host != "" {
+		request = append(request, "Host: "+host)
+	}
+	return strings.Join(append(request, headers...), "\r\n")
+}
+
+func healthMonitorFromProbe(poolNode *AviPoolNode, svcObj *corev1.Service, pod *corev1.Pod, container *corev1.Container, containerPort int32, podServers bool, key string) *AviHealthMonitorNode {
+	probe := container.ReadinessProbe
+	healthMonitor := &AviHealthMonitorNode{
+		Name:       lib.GetReadinessProbeHealthMonitorName(poolNode.Name),
+		Tenant:     poolNode.Tenant,
+		AviMarkers: lib.PopulateL4VSNodeMarkers(svcObj.Namespace, svcObj.Name),
+	}
+	if healthMonitor.Tenant == "" {
+		healthMonitor.Tenant = lib.GetTenant()
+	}
+
+	var probePort intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		probePort = probe.HTTPGet.Port
+		healthMonitor.Type = healthMonitorTypeHTTP
+		if probe.HTTPGet.Scheme == corev1.URISchemeHTTPS {
+			healthMonitor.Type = healthMonitorTypeHTTPS
+		}
+		healthMonitor.HTTPRequest = getHTTPRequest(probe.HTTPGet)
+		// The readiness probe succeeds on any status code from 200 to 399.
+		healthMonitor.ResponseCodes = []string{"HTTP_2XX", "HTTP_3XX"}
+	case probe.TCPSocket != nil:
+		probePort = probe.TCPSocket.Port
+		healthMonitor.Type = healthMonitorTypeTCP
+	case probe.Exec != nil:
+		utils.AviLog.Debugf("key: %s, msg: readiness probe of container %s is not an HTTP, TCP or gRPC probe", key, container.Name)
+		return nil
+	default:
+		// The Service Engines check that the gRPC port accepts connections, as the health monitors do not
+		// support the gRPC health checks.
+		grpcPort, found := getGRPCProbePort(pod, container.Name, key)
+		if !found {
+			utils.AviLog.Debugf("key: %s, msg: readiness probe of container %s is not an HTTP, TCP or gRPC probe", key, container.Name)
+			return nil
+		}
+		probePort = intstr.FromInt(int(grpcPort))
+		healthMonitor.Type = healthMonitorTypeTCP
+	}
+	port, ok := getProbePort(probePort, container)
+	if !ok {
+		utils.AviLog.Warnf("key: %s, msg: port %s of the readiness probe of container %s is not found", key, probePort.String(), container.Name)
+		return nil
+	}
+	if port != containerPort {
+		if !podServers {
+			utils.AviLog.Warnf("key: %s, msg: readiness probe port %d of container %s is not reachable through the pool servers", key, port, container.Name)
+			return nil
+		}
+		healthMonitor.MonitorPort = port
+	}
+
+	healthMonitor.SendInterval = boundProbeValue(probe.PeriodSeconds, defaultProbePeriodSeconds, maxHealthMonitorSendInterval)
+	healthMonitor.ReceiveTimeout = boundProbeValue(probe.TimeoutSeconds, defaultProbeTimeoutSeconds, maxHealthMonitorReceiveTimeout)
+	// The receive timeout must be shorter than the send interval.
+	if healthMonitor.ReceiveTimeout >= healthMonitor.SendInterval {
+		if healthMonitor.SendInterval > 1 {
+			healthMonitor.ReceiveTimeout = healthMonitor.SendInterval - 1
+		} else {
+			healthMonitor.SendInterval = 2
+			healthMonitor.ReceiveTimeout = 1
+		}
+	}
+	healthMonitor.SuccessfulChecks = boundProbeValue(probe.SuccessThreshold, defaultProbeSuccessThreshold, maxHealthMonitorChecks)
+	healthMonitor.FailedChecks = boundProbeValue(probe.FailureThreshold, defaultProbeFailureThreshold, maxHealthMonitorChecks)
+	return healthMonitor
+}
+
+// boundProbeValue returns the value of a probe field, or its default if it is not set, capped at the maximum value
+// of the health monitor field.
+func boundProbeValue(value, defaultValue, maxValue int32) int32 {
+	if value <= 0 {
+		value = defaultValue
+	}
+	if value > maxValue {
+		value = maxValue
+	}
+	return value
+}
@@ -23,6 +23,9 @@ import (
 	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+	gatewayv1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1alpha2"
+
 	routev1 "github.com/openshift/api/route/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 )
@@ -74,6 +77,15 @@ type multiClusterIngressModel struct {
 	annotations map[string]string
 }
 
+// httpRouteModel : Model for gateway.networking.k8s.io HTTPRoutes with it's own service lister
+type httpRouteModel struct {
+	key          string
+	name         string
+	namespace    string
+	spec         *gatewayv1.HTTPRoute
+	infrasetting *akov1alpha1.AviInfraSetting
+}
+
 func GetOshiftRouteModel(name, namespace, key string) (*OshiftRouteModel, error, bool) {
 	routeModel := OshiftRouteModel{
 		key:       key,
@@ -386,3 +398,202 @@ func (mciModel *multiClusterIngressModel) GetAviInfraSetting() *akov1alpha1.AviI
 		},
 	}
 }
+
+func GetHTTPRouteModel(name, namespace, key string) (RouteIngressModel, error, bool) {
+	routeModel := &httpRouteModel{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+	processObj := utils.CheckIfNamespaceAccepted(namespace)
+
+	routeObj, err := lib.GetHTTPRoute(namespace, name)
+	if err != nil {
+		return routeModel, err, processObj
+	}
+	if routeObj.GetDeletionTimestamp() != nil {
+		return routeModel, err, false
+	}
+	routeModel.spec = routeObj
+	routeModel.infrasetting, err = getGatewayAPIRouteInfraSetting(key, namespace, routeObj.Spec.ParentRefs)
+	return routeModel, err, processObj
+}
+
+func (m *httpRouteModel) GetName() string {
+	return m.name
+}
+
+func (m *httpRouteModel) GetNamespace() string {
+	return m.namespace
+}
+
+func (m *httpRouteModel) GetAnnotations() map[string]string {
+	if m.spec == nil {
+		return nil
+	}
+	return m.spec.GetAnnotations()
+}
+
+func (m *httpRouteModel) GetType() string {
+	return lib.HTTPRoute
+}
+
+func (m *httpRouteModel) GetSvcLister() *objects.SvcLister {
+	return objects.SharedHTTPRouteSvcLister()
+}
+
+func (m *httpRouteModel) GetSpec() interface{} {
+	return m.spec
+}
+
+func (m *httpRouteModel) ParseHostPath() IngressConfig {
+	o := NewNodesValidator()
+	return o.ParseHostPathForHTTPRoute(m.namespace, m.name, m.spec, m.key)
+}
+
+func (m *httpRouteModel) Exists() bool {
+	return m.spec != nil
+}
+
+func (m *httpRouteModel) GetDiffPathSvc(storedPathSvc map[string][]string, currentPathSvc []IngressHostPathSvc, checkSvc bool) map[string][]string {
+	return getGatewayAPIRouteDiffPathSvc(storedPathSvc, currentPathSvc)
+}
+
+// getGatewayAPIRouteDiffPathSvc returns the stored paths and services which are not present in the current configuration.
+func getGatewayAPIRouteDiffPathSvc(storedPathSvc map[string][]string, currentPathSvc []IngressHostPathSvc) map[string][]string {
+	pathSvcCopy := make(map[string][]string)
+	for k, v := range storedPathSvc {
+		pathSvcCopy[k] = v
+	}
+	currPathSvcMap := make(map[string][]string)
+	for _, val := range currentPathSvc {
+		currPathSvcMap[val.Path] = append(currPathSvcMap[val.Path], val.ServiceName)
+	}
+	for path, services := range currPathSvcMap {
+		// like openshift routes, Gateway API route pool names carry the service name, so the service diff is always checked
+		storedServices, ok := pathSvcCopy[path]
+		if ok {
+			pathSvcCopy[path] = lib.Difference(storedServices, services)
+			if len(pathSvcCopy[path]) == 0 {
+				delete(pathSvcCopy, path)
+			}
+		}
+	}
+	return pathSvcCopy
+}
+
+func (m *httpRouteModel) GetAviInfraSetting() *akov1alpha1.AviInfraSetting {
+	return m.infrasetting.DeepCopy()
+}
+
+// getGatewayAPIRouteInfraSetting returns the AviInfraSetting referred via parametersRef of the GatewayClass
+// of the first AKO managed parent Gateway of the HTTPRoute or TLSRoute.
+func getGatewayAPIRouteInfraSetting(key, namespace string, parentRefs []gatewayv1.ParentReference) (*akov1alpha1.AviInfraSetting, error) {
+	for _, parentRef := range parentRefs {
+		if !lib.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gw, err := lib.GetGatewayAPIGateway(lib.GetParentGatewayNamespace(parentRef, namespace), string(parentRef.Name))
+		if err != nil || !lib.IsGatewayAPIGatewayValid(gw) {
+			continue
+		}
+		return getGatewayAPIGatewayInfraSetting(key, gw)
+	}
+	return nil, nil
+}
+
+// getGatewayAPIGatewayInfraSetting returns the AviInfraSetting referred via parametersRef of the GatewayClass of the Gateway.
+func getGatewayAPIGatewayInfraSetting(key string, gw *gatewayv1.Gateway) (*akov1alpha1.AviInfraSetting, error) {
+	gwClass, err := lib.GetGatewayAPIGatewayClass(string(gw.Spec.GatewayClassName))
+	if err != nil {
+		return nil, err
+	}
+	paramsRef := gwClass.Spec.ParametersRef
+	if paramsRef == nil || string(paramsRef.Group) != lib.AkoGroup || string(paramsRef.Kind) != lib.AviInfraSetting {
+		return nil, nil
+	}
+	infraSetting, err := lib.AKOControlConfig().CRDInformers().AviInfraSettingInformer.Lister().Get(paramsRef.Name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Unable to get corresponding AviInfraSetting via GatewayClass %s", key, err.Error())
+		return nil, err
+	}
+	if infraSetting.Status.Status != lib.StatusAccepted {
+		utils.AviLog.Warnf("key: %s, msg: Referred AviInfraSetting %s is invalid", key, infraSetting.Name)
+		return nil, fmt.Errorf("Referred AviInfraSetting %s is invalid", infraSetting.Name)
+	}
+	return infraSetting, nil
+}
+
+// tlsRouteModel : Model for gateway.networking.k8s.io TLSRoutes with it's own service lister, TLSRoutes
+// are handled as passthrough hosts.
+type tlsRouteModel struct {
+	key          string
+	name         string
+	namespace    string
+	spec         *gatewayv1alpha2.TLSRoute
+	infrasetting *akov1alpha1.AviInfraSetting
+}
+
+func GetTLSRouteModel(name, namespace, key string) (RouteIngressModel, error, bool) {
+	routeModel := &tlsRouteModel{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+	processObj := utils.CheckIfNamespaceAccepted(namespace)
+
+	routeObj, err := lib.GetTLSRoute(namespace, name)
+	if err != nil {
+		return routeModel, err, processObj
+	}
+	if routeObj.GetDeletionTimestamp() != nil {
+		return routeModel, err, false
+	}
+	routeModel.spec = routeObj
+	routeModel.infrasetting, err = getGatewayAPIRouteInfraSetting(key, namespace, routeObj.Spec.ParentRefs)
+	return routeModel, err, processObj
+}
+
+func (m *tlsRouteModel) GetName() string {
+	return m.name
+}
+
+func (m *tlsRouteModel) GetNamespace() string {
+	return m.namespace
+}
+
+func (m *tlsRouteModel) GetAnnotations() map[string]string {
+	if m.spec == nil {
+		return nil
+	}
+	return m.spec.GetAnnotations()
+}
+
+func (m *tlsRouteModel) GetType() string {
+	return lib.TLSRoute
+}
+
+func (m *tlsRouteModel) GetSvcLister() *objects.SvcLister {
+	return objects.SharedTLSRouteSvcLister()
+}
+
+func (m *tlsRouteModel) GetSpec() interface{} {
+	return m.spec
+}
+
+func (m *tlsRouteModel) ParseHostPath() IngressConfig {
+	o := NewNodesValidator()
+	return o.ParseHostPathForTLSRoute(m.namespace, m.name, m.spec, m.key)
+}
+
+func (m *tlsRouteModel) Exists() bool {
+	return m.spec != nil
+}
+
+func (m *tlsRouteModel) GetDiffPathSvc(storedPathSvc map[string][]string, currentPathSvc []IngressHostPathSvc, checkSvc bool) map[string][]string {
+	return getGatewayAPIRouteDiffPathSvc(storedPathSvc, currentPathSvc)
+}
+
+func (m *tlsRouteModel) GetAviInfraSetting() *akov1alpha1.AviInfraSetting {
+	return m.infrasetting.DeepCopy()
+}
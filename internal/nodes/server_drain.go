@@ -0,0 +1,93 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// serverDrainTracker records the time at which the servers, which are being removed from the pools,
+// started draining. The servers are grouped by the pool they are drained from, so that the builds of
+// the other pools of the same Service or nodes do not reset their start times.
+type serverDrainTracker struct {
+	lock      sync.Mutex
+	startTime map[string]map[string]time.Time
+}
+
+var drainTracker = &serverDrainTracker{startTime: make(map[string]map[string]time.Time)}
+
+// getDrainingServers returns the servers, out of the servers of the pool that are being removed, which are
+// still within the drain period and must be kept in the pool as disabled. The servers are mapped to the
+// ingestion key that is synced again when their drain period ends, so that these are removed from the pool.
+// A server which is no longer being removed is forgotten only once its drain period has ended, as the pool
+// can be built without it while the endpoints of the Service are being updated.
+func getDrainingServers(poolName string, servers map[string]string, key string) sets.String {
+	drainingServers := sets.NewString()
+	drainPeriod := lib.GetServerDrainPeriod()
+	if drainPeriod == 0 {
+		return drainingServers
+	}
+
+	drainTracker.lock.Lock()
+	defer drainTracker.lock.Unlock()
+	now := time.Now()
+	startTime := drainTracker.startTime[poolName]
+	if startTime == nil {
+		startTime = make(map[string]time.Time, len(servers))
+	}
+	for server, syncKey := range servers {
+		serverStartTime, ok := startTime[server]
+		if !ok {
+			serverStartTime = now
+			startTime[server] = serverStartTime
+			utils.AviLog.Infof("key: %s, msg: draining server %s of pool %s for %v", key, server, poolName, drainPeriod)
+			requeueAfterDrain(syncKey, drainPeriod)
+		}
+		if now.Sub(serverStartTime) < drainPeriod {
+			drainingServers.Insert(server)
+		}
+	}
+	for server, serverStartTime := range startTime {
+		if _, ok := servers[server]; !ok && now.Sub(serverStartTime) >= drainPeriod {
+			delete(startTime, server)
+		}
+	}
+	if len(startTime) == 0 {
+		delete(drainTracker.startTime, poolName)
+	} else {
+		drainTracker.startTime[poolName] = startTime
+	}
+	return drainingServers
+}
+
+// requeueAfterDrain publishes the key to the ingestion layer after the drain period.
+func requeueAfterDrain(syncKey string, drainPeriod time.Duration) {
+	time.AfterFunc(drainPeriod, func() {
+		_, namespace, _ := lib.ExtractTypeNameNamespace(syncKey)
+		if namespace == "" {
+			namespace = lib.GetTenant()
+		}
+		ingestionQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+		bkt := utils.Bkt(namespace, ingestionQueue.NumWorkers)
+		ingestionQueue.Workqueue[bkt].AddRateLimited(syncKey)
+		utils.AviLog.Infof("key: %s, msg: drain period ended", syncKey)
+	})
+}
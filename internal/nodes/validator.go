@@ -15,18 +15,25 @@
 package nodes
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
 	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+	gatewayv1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1alpha2"
 
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -188,6 +195,57 @@ func destinationCAHTTPRulePresent(key, host, path string) (bool, string) {
 
 // ParseHostPathForIngress handling for hostrule: if the host has a hostrule, and that hostrule has a tls.sslkeycertref then
 // move that host in the tls.hosts, this should be only in case of hostname sharding
+// parseIngressBackend builds the pool details for a service backend of an ingress. Services in other
+// namespaces can be referred only if a ReferenceGrant in that namespace allows it.
+func (v *Validator) parseIngressBackend(ns string, backend networkingv1.IngressBackend, backendNamespaces map[string]string, key string) (IngressHostPathSvc, bool) {
+	if backend.Service == nil {
+		utils.AviLog.Warnf("key: %s, msg: only service backends are supported, skipping the backend", key)
+		return IngressHostPathSvc{}, false
+	}
+	svcNamespace := ns
+	if backendNS, ok := backendNamespaces[backend.Service.Name]; ok && backendNS != "" && backendNS != ns {
+		if !lib.IsReferenceGranted(lib.IngressGroup, utils.Ingress, ns, lib.CoreGroup, utils.Service, backendNS, backend.Service.Name) {
+			utils.AviLog.Warnf("key: %s, msg: reference to service %s/%s is not permitted by any ReferenceGrant, skipping the backend",
+				key, backendNS, backend.Service.Name)
+			return IngressHostPathSvc{}, false
+		}
+		svcNamespace = backendNS
+	}
+	hostPathMapSvc := IngressHostPathSvc{
+		ServiceName: backend.Service.Name,
+		Port:        backend.Service.Port.Number,
+		PortName:    backend.Service.Port.Name,
+		TargetPort:  v.findTargetPort(backend.Service.Name, svcNamespace, &backend.Service.Port, key),
+	}
+	if svcNamespace != ns {
+		hostPathMapSvc.serviceNamespace = svcNamespace
+	}
+	if hostPathMapSvc.PortName == "" {
+		// fill the port name as the port name is not given in the ingress
+		hostPathMapSvc.PortName = v.findPortName(backend.Service.Name, svcNamespace, backend.Service.Port.Number, key)
+	}
+	if hostPathMapSvc.Port == 0 {
+		// Default to port 80 if not set in the ingress object
+		hostPathMapSvc.Port = 80
+	}
+	// for ingress use 100 as default weight
+	hostPathMapSvc.weight = 100
+	return hostPathMapSvc, true
+}
+
+// ParseDefaultBackendForIngress returns the pool details for spec.defaultBackend of the ingress, nil if
+// the ingress does not have a usable default backend.
+func (v *Validator) ParseDefaultBackendForIngress(ns string, ingSpec networkingv1.IngressSpec, annotations map[string]string, key string) *IngressHostPathSvc {
+	if ingSpec.DefaultBackend == nil {
+		return nil
+	}
+	backend, ok := v.parseIngressBackend(ns, *ingSpec.DefaultBackend, lib.GetIngressBackendNamespaces(annotations), key)
+	if !ok {
+		return nil
+	}
+	return &backend
+}
+
 func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec networkingv1.IngressSpec, annotations map[string]string, key string) IngressConfig {
 	// Figure out the service names that are part of this ingress
 
@@ -209,6 +267,9 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		passthroughEnabled = strings.EqualFold(val, "true")
 	}
 
+	backendNamespaces := lib.GetIngressBackendNamespaces(annotations)
+	backendWeights := lib.GetIngressBackendWeights(annotations)
+
 	var tlsConfigs []TlsSettings
 	for _, rule := range ingSpec.Rules {
 		var hostPathMapSvcList HostMetadata
@@ -265,24 +326,33 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 				if path.PathType != nil {
 					pathType = *path.PathType
 				}
-				hostPathMapSvc := IngressHostPathSvc{
-					Path:        path.Path,
-					PathType:    pathType,
-					ServiceName: path.Backend.Service.Name,
-					Port:        path.Backend.Service.Port.Number,
-					PortName:    path.Backend.Service.Port.Name,
-					TargetPort:  v.findTargetPort(path.Backend.Service.Name, ns, &path.Backend.Service.Port, key),
+				hostPathMapSvc, ok := v.parseIngressBackend(ns, path.Backend, backendNamespaces, key)
+				if !ok {
+					continue
 				}
-				if hostPathMapSvc.PortName == "" {
-					// fill the port name as the port name is not given in the ingress
-					hostPathMapSvc.PortName = v.findPortName(path.Backend.Service.Name, ns, path.Backend.Service.Port.Number, key)
+				hostPathMapSvc.Path = path.Path
+				hostPathMapSvc.PathType = pathType
+				hostPathMapSvc.pathRule = getHTTPRulePathRule(hostName, path.Path, pathType, ns, key)
+				if weight, ok := backendWeights[hostPathMapSvc.ServiceName]; ok {
+					hostPathMapSvc.weight = weight
 				}
-				if hostPathMapSvc.Port == 0 {
-					// Default to port 80 if not set in the ingress object
-					hostPathMapSvc.Port = 80
+				// A path can be repeated with different services to split the traffic between them, based on the weights
+				// of the services. The pools of these alternate backends are named after the service.
+				duplicate := false
+				for _, existing := range hostPathMapSvcList.ingressHPSvc {
+					if existing.Path != hostPathMapSvc.Path {
+						continue
+					}
+					if existing.ServiceName == hostPathMapSvc.ServiceName {
+						duplicate = true
+						break
+					}
+					hostPathMapSvc.alternateBackend = true
+				}
+				if duplicate {
+					utils.AviLog.Warnf("key: %s, msg: service %s is repeated for path %s of host %s, skipping the backend", key, hostPathMapSvc.ServiceName, path.Path, hostName)
+					continue
 				}
-				// for ingress use 100 as default weight
-				hostPathMapSvc.weight = 100
 				hostPathMapSvcList.ingressHPSvc = append(hostPathMapSvcList.ingressHPSvc, hostPathMapSvc)
 			}
 		}
@@ -322,6 +392,8 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		return ingressConfig
 	}
 
+	ingressConfig.DefaultBackend = v.ParseDefaultBackendForIngress(ns, ingSpec, annotations, key)
+
 	for _, tlsSettings := range ingSpec.TLS {
 		tlsHostSvcMap := make(IngressHostMap)
 		tls := TlsSettings{}
@@ -657,3 +729,645 @@ func (v *Validator) ParseHostPathForMultiClusterIngress(ns string, ingName strin
 	utils.AviLog.Infof("key: %s, msg: host path config from multi-cluster ingress: %+v", key, utils.Stringify(ingressConfig))
 	return ingressConfig
 }
+
+// gatewayAPIRouteParent is an AKO managed parent Gateway of a route, along with the listeners of the Gateway
+// which allow the route, and the Accepted condition of the route for the parent.
+type gatewayAPIRouteParent struct {
+	gw                *gatewayv1.Gateway
+	parentRef         gatewayv1.ParentReference
+	listeners         []gatewayv1.Listener
+	acceptedCondition metav1.Condition
+}
+
+// getGatewayAPIRouteParents returns the AKO managed parent Gateways of the route of the kind. The Accepted condition
+// of a parent is left as False with an empty reason if listeners allow the route, which is to be set by the caller.
+func getGatewayAPIRouteParents(kind, ns string, parentRefs []gatewayv1.ParentReference, key string) []gatewayAPIRouteParent {
+	var parents []gatewayAPIRouteParent
+	for _, parentRef := range parentRefs {
+		if !lib.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gwNS := lib.GetParentGatewayNamespace(parentRef, ns)
+		gw, err := lib.GetGatewayAPIGateway(gwNS, string(parentRef.Name))
+		if err != nil || !lib.IsGatewayAPIGatewayValid(gw) {
+			// not an AKO managed Gateway
+			continue
+		}
+
+		parent := gatewayAPIRouteParent{
+			gw:        gw,
+			parentRef: parentRef,
+			acceptedCondition: metav1.Condition{
+				Type:   gatewayv1.RouteConditionAccepted,
+				Status: metav1.ConditionFalse,
+			},
+		}
+		matched := false
+		for _, listener := range gw.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+				continue
+			}
+			if parentRef.Port != nil && *parentRef.Port != listener.Port {
+				continue
+			}
+			matched = true
+			if isRouteAllowedByListener(kind, ns, gwNS, listener, key) {
+				parent.listeners = append(parent.listeners, listener)
+			}
+		}
+		if !matched {
+			parent.acceptedCondition.Reason = gatewayv1.RouteReasonNoMatchingParent
+			parent.acceptedCondition.Message = "No listener of the Gateway matches the parentRef"
+		} else if len(parent.listeners) == 0 {
+			parent.acceptedCondition.Reason = gatewayv1.RouteReasonNotAllowedByListeners
+			parent.acceptedCondition.Message = kind + " is not allowed by the listeners of the Gateway"
+		}
+		parents = append(parents, parent)
+	}
+	return parents
+}
+
+// setRouteAccepted marks the route as accepted by the parent.
+func (p *gatewayAPIRouteParent) setRouteAccepted() {
+	p.acceptedCondition.Status = metav1.ConditionTrue
+	p.acceptedCondition.Reason = gatewayv1.RouteReasonAccepted
+	p.acceptedCondition.Message = ""
+}
+
+// buildGatewayAPIRouteStatus returns the status of a route with the parent statuses of AKO replaced, the parent
+// statuses set by other controllers are retained.
+func buildGatewayAPIRouteStatus(oldStatus *gatewayv1.RouteStatus, parents []gatewayAPIRouteParent, resolvedRefsCondition metav1.Condition, unsupported []string, generation int64) *gatewayv1.RouteStatus {
+	routeStatus := &gatewayv1.RouteStatus{}
+	for _, parentStatus := range oldStatus.Parents {
+		if string(parentStatus.ControllerName) != lib.GatewayAPIAviController {
+			routeStatus.Parents = append(routeStatus.Parents, parentStatus)
+		}
+	}
+	for _, parent := range parents {
+		parentStatus := gatewayv1.RouteParentStatus{
+			ParentRef:      parent.parentRef,
+			ControllerName: lib.GatewayAPIAviController,
+		}
+		for _, oldParentStatus := range oldStatus.Parents {
+			if string(oldParentStatus.ControllerName) == lib.GatewayAPIAviController && reflect.DeepEqual(oldParentStatus.ParentRef, parent.parentRef) {
+				parentStatus.Conditions = append([]metav1.Condition{}, oldParentStatus.Conditions...)
+				break
+			}
+		}
+		acceptedCondition := parent.acceptedCondition
+		if acceptedCondition.Status == metav1.ConditionTrue && len(unsupported) != 0 {
+			acceptedCondition.Message = "Ignored unsupported configuration: " + strings.Join(unsupported, ", ")
+		}
+		status.SetGatewayAPICondition(&parentStatus.Conditions, acceptedCondition.Type, acceptedCondition.Status, acceptedCondition.Reason, acceptedCondition.Message, generation)
+		status.SetGatewayAPICondition(&parentStatus.Conditions, resolvedRefsCondition.Type, resolvedRefsCondition.Status, resolvedRefsCondition.Reason, resolvedRefsCondition.Message, generation)
+		routeStatus.Parents = append(routeStatus.Parents, parentStatus)
+	}
+	return routeStatus
+}
+
+// getAcceptedRouteParentGateways returns the parent Gateways, in namespace/name format, which have accepted the route.
+func getAcceptedRouteParentGateways(parents []gatewayAPIRouteParent) []string {
+	var gateways []string
+	for _, parent := range parents {
+		if parent.acceptedCondition.Status == metav1.ConditionTrue {
+			gateways = appendUnique(gateways, parent.gw.Namespace+"/"+parent.gw.Name)
+		}
+	}
+	return gateways
+}
+
+// ParseHostPathForHTTPRoute extracts the host path configuration of the HTTPRoute for the listeners of the AKO managed
+// parent Gateways, to which the HTTPRoute is attached. The parent statuses of the HTTPRoute are updated as well.
+func (v *Validator) ParseHostPathForHTTPRoute(ns string, routeName string, route *gatewayv1.HTTPRoute, key string) IngressConfig {
+	ingressConfig := IngressConfig{}
+
+	var insecureHosts []string
+	// secretNS/secretName -> hosts
+	secretHostsMap := make(map[string][]string)
+	parents := getGatewayAPIRouteParents(lib.HTTPRoute, ns, route.Spec.ParentRefs, key)
+	for i := range parents {
+		parent := &parents[i]
+		if len(parent.listeners) == 0 {
+			continue
+		}
+		parent.acceptedCondition.Reason = gatewayv1.RouteReasonNoMatchingListenerHostname
+		parent.acceptedCondition.Message = "No hostname of the HTTPRoute matches the listeners of the Gateway"
+		for _, listener := range parent.listeners {
+			var hosts []string
+			for _, host := range getRouteListenerHosts(route.Spec.Hostnames, listener.Hostname) {
+				if v.IsValidHostName(host) {
+					hosts = append(hosts, host)
+				}
+			}
+			if len(hosts) == 0 {
+				continue
+			}
+			if listener.Protocol == gatewayv1.HTTPSProtocolType {
+				secretNS, secretName, ok := getListenerCertificate(parent.gw.Namespace, listener)
+				if !ok {
+					utils.AviLog.Warnf("key: %s, msg: listener %s of gateway %s/%s has no valid certificate, skipping it", key, listener.Name, parent.gw.Namespace, parent.gw.Name)
+					continue
+				}
+				secretHostsMap[secretNS+"/"+secretName] = appendUnique(secretHostsMap[secretNS+"/"+secretName], hosts...)
+			} else {
+				insecureHosts = appendUnique(insecureHosts, hosts...)
+			}
+			parent.setRouteAccepted()
+		}
+	}
+	objects.GatewayAPIObjLister().UpdateRouteToGateways(lib.GetGatewayAPIRouteKey(lib.HTTPRoute, ns, routeName), getAcceptedRouteParentGateways(parents))
+
+	// Redirects without a scheme retain the scheme of the request, which is known only if the
+	// HTTPRoute is attached to either of HTTP or HTTPS listeners.
+	defaultScheme := "HTTP"
+	if len(insecureHosts) == 0 && len(secretHostsMap) != 0 {
+		defaultScheme = "HTTPS"
+	}
+	pathSvcs, resolvedRefsCondition, unsupported := v.parseHTTPRouteRules(ns, route, defaultScheme, key)
+
+	routeStatus := buildGatewayAPIRouteStatus(&route.Status.RouteStatus, parents, resolvedRefsCondition, unsupported, route.Generation)
+	status.UpdateGatewayAPIRouteStatusObject(key, lib.HTTPRoute, ns, routeName, &route.Status.RouteStatus, routeStatus)
+
+	getHostMetadata := func(host string) HostMetadata {
+		hostPathMapSvcList := HostMetadata{ingressHPSvc: pathSvcs}
+		if foundHR, hrObj := findHostRuleMappingForFqdn(key, host); foundHR {
+			if foundGs, gslbFqdn := getGslbFqdnFromHostRule(hrObj); foundGs {
+				hostPathMapSvcList.gslbHostHeader = gslbFqdn
+			}
+		}
+		return hostPathMapSvcList
+	}
+
+	hostMap := make(IngressHostMap)
+	for _, host := range insecureHosts {
+		hostMap[host] = getHostMetadata(host)
+	}
+
+	var tlsConfigs []TlsSettings
+	for secret, hosts := range secretHostsMap {
+		secretNS, secretName := strings.Split(secret, "/")[0], strings.Split(secret, "/")[1]
+		tlsHostSvcMap := make(IngressHostMap)
+		for _, host := range hosts {
+			tlsHostSvcMap[host] = getHostMetadata(host)
+			if _, ok := hostMap[host]; ok {
+				// host is exposed via both HTTP and HTTPS listeners
+				ingressConfig.InsecureEdgeTermAllow = true
+			}
+		}
+		tlsConfigs = append(tlsConfigs, TlsSettings{
+			SecretName: secretName,
+			SecretNS:   secretNS,
+			Hosts:      tlsHostSvcMap,
+		})
+		objects.SharedHTTPRouteSvcLister().IngressMappings(ns).AddIngressToSecretsMappings(secretNS, routeName, secretName)
+		objects.SharedHTTPRouteSvcLister().IngressMappings(secretNS).AddSecretsToIngressMappings(ns, routeName, secretName)
+	}
+
+	ingressConfig.TlsCollection = tlsConfigs
+	ingressConfig.IngressHostMap = hostMap
+	utils.AviLog.Infof("key: %s, msg: host path config from HTTPRoute: %+v", key, utils.Stringify(ingressConfig))
+	return ingressConfig
+}
+
+// ParseHostPathForTLSRoute extracts the passthrough configuration of the TLSRoute for the TLS listeners, in Passthrough
+// mode, of the AKO managed parent Gateways. The parent statuses of the TLSRoute are updated as well.
+func (v *Validator) ParseHostPathForTLSRoute(ns string, routeName string, route *gatewayv1alpha2.TLSRoute, key string) IngressConfig {
+	ingressConfig := IngressConfig{}
+
+	var hosts []string
+	parents := getGatewayAPIRouteParents(lib.TLSRoute, ns, route.Spec.ParentRefs, key)
+	for i := range parents {
+		parent := &parents[i]
+		if len(parent.listeners) == 0 {
+			continue
+		}
+		parent.acceptedCondition.Reason = gatewayv1.RouteReasonNoMatchingListenerHostname
+		parent.acceptedCondition.Message = "No hostname of the TLSRoute matches the listeners of the Gateway"
+		for _, listener := range parent.listeners {
+			listenerHosts := 0
+			for _, host := range getRouteListenerHosts(route.Spec.Hostnames, listener.Hostname) {
+				if v.IsValidHostName(host) {
+					hosts = appendUnique(hosts, host)
+					listenerHosts++
+				}
+			}
+			if listenerHosts != 0 {
+				parent.setRouteAccepted()
+			}
+		}
+	}
+	objects.GatewayAPIObjLister().UpdateRouteToGateways(lib.GetGatewayAPIRouteKey(lib.TLSRoute, ns, routeName), getAcceptedRouteParentGateways(parents))
+
+	resolvedRefsCondition := metav1.Condition{
+		Type:   gatewayv1.RouteConditionResolvedRefs,
+		Status: metav1.ConditionTrue,
+		Reason: gatewayv1.RouteReasonResolvedRefs,
+	}
+	var pathSvcs []IngressHostPathSvc
+	for _, rule := range route.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			svcName, port, weight, ok := parseGatewayAPIBackendRef(lib.TLSRoute, ns, backendRef, &resolvedRefsCondition)
+			if !ok {
+				continue
+			}
+			pathSvcs = append(pathSvcs, IngressHostPathSvc{
+				ServiceName: svcName,
+				Port:        port,
+				PortName:    v.findPortName(svcName, ns, port, key),
+				TargetPort:  v.findTargetPort(svcName, ns, &networkingv1.ServiceBackendPort{Number: port}, key),
+				weight:      weight,
+				isTLSRoute:  true,
+			})
+		}
+	}
+
+	routeStatus := buildGatewayAPIRouteStatus(&route.Status.RouteStatus, parents, resolvedRefsCondition, nil, route.Generation)
+	status.UpdateGatewayAPIRouteStatusObject(key, lib.TLSRoute, ns, routeName, &route.Status.RouteStatus, routeStatus)
+
+	if len(pathSvcs) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no valid backends in TLSRoute %s/%s", key, ns, routeName)
+		return ingressConfig
+	}
+	passConfig := make(map[string]PassthroughSettings)
+	for _, host := range hosts {
+		passConfig[host] = PassthroughSettings{
+			PathSvc: pathSvcs,
+			host:    host,
+		}
+	}
+	ingressConfig.PassthroughCollection = passConfig
+	utils.AviLog.Infof("key: %s, msg: host path config from TLSRoute: %+v", key, utils.Stringify(ingressConfig))
+	return ingressConfig
+}
+
+// isRouteAllowedByListener checks the protocol and allowedRoutes of the listener for a route of the kind in routeNS.
+func isRouteAllowedByListener(kind, routeNS, gwNS string, listener gatewayv1.Listener, key string) bool {
+	switch kind {
+	case lib.HTTPRoute:
+		if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.HTTPSProtocolType {
+			return false
+		}
+	case lib.TLSRoute:
+		if listener.Protocol != gatewayv1.TLSProtocolType || listener.TLS == nil ||
+			listener.TLS.Mode == nil || *listener.TLS.Mode != gatewayv1.TLSModePassthrough {
+			return false
+		}
+	case lib.TCPRoute:
+		if listener.Protocol != gatewayv1.TCPProtocolType {
+			return false
+		}
+	case lib.UDPRoute:
+		if listener.Protocol != gatewayv1.UDPProtocolType {
+			return false
+		}
+	default:
+		return false
+	}
+	if listener.AllowedRoutes == nil {
+		return routeNS == gwNS
+	}
+	if len(listener.AllowedRoutes.Kinds) > 0 {
+		kindAllowed := false
+		for _, allowedKind := range listener.AllowedRoutes.Kinds {
+			if (allowedKind.Group == nil || string(*allowedKind.Group) == gatewayv1.GroupName) && string(allowedKind.Kind) == kind {
+				kindAllowed = true
+				break
+			}
+		}
+		if !kindAllowed {
+			return false
+		}
+	}
+	from := gatewayv1.NamespacesFromSame
+	if listener.AllowedRoutes.Namespaces != nil && listener.AllowedRoutes.Namespaces.From != nil {
+		from = *listener.AllowedRoutes.Namespaces.From
+	}
+	switch from {
+	case gatewayv1.NamespacesFromAll:
+		return true
+	case gatewayv1.NamespacesFromSelector:
+		if listener.AllowedRoutes.Namespaces.Selector == nil {
+			return false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: invalid namespace selector in listener %s: %v", key, listener.Name, err)
+			return false
+		}
+		nsObj, err := utils.GetInformers().NSInformer.Lister().Get(routeNS)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: unable to get namespace %s: %v", key, routeNS, err)
+			return false
+		}
+		return selector.Matches(labels.Set(nsObj.GetLabels()))
+	default:
+		return routeNS == gwNS
+	}
+}
+
+// getRouteListenerHosts returns the intersection of the hostnames of the route and the listener.
+// Wildcard hostnames are supported for matching, only fully qualified hostnames are returned.
+func getRouteListenerHosts(routeHosts []gatewayv1.Hostname, listenerHost *gatewayv1.Hostname) []string {
+	var hosts []string
+	if listenerHost == nil || *listenerHost == "" {
+		for _, routeHost := range routeHosts {
+			hosts = append(hosts, string(routeHost))
+		}
+	} else if len(routeHosts) == 0 {
+		hosts = append(hosts, string(*listenerHost))
+	} else {
+		lHost := string(*listenerHost)
+		for _, routeHost := range routeHosts {
+			rHost := string(routeHost)
+			if rHost == lHost || hostMatchesWildcard(rHost, lHost) {
+				hosts = append(hosts, rHost)
+			} else if hostMatchesWildcard(lHost, rHost) {
+				hosts = append(hosts, lHost)
+			}
+		}
+	}
+	var fqdns []string
+	for _, host := range hosts {
+		if !strings.HasPrefix(host, "*") {
+			fqdns = appendUnique(fqdns, host)
+		}
+	}
+	return fqdns
+}
+
+func hostMatchesWildcard(host, wildcard string) bool {
+	return strings.HasPrefix(wildcard, "*.") && !strings.HasPrefix(host, "*") && strings.HasSuffix(host, wildcard[1:])
+}
+
+// getListenerCertificate returns the Secret to be used for TLS termination in the listener.
+func getListenerCertificate(gwNS string, listener gatewayv1.Listener) (string, string, bool) {
+	if listener.TLS == nil || len(listener.TLS.CertificateRefs) == 0 {
+		return "", "", false
+	}
+	if listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate {
+		return "", "", false
+	}
+	certRef := listener.TLS.CertificateRefs[0]
+	if (certRef.Group != nil && *certRef.Group != "") || (certRef.Kind != nil && *certRef.Kind != utils.Secret) {
+		return "", "", false
+	}
+	if certRef.Namespace != nil && string(*certRef.Namespace) != gwNS {
+		return "", "", false
+	}
+	return gwNS, string(certRef.Name), true
+}
+
+// parseHTTPRouteRules builds the host path configuration from the rules of the HTTPRoute, along with the ResolvedRefs
+// condition of the HTTPRoute and the list of unsupported configuration which has been ignored.
+func (v *Validator) parseHTTPRouteRules(ns string, route *gatewayv1.HTTPRoute, defaultScheme, key string) ([]IngressHostPathSvc, metav1.Condition, []string) {
+	resolvedRefsCondition := metav1.Condition{
+		Type:   gatewayv1.RouteConditionResolvedRefs,
+		Status: metav1.ConditionTrue,
+		Reason: gatewayv1.RouteReasonResolvedRefs,
+	}
+	var unsupported []string
+	var pathSvcs []IngressHostPathSvc
+	paths := make(map[string]bool)
+
+	for _, rule := range route.Spec.Rules {
+		var backends []IngressHostPathSvc
+		for _, backendRef := range rule.BackendRefs {
+			if len(backendRef.Filters) > 0 {
+				unsupported = appendUnique(unsupported, "backendRef filters")
+			}
+			svcName, port, weight, ok := parseGatewayAPIBackendRef(lib.HTTPRoute, ns, backendRef.BackendRef, &resolvedRefsCondition)
+			if !ok {
+				continue
+			}
+			backends = append(backends, IngressHostPathSvc{
+				ServiceName: svcName,
+				Port:        port,
+				PortName:    v.findPortName(svcName, ns, port, key),
+				TargetPort:  v.findTargetPort(svcName, ns, &networkingv1.ServiceBackendPort{Number: port}, key),
+				weight:      weight,
+				isHTTPRoute: true,
+			})
+		}
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1.HTTPRouteMatch{{}}
+		}
+		for _, match := range matches {
+			matchPath, pathType, ok := parseHTTPRoutePathMatch(match.Path)
+			if !ok {
+				unsupported = appendUnique(unsupported, "RegularExpression path match")
+				continue
+			}
+			if match.Method != nil {
+				unsupported = appendUnique(unsupported, "method match")
+				continue
+			}
+			headerMatches, ok := parseHTTPRouteHeaderMatches(match.Headers)
+			if !ok {
+				unsupported = appendUnique(unsupported, "RegularExpression header match")
+				continue
+			}
+			path := matchPath
+			if len(headerMatches) > 0 {
+				// paths are used for naming the pools and policies, make it unique for the header matches.
+				path = fmt.Sprintf("%s-%d", matchPath, utils.Hash(utils.Stringify(headerMatches)))
+			}
+			if paths[path] {
+				utils.AviLog.Warnf("key: %s, msg: duplicate match for path %s in HTTPRoute %s/%s, using the first one", key, matchPath, ns, route.Name)
+				continue
+			}
+			paths[path] = true
+
+			pathRule := buildHTTPRoutePathRule(rule.Filters, headerMatches, matchPath, pathType == networkingv1.PathTypePrefix, defaultScheme)
+			hostPathMapSvc := IngressHostPathSvc{
+				Path:        path,
+				PathType:    pathType,
+				pathRule:    pathRule,
+				isHTTPRoute: true,
+			}
+			if path != matchPath {
+				hostPathMapSvc.matchPath = matchPath
+			}
+			if pathRule != nil && pathRule.Redirect != nil {
+				pathSvcs = append(pathSvcs, hostPathMapSvc)
+				continue
+			}
+			if len(backends) == 0 {
+				utils.AviLog.Warnf("key: %s, msg: no valid backends for path %s in HTTPRoute %s/%s", key, matchPath, ns, route.Name)
+				continue
+			}
+			for _, backend := range backends {
+				backend.Path = hostPathMapSvc.Path
+				backend.PathType = hostPathMapSvc.PathType
+				backend.matchPath = hostPathMapSvc.matchPath
+				backend.pathRule = pathRule
+				pathSvcs = append(pathSvcs, backend)
+			}
+		}
+	}
+	return pathSvcs, resolvedRefsCondition, unsupported
+}
+
+// parseGatewayAPIBackendRef validates a backendRef of the route of the kind in the namespace ns, and returns the service
+// name, port and weight of the backend. The ResolvedRefs condition is set to False for an invalid backendRef. Backends
+// with weight 0 are not returned, along with the invalid backendRefs.
+func parseGatewayAPIBackendRef(kind, ns string, backendRef gatewayv1.BackendRef, resolvedRefsCondition *metav1.Condition) (string, int32, int32, bool) {
+	if !isServiceBackendRef(backendRef.BackendObjectReference) {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = gatewayv1.RouteReasonInvalidKind
+		resolvedRefsCondition.Message = "Only core Service backendRefs are supported"
+		return "", 0, 0, false
+	}
+	if backendRef.Namespace != nil && string(*backendRef.Namespace) != ns {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = gatewayv1.RouteReasonRefNotPermitted
+		resolvedRefsCondition.Message = "Service " + string(*backendRef.Namespace) + "/" + string(backendRef.Name) + " is not in the namespace of the " + kind
+		return "", 0, 0, false
+	}
+	if backendRef.Port == nil {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = gatewayv1.RouteReasonBackendNotFound
+		resolvedRefsCondition.Message = "Port is not specified for Service " + string(backendRef.Name)
+		return "", 0, 0, false
+	}
+	weight := int32(1)
+	if backendRef.Weight != nil {
+		weight = *backendRef.Weight
+	}
+	if weight == 0 {
+		return "", 0, 0, false
+	}
+	svcName := string(backendRef.Name)
+	if _, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(svcName); err != nil {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = gatewayv1.RouteReasonBackendNotFound
+		resolvedRefsCondition.Message = "Service " + ns + "/" + svcName + " not found"
+	}
+	return svcName, int32(*backendRef.Port), weight, true
+}
+
+func parseHTTPRoutePathMatch(pathMatch *gatewayv1.HTTPPathMatch) (string, networkingv1.PathType, bool) {
+	path, pathType := "/", networkingv1.PathTypePrefix
+	if pathMatch == nil {
+		return path, pathType, true
+	}
+	if pathMatch.Value != nil && *pathMatch.Value != "" {
+		path = *pathMatch.Value
+	}
+	if pathMatch.Type != nil {
+		switch *pathMatch.Type {
+		case gatewayv1.PathMatchExact:
+			pathType = networkingv1.PathTypeExact
+		case gatewayv1.PathMatchPathPrefix:
+			pathType = networkingv1.PathTypePrefix
+		default:
+			return "", "", false
+		}
+	}
+	return path, pathType, true
+}
+
+func parseHTTPRouteHeaderMatches(headers []gatewayv1.HTTPHeaderMatch) ([]AviHTTPHeaderMatch, bool) {
+	var headerMatches []AviHTTPHeaderMatch
+	for _, header := range headers {
+		if header.Type != nil && *header.Type != gatewayv1.HeaderMatchExact {
+			return nil, false
+		}
+		headerMatches = append(headerMatches, AviHTTPHeaderMatch{
+			Name:          string(header.Name),
+			Values:        []string{header.Value},
+			MatchCriteria: "HDR_EQUALS",
+			MatchCase:     "SENSITIVE",
+		})
+	}
+	return headerMatches, true
+}
+
+// buildHTTPRoutePathRule translates the header matches and the filters of an HTTPRoute rule. matchPrefix is the
+// path prefix of the match, replaced by ReplacePrefixMatch path modifiers.
+func buildHTTPRoutePathRule(filters []gatewayv1.HTTPRouteFilter, headerMatches []AviHTTPHeaderMatch, matchPath string, isPrefix bool, defaultScheme string) *AviHTTPPathRule {
+	if len(filters) == 0 && len(headerMatches) == 0 {
+		return nil
+	}
+	pathRule := &AviHTTPPathRule{HeaderMatches: headerMatches}
+	for _, filter := range filters {
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+			pathRule.RequestHeaders = append(pathRule.RequestHeaders, buildHTTPRouteHeaderActions(filter.RequestHeaderModifier)...)
+		case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+			pathRule.ResponseHeaders = append(pathRule.ResponseHeaders, buildHTTPRouteHeaderActions(filter.ResponseHeaderModifier)...)
+		case gatewayv1.HTTPRouteFilterURLRewrite:
+			if filter.URLRewrite == nil {
+				continue
+			}
+			rewrite := &AviHTTPRewriteURL{}
+			if filter.URLRewrite.Hostname != nil {
+				rewrite.Host = string(*filter.URLRewrite.Hostname)
+			}
+			rewrite.Path, rewrite.PathPrefix, rewrite.MatchPrefix = parseHTTPPathModifier(filter.URLRewrite.Path, matchPath, isPrefix)
+			pathRule.RewriteURL = rewrite
+		case gatewayv1.HTTPRouteFilterRequestRedirect:
+			if filter.RequestRedirect == nil {
+				continue
+			}
+			redirect := &AviHTTPRedirect{
+				Protocol:   defaultScheme,
+				StatusCode: "HTTP_REDIRECT_STATUS_CODE_302",
+			}
+			if filter.RequestRedirect.Scheme != nil {
+				redirect.Protocol = strings.ToUpper(*filter.RequestRedirect.Scheme)
+			}
+			if filter.RequestRedirect.Hostname != nil {
+				redirect.Host = string(*filter.RequestRedirect.Hostname)
+			}
+			if filter.RequestRedirect.Port != nil {
+				redirect.Port = int32(*filter.RequestRedirect.Port)
+			}
+			if filter.RequestRedirect.StatusCode != nil && *filter.RequestRedirect.StatusCode == 301 {
+				redirect.StatusCode = "HTTP_REDIRECT_STATUS_CODE_301"
+			}
+			redirect.Path, redirect.PathPrefix, redirect.MatchPrefix = parseHTTPPathModifier(filter.RequestRedirect.Path, matchPath, isPrefix)
+			pathRule.Redirect = redirect
+		}
+	}
+	return pathRule
+}
+
+func buildHTTPRouteHeaderActions(headerFilter *gatewayv1.HTTPHeaderFilter) []AviHTTPHeaderAction {
+	if headerFilter == nil {
+		return nil
+	}
+	var actions []AviHTTPHeaderAction
+	for _, header := range headerFilter.Set {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_REPLACE_HDR", Name: string(header.Name), Value: header.Value})
+	}
+	for _, header := range headerFilter.Add {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_ADD_HDR", Name: string(header.Name), Value: header.Value})
+	}
+	for _, header := range headerFilter.Remove {
+		actions = append(actions, AviHTTPHeaderAction{Action: "HTTP_REMOVE_HDR", Name: header})
+	}
+	return actions
+}
+
+// parseHTTPPathModifier returns the full path, or the path prefix along with the prefix it replaces.
+func parseHTTPPathModifier(pathModifier *gatewayv1.HTTPPathModifier, matchPath string, isPrefix bool) (string, string, string) {
+	if pathModifier == nil {
+		return "", "", ""
+	}
+	switch pathModifier.Type {
+	case gatewayv1.FullPathHTTPPathModifier:
+		if pathModifier.ReplaceFullPath != nil {
+			return *pathModifier.ReplaceFullPath, "", ""
+		}
+	case gatewayv1.PrefixMatchHTTPPathModifier:
+		if pathModifier.ReplacePrefixMatch != nil {
+			if !isPrefix {
+				return *pathModifier.ReplacePrefixMatch, "", ""
+			}
+			return "", *pathModifier.ReplacePrefixMatch, matchPath
+		}
+	}
+	return "", "", ""
+}
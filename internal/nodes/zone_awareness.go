@@ -0,0 +1,105 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strconv"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// otherZonesPoolSuffix is the suffix of the zone pool with the servers, which are not in any of the zones of the
+// zone awareness, or whose zone is not known.
+const otherZonesPoolSuffix = "other-zones"
+
+// buildZonePoolGroup splits the servers of the pool by zone into a pool per zone, which are the members of a pool
+// group ranked by the order of the zones. The Service Engines send the traffic to the pool of the most preferred
+// zone which is up, hence the servers of a zone receive traffic only once the servers of the preferred zones are
+// down, as reported by the health monitors. The servers of the other zones are in the pool with the lowest priority.
+// The zone of a server is the topology zone label of its node, which is either the node of the endpoint, or the node
+// with the server IP in NodePort and NodePortLocal modes. The pool is not split if the zones of the nodes are not known.
+func buildZonePoolGroup(pool *AviPoolNode, zones []string, key string) ([]*AviPoolNode, *AviPoolGroupNode) {
+	// The node informer is not created in WCP, where the zones of the nodes are not known.
+	if utils.GetInformers().NodeInformer == nil {
+		utils.AviLog.Warnf("key: %s, msg: node informer not found, skipping the zone awareness of pool %s", key, pool.Name)
+		return nil, nil
+	}
+	zoneByNodeName := make(map[string]string)
+	zoneByNodeIP := make(map[string]string)
+	// The node informer runs even if the node event handlers are not set up, as the static routes are disabled.
+	allNodes, err := utils.GetInformers().NodeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the nodes for the zones of the servers: %v", key, err)
+		return nil, nil
+	}
+	for _, node := range allNodes {
+		zone := node.Labels[corev1.LabelTopologyZone]
+		if zone == "" {
+			continue
+		}
+		zoneByNodeName[node.Name] = zone
+		nodeV4, nodeV6 := lib.GetIPFromNode(node)
+		for _, nodeIP := range []string{nodeV4, nodeV6} {
+			if nodeIP != "" {
+				zoneByNodeIP[nodeIP] = zone
+			}
+		}
+	}
+
+	// The pools of all the zones are built even without servers, so that the pool group does not change as the
+	// servers move across the zones.
+	zonePools := make(map[string]*AviPoolNode)
+	pgNode := &AviPoolGroupNode{Name: pool.Name, Tenant: pool.Tenant, AviMarkers: pool.AviMarkers}
+	var pools []*AviPoolNode
+	for i, zone := range append(append([]string{}, zones...), otherZonesPoolSuffix) {
+		if _, ok := zonePools[zone]; ok {
+			continue
+		}
+		zonePool := *pool
+		zonePool.Name = pool.Name + "--" + zone
+		zonePool.Servers = nil
+		if pool.HealthMonitor != nil {
+			healthMonitor := *pool.HealthMonitor
+			healthMonitor.Name = lib.GetReadinessProbeHealthMonitorName(zonePool.Name)
+			zonePool.HealthMonitor = &healthMonitor
+		}
+		zonePools[zone] = &zonePool
+		pools = append(pools, &zonePool)
+
+		poolRef := fmt.Sprintf("/api/pool?name=%s", zonePool.Name)
+		// The pools of the preferred zones have the higher priority labels.
+		priorityLabel := strconv.Itoa(len(zones) + 1 - i)
+		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &poolRef, PriorityLabel: &priorityLabel})
+	}
+	for _, server := range pool.Servers {
+		zone := zoneByNodeName[server.ServerNode]
+		if zone == "" && server.Ip.Addr != nil {
+			zone = zoneByNodeIP[*server.Ip.Addr]
+		}
+		zonePool, ok := zonePools[zone]
+		if !ok {
+			zonePool = zonePools[otherZonesPoolSuffix]
+		}
+		zonePool.Servers = append(zonePool.Servers, server)
+	}
+	utils.AviLog.Debugf("key: %s, msg: servers of pool %s split by zone into pool group %s", key, pool.Name, pgNode.Name)
+	return pools, pgNode
+}
@@ -0,0 +1,310 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package objects
+
+import (
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// This file builds cache relations for the gateway.networking.k8s.io objects.
+// Relationships stored are: gatewayclass to gateway, gateway to routes, secret to gateway and
+// gateway to the TCP/UDP listener backends. Route to service relationships of the HTTPRoutes and
+// TLSRoutes are stored in their respective SvcListers.
+
+var httpRouteSvcListerInstance *SvcLister
+var httpRouteSvcOnce sync.Once
+
+func SharedHTTPRouteSvcLister() *SvcLister {
+	httpRouteSvcOnce.Do(func() {
+		httpRouteSvcListerInstance = &SvcLister{
+			svcIngStore:         NewObjectStore(),
+			ingSvcStore:         NewObjectStore(),
+			secretIngStore:      NewObjectStore(),
+			ingSecretStore:      NewObjectStore(),
+			secretHostNameStore: NewObjectStore(),
+			ingHostStore:        NewObjectStore(),
+			classIngStore:       NewObjectStore(),
+			ingClassStore:       NewObjectStore(),
+		}
+	})
+	return httpRouteSvcListerInstance
+}
+
+var tlsRouteSvcListerInstance *SvcLister
+var tlsRouteSvcOnce sync.Once
+
+func SharedTLSRouteSvcLister() *SvcLister {
+	tlsRouteSvcOnce.Do(func() {
+		tlsRouteSvcListerInstance = &SvcLister{
+			svcIngStore:         NewObjectStore(),
+			ingSvcStore:         NewObjectStore(),
+			secretIngStore:      NewObjectStore(),
+			ingSecretStore:      NewObjectStore(),
+			secretHostNameStore: NewObjectStore(),
+			ingHostStore:        NewObjectStore(),
+			classIngStore:       NewObjectStore(),
+			ingClassStore:       NewObjectStore(),
+		}
+	})
+	return tlsRouteSvcListerInstance
+}
+
+var gatewayAPIListerInstance *GatewayAPILister
+var gatewayAPIOnce sync.Once
+
+func GatewayAPIObjLister() *GatewayAPILister {
+	gatewayAPIOnce.Do(func() {
+		gatewayAPIListerInstance = &GatewayAPILister{
+			GwClassGwStore: NewObjectMapStore(),
+			GwGwClassStore: NewObjectMapStore(),
+			GwRouteStore:   NewObjectMapStore(),
+			RouteGwStore:   NewObjectMapStore(),
+			SecretGwStore:  NewObjectMapStore(),
+			GwSecretStore:  NewObjectMapStore(),
+			GwL4Store:      NewObjectMapStore(),
+			SvcL4GwStore:   NewObjectMapStore(),
+		}
+	})
+	return gatewayAPIListerInstance
+}
+
+type GatewayAPILister struct {
+	GatewayAPILock sync.RWMutex
+
+	// gwclass -> [ns1/gw1, ns1/gw2, ns2/gw3]
+	GwClassGwStore *ObjectMapStore
+
+	// ns1/gw1 -> gwclass
+	GwGwClassStore *ObjectMapStore
+
+	// ns1/gw1 -> [ns1/route1, ns2/route2]
+	GwRouteStore *ObjectMapStore
+
+	// ns1/route1 -> [ns1/gw1, ns2/gw2], routes other than HTTPRoutes are prefixed with the kind, TCPRoute/ns1/route1
+	RouteGwStore *ObjectMapStore
+
+	// ns1/secret1 -> [ns1/gw1, ns1/gw2]
+	SecretGwStore *ObjectMapStore
+
+	// ns1/gw1 -> [ns1/secret1, ns1/secret2]
+	GwSecretStore *ObjectMapStore
+
+	// ns1/gw1 -> {TCP/8080: {TCPRoute/ns1/route1, ns1/svc1, 80}}
+	GwL4Store *ObjectMapStore
+
+	// ns1/svc1 -> [ns1/gw1, ns1/gw2]
+	SvcL4GwStore *ObjectMapStore
+}
+
+// GatewayAPIL4Backend is the backend of a TCP or UDP listener of a Gateway, Port is the port of the Service.
+type GatewayAPIL4Backend struct {
+	Route   string
+	Service string
+	Port    int32
+}
+
+func getStringList(store *ObjectMapStore, key string) (bool, []string) {
+	found, objList := store.Get(key)
+	if !found {
+		return false, make([]string, 0)
+	}
+	return true, objList.([]string)
+}
+
+// GatewayClass <-> Gateway
+func (g *GatewayAPILister) GetGatewayClassToGateways(gwClass string) (bool, []string) {
+	return getStringList(g.GwClassGwStore, gwClass)
+}
+
+func (g *GatewayAPILister) GetGatewayToGatewayClass(gateway string) (bool, string) {
+	found, gwClass := g.GwGwClassStore.Get(gateway)
+	if !found {
+		return false, ""
+	}
+	return true, gwClass.(string)
+}
+
+func (g *GatewayAPILister) UpdateGatewayToGatewayClass(gateway, gwClass string) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeGatewayFromGatewayClass(gateway)
+	_, gatewayList := g.GetGatewayClassToGateways(gwClass)
+	if !utils.HasElem(gatewayList, gateway) {
+		gatewayList = append(gatewayList, gateway)
+	}
+	g.GwClassGwStore.AddOrUpdate(gwClass, gatewayList)
+	g.GwGwClassStore.AddOrUpdate(gateway, gwClass)
+}
+
+func (g *GatewayAPILister) removeGatewayFromGatewayClass(gateway string) {
+	found, gwClass := g.GetGatewayToGatewayClass(gateway)
+	if !found {
+		return
+	}
+	if found, gatewayList := g.GetGatewayClassToGateways(gwClass); found {
+		gatewayList = utils.Remove(gatewayList, gateway)
+		if len(gatewayList) == 0 {
+			g.GwClassGwStore.Delete(gwClass)
+		} else {
+			g.GwClassGwStore.AddOrUpdate(gwClass, gatewayList)
+		}
+	}
+	g.GwGwClassStore.Delete(gateway)
+}
+
+// Gateway <-> Route
+func (g *GatewayAPILister) GetGatewayToRoutes(gateway string) (bool, []string) {
+	return getStringList(g.GwRouteStore, gateway)
+}
+
+func (g *GatewayAPILister) GetRouteToGateways(route string) (bool, []string) {
+	return getStringList(g.RouteGwStore, route)
+}
+
+// UpdateRouteToGateways replaces the parent gateways of the route.
+func (g *GatewayAPILister) UpdateRouteToGateways(route string, gateways []string) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeRouteFromGateways(route)
+	for _, gateway := range gateways {
+		_, routeList := g.GetGatewayToRoutes(gateway)
+		if !utils.HasElem(routeList, route) {
+			routeList = append(routeList, route)
+		}
+		g.GwRouteStore.AddOrUpdate(gateway, routeList)
+	}
+	if len(gateways) > 0 {
+		g.RouteGwStore.AddOrUpdate(route, gateways)
+	}
+}
+
+func (g *GatewayAPILister) DeleteRouteToGateways(route string) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeRouteFromGateways(route)
+}
+
+func (g *GatewayAPILister) removeRouteFromGateways(route string) {
+	_, gateways := g.GetRouteToGateways(route)
+	for _, gateway := range gateways {
+		if found, routeList := g.GetGatewayToRoutes(gateway); found {
+			routeList = utils.Remove(routeList, route)
+			if len(routeList) == 0 {
+				g.GwRouteStore.Delete(gateway)
+			} else {
+				g.GwRouteStore.AddOrUpdate(gateway, routeList)
+			}
+		}
+	}
+	g.RouteGwStore.Delete(route)
+}
+
+// Secret <-> Gateway
+func (g *GatewayAPILister) GetSecretToGateways(secret string) (bool, []string) {
+	return getStringList(g.SecretGwStore, secret)
+}
+
+func (g *GatewayAPILister) GetGatewayToSecrets(gateway string) (bool, []string) {
+	return getStringList(g.GwSecretStore, gateway)
+}
+
+// UpdateGatewayToSecrets replaces the secrets referred by the gateway listeners.
+func (g *GatewayAPILister) UpdateGatewayToSecrets(gateway string, secrets []string) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeGatewayFromSecrets(gateway)
+	for _, secret := range secrets {
+		_, gatewayList := g.GetSecretToGateways(secret)
+		if !utils.HasElem(gatewayList, gateway) {
+			gatewayList = append(gatewayList, gateway)
+		}
+		g.SecretGwStore.AddOrUpdate(secret, gatewayList)
+	}
+	if len(secrets) > 0 {
+		g.GwSecretStore.AddOrUpdate(gateway, secrets)
+	}
+}
+
+func (g *GatewayAPILister) removeGatewayFromSecrets(gateway string) {
+	_, secrets := g.GetGatewayToSecrets(gateway)
+	for _, secret := range secrets {
+		if found, gatewayList := g.GetSecretToGateways(secret); found {
+			gatewayList = utils.Remove(gatewayList, gateway)
+			if len(gatewayList) == 0 {
+				g.SecretGwStore.Delete(secret)
+			} else {
+				g.SecretGwStore.AddOrUpdate(secret, gatewayList)
+			}
+		}
+	}
+	g.GwSecretStore.Delete(gateway)
+}
+
+// Gateway <-> L4 listener backends
+func (g *GatewayAPILister) GetGatewayToL4Backends(gateway string) (bool, map[string]GatewayAPIL4Backend) {
+	found, backends := g.GwL4Store.Get(gateway)
+	if !found {
+		return false, make(map[string]GatewayAPIL4Backend)
+	}
+	return true, backends.(map[string]GatewayAPIL4Backend)
+}
+
+func (g *GatewayAPILister) GetServiceToL4Gateways(service string) (bool, []string) {
+	return getStringList(g.SvcL4GwStore, service)
+}
+
+// UpdateGatewayToL4Backends replaces the backends of the TCP and UDP listeners of the gateway,
+// keyed by the listener in PROTOCOL/port format.
+func (g *GatewayAPILister) UpdateGatewayToL4Backends(gateway string, backends map[string]GatewayAPIL4Backend) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeGatewayFromL4Services(gateway)
+	for _, backend := range backends {
+		_, gatewayList := g.GetServiceToL4Gateways(backend.Service)
+		if !utils.HasElem(gatewayList, gateway) {
+			gatewayList = append(gatewayList, gateway)
+		}
+		g.SvcL4GwStore.AddOrUpdate(backend.Service, gatewayList)
+	}
+	if len(backends) > 0 {
+		g.GwL4Store.AddOrUpdate(gateway, backends)
+	}
+}
+
+func (g *GatewayAPILister) removeGatewayFromL4Services(gateway string) {
+	_, backends := g.GetGatewayToL4Backends(gateway)
+	for _, backend := range backends {
+		if found, gatewayList := g.GetServiceToL4Gateways(backend.Service); found {
+			gatewayList = utils.Remove(gatewayList, gateway)
+			if len(gatewayList) == 0 {
+				g.SvcL4GwStore.Delete(backend.Service)
+			} else {
+				g.SvcL4GwStore.AddOrUpdate(backend.Service, gatewayList)
+			}
+		}
+	}
+	g.GwL4Store.Delete(gateway)
+}
+
+// DeleteGateway removes all the relationships of the gateway, except the routes attached to it.
+func (g *GatewayAPILister) DeleteGateway(gateway string) {
+	g.GatewayAPILock.Lock()
+	defer g.GatewayAPILock.Unlock()
+	g.removeGatewayFromGatewayClass(gateway)
+	g.removeGatewayFromSecrets(gateway)
+	g.removeGatewayFromL4Services(gateway)
+}
@@ -15,6 +15,7 @@
 package objects
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -71,6 +72,8 @@ type SvcLister struct {
 	classIngStore       *ObjectStore
 	svcSIStore          *ObjectStore
 	SISvcStore          *ObjectStore
+	// ingressLocks holds the IngressLock of each namespace, shared by the SvcNSCaches of the namespace.
+	ingressLocks sync.Map
 }
 
 type SvcNSCache struct {
@@ -78,7 +81,7 @@ type SvcNSCache struct {
 	svcIngObject    *ObjectMapStore
 	secretIngObject *ObjectMapStore
 	classIngObject  *ObjectMapStore
-	IngressLock     sync.RWMutex
+	IngressLock     *sync.RWMutex
 	IngNSCache
 	SecretIngNSCache
 	IngHostCache
@@ -124,6 +127,7 @@ func (v *SvcLister) IngressMappings(ns string) *SvcNSCache {
 		svcIngObject:    v.svcIngStore.GetNSStore(ns),
 		secretIngObject: v.secretIngStore.GetNSStore(ns),
 		classIngObject:  v.classIngStore.GetNSStore(ns),
+		IngressLock:     v.ingressLock(ns),
 		IngNSCache: IngNSCache{
 			ingSvcObjects: v.ingSvcStore.GetNSStore(ns),
 		},
@@ -142,6 +146,31 @@ func (v *SvcLister) IngressMappings(ns string) *SvcNSCache {
 	}
 }
 
+func (v *SvcLister) ingressLock(ns string) *sync.RWMutex {
+	lock, _ := v.ingressLocks.LoadOrStore(ns, &sync.RWMutex{})
+	return lock.(*sync.RWMutex)
+}
+
+// lockIngressMappings takes the IngressLocks of the two namespaces, in the order of the namespaces, and
+// returns the function which releases these.
+func (v *SvcLister) lockIngressMappings(ns1, ns2 string) func() {
+	if ns1 == ns2 {
+		lock := v.ingressLock(ns1)
+		lock.Lock()
+		return lock.Unlock
+	}
+	if ns1 > ns2 {
+		ns1, ns2 = ns2, ns1
+	}
+	first, second := v.ingressLock(ns1), v.ingressLock(ns2)
+	first.Lock()
+	second.Lock()
+	return func() {
+		second.Unlock()
+		first.Unlock()
+	}
+}
+
 //=====All service to ingress mapping methods are here.
 
 func (v *SvcNSCache) GetSvcToIng(svcName string) (bool, []string) {
@@ -379,6 +408,61 @@ func (v *SvcNSCache) RemoveSvcFromIngressMappings(ingName string, svcName string
 	}
 }
 
+// UpdateCrossNamespaceIngressMappings maps an ingress to a service in a different namespace. The service is
+// stored as namespace/name against the ingress, and the ingress as namespace/name against the service.
+func (v *SvcLister) UpdateCrossNamespaceIngressMappings(ingNS, ingName, svcNS, svcName string) {
+	unlock := v.lockIngressMappings(ingNS, svcNS)
+	defer unlock()
+	svcCache := v.IngressMappings(svcNS)
+	nsIngress := ingNS + "/" + ingName
+	_, ingresses := svcCache.GetSvcToIng(svcName)
+	if !utils.HasElem(ingresses, nsIngress) {
+		ingresses = append(ingresses, nsIngress)
+		svcCache.UpdateSvcToIngMapping(svcName, ingresses)
+	}
+	ingCache := v.IngressMappings(ingNS)
+	nsSvc := svcNS + "/" + svcName
+	_, svcs := ingCache.GetIngToSvc(ingName)
+	if !utils.HasElem(svcs, nsSvc) {
+		svcs = append(svcs, nsSvc)
+		ingCache.UpdateIngToSvcMapping(ingName, svcs)
+	}
+}
+
+// RemoveCrossNamespaceIngressMappings removes the mappings added by UpdateCrossNamespaceIngressMappings, and
+// returns the ingresses still referring to the service.
+func (v *SvcLister) RemoveCrossNamespaceIngressMappings(ingNS, ingName, svcNS, svcName string) []string {
+	unlock := v.lockIngressMappings(ingNS, svcNS)
+	defer unlock()
+	svcCache := v.IngressMappings(svcNS)
+	_, ingresses := svcCache.GetSvcToIng(svcName)
+	if nsIngress := ingNS + "/" + ingName; utils.HasElem(ingresses, nsIngress) {
+		ingresses = utils.Remove(ingresses, nsIngress)
+		svcCache.UpdateSvcToIngMapping(svcName, ingresses)
+	}
+	ingCache := v.IngressMappings(ingNS)
+	_, svcs := ingCache.GetIngToSvc(ingName)
+	if nsSvc := svcNS + "/" + svcName; utils.HasElem(svcs, nsSvc) {
+		svcs = utils.Remove(svcs, nsSvc)
+		ingCache.UpdateIngToSvcMapping(ingName, svcs)
+	}
+	return ingresses
+}
+
+// GetCrossNamespaceIngresses returns the ingresses from other namespaces, in namespace/name format,
+// which refer to the services in this namespace.
+func (v *SvcNSCache) GetCrossNamespaceIngresses() []string {
+	var nsIngresses []string
+	for _, obj := range v.svcIngObject.CopyAllObjects() {
+		for _, ingress := range obj.([]string) {
+			if strings.Contains(ingress, "/") && !utils.HasElem(nsIngresses, ingress) {
+				nsIngresses = append(nsIngresses, ingress)
+			}
+		}
+	}
+	return nsIngresses
+}
+
 func (v *SvcNSCache) AddSecretsToIngressMappings(ingressNS, ingName, secretName string) {
 	v.IngressLock.Lock()
 	defer v.IngressLock.Unlock()
@@ -55,6 +55,7 @@ func (v *SvcLister) MultiClusterIngressMappings(ns string) *SvcNSCache {
 		svcIngObject:    v.svcIngStore.GetNSStore(ns),
 		secretIngObject: v.secretIngStore.GetNSStore(ns),
 		classIngObject:  v.classIngStore.GetNSStore(ns),
+		IngressLock:     v.ingressLock(ns),
 		IngNSCache: IngNSCache{
 			ingSvcObjects: v.ingSvcStore.GetNSStore(ns),
 		},
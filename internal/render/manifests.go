@@ -0,0 +1,149 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	akoscheme "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/scheme"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	routescheme "github.com/openshift/client-go/route/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var manifestScheme = runtime.NewScheme()
+
+func init() {
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		akoscheme.AddToScheme,
+		routescheme.AddToScheme,
+	} {
+		if err := addToScheme(manifestScheme); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ReadManifestFiles decodes the Kubernetes objects in the files at paths. The directories are walked for the
+// files with the extensions .yaml, .yml and .json, and the path "-" is read from the stdin.
+func ReadManifestFiles(paths []string) ([]runtime.Object, error) {
+	var objs []runtime.Object
+	for _, path := range paths {
+		if path == "-" {
+			stdinObjs, err := DecodeManifests(os.Stdin)
+			if err != nil {
+				return nil, fmt.Errorf("error in decoding the manifests from stdin: %v", err)
+			}
+			objs = append(objs, stdinObjs...)
+			continue
+		}
+		err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			// The files given explicitly are read irrespective of their extension.
+			if filePath != path {
+				switch strings.ToLower(filepath.Ext(filePath)) {
+				case ".yaml", ".yml", ".json":
+				default:
+					return nil
+				}
+			}
+			file, err := os.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			fileObjs, err := DecodeManifests(file)
+			if err != nil {
+				return fmt.Errorf("error in decoding the manifests in %s: %v", filePath, err)
+			}
+			objs = append(objs, fileObjs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objs, nil
+}
+
+// DecodeManifests decodes the Kubernetes objects in the YAML or JSON documents read from r. The items of a List
+// are decoded as separate objects. The documents of kinds unknown to AKO are skipped.
+func DecodeManifests(r io.Reader) ([]runtime.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	deserializer := serializer.NewCodecFactory(manifestScheme).UniversalDeserializer()
+
+	var objs []runtime.Object
+	for doc := 1; ; doc++ {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("document %d: %v", doc, err)
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+		docObjs, err := decodeObject(deserializer, raw.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %v", doc, err)
+		}
+		objs = append(objs, docObjs...)
+	}
+}
+
+func decodeObject(deserializer runtime.Decoder, data []byte) ([]runtime.Object, error) {
+	obj, gvk, err := deserializer.Decode(data, nil, nil)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			utils.AviLog.Warnf("Skipping the object of unknown kind: %v", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	list, ok := obj.(*corev1.List)
+	if !ok {
+		return []runtime.Object{obj}, nil
+	}
+	utils.AviLog.Debugf("Decoding the items of the %s", gvk.Kind)
+	var objs []runtime.Object
+	for _, item := range list.Items {
+		itemObjs, err := decodeObject(deserializer, item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, itemObjs...)
+	}
+	return objs, nil
+}
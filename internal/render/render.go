@@ -0,0 +1,245 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package render translates the Kubernetes objects read from the manifests into the Avi objects, such as the
+// virtualservices, pools, poolgroups, httppolicysets and vsvips, which AKO would create on the Avi Controller.
+// Neither a Kubernetes cluster nor an Avi Controller is needed: the objects are served to the ingestion and the
+// graph layers by fake clientsets, and the rest layer plans the operations in the dry run mode.
+package render
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/rest"
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	routev1 "github.com/openshift/api/route/v1"
+	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
+	routefake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+// defaultIngressClass is the IngressClass created by the AKO Helm chart.
+const defaultIngressClass = "avi-lb"
+
+// Options are the settings of the rendering which AKO otherwise gets from the Avi Controller. The other settings
+// of AKO are read from the same environment variables as in the AKO pod, such as CLUSTER_NAME and SHARD_VS_SIZE.
+type Options struct {
+	// DNSSubDomains are the sub-domains of the DNS profile of the cloud, used for the auto FQDNs of the L4 services.
+	DNSSubDomains []string
+}
+
+// Output holds the Avi objects of each model, in the order in which they would be created.
+type Output struct {
+	Models []Model `json:"models"`
+}
+
+type Model struct {
+	Name    string   `json:"name"`
+	Objects []Object `json:"objects"`
+}
+
+// Object is an Avi object, as it would be sent to the Avi Controller. The private keys are redacted.
+type Object struct {
+	Type   string      `json:"type"`
+	Tenant string      `json:"tenant"`
+	Name   string      `json:"name"`
+	Object interface{} `json:"object"`
+}
+
+// Render translates objs into the Avi objects. Render sets up the shared informers, queues and caches of AKO,
+// hence it can be called only once in a process.
+func Render(objs []runtime.Object, opts Options) (*Output, error) {
+	os.Setenv(lib.DRY_RUN, "true")
+	setDefaultObjects(&objs)
+
+	var kubeObjs, crdObjs, routeObjs []runtime.Object
+	hasEndpointSlices := false
+	for _, obj := range objs {
+		if _, ok := obj.(*discoveryv1.EndpointSlice); ok {
+			hasEndpointSlices = true
+		}
+		switch obj.GetObjectKind().GroupVersionKind().Group {
+		case akov1alpha1.SchemeGroupVersion.Group:
+			crdObjs = append(crdObjs, obj)
+		case routev1.GroupName:
+			routeObjs = append(routeObjs, obj)
+		default:
+			kubeObjs = append(kubeObjs, obj)
+		}
+	}
+	kubeClient := k8sfake.NewSimpleClientset(kubeObjs...)
+	crdClient := crdfake.NewSimpleClientset(crdObjs...)
+	// The Routes are handled in place of the Ingresses in Openshift, hence only when there are Routes.
+	var oshiftClient oshiftclient.Interface
+	if len(routeObjs) > 0 {
+		oshiftClient = routefake.NewSimpleClientset(routeObjs...)
+	}
+
+	akoControlConfig := lib.AKOControlConfig()
+	akoControlConfig.SetCRDClientset(crdClient)
+	akoControlConfig.SetAKOInstanceFlag(true)
+	akoControlConfig.SetAKOBlockedNSList(lib.GetGlobalBlockedNSList())
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, kubeClient, true)
+
+	registeredInformers, err := lib.InformersToRegister(kubeClient, oshiftClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize informers: %v", err)
+	}
+	// Unless there are EndpointSlices in the manifests, the servers of the pools are taken from the Endpoints.
+	if !hasEndpointSlices {
+		for i, informer := range registeredInformers {
+			if informer == utils.EndpointSlicesInformer {
+				registeredInformers[i] = utils.EndpointInformer
+			}
+		}
+	}
+	informersArg := make(map[string]interface{})
+	if oshiftClient != nil {
+		informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = oshiftClient
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: kubeClient}, registeredInformers, informersArg)
+	k8s.NewCRDInformers(crdClient)
+
+	// The values which AKO otherwise sets up while validating the user input with the Avi Controller.
+	if _, err := lib.IsClusterNameValid(); err != nil {
+		return nil, err
+	}
+	lib.SetNamePrefix()
+	lib.SetAKOUser()
+	lib.SetClusterLabelChecksum()
+	// The vsvips are rendered without a VIP network, unless one is specified.
+	if os.Getenv(lib.VIP_NETWORK_LIST) != "" {
+		vipList, err := lib.GetVipNetworkListEnv()
+		if err != nil {
+			return nil, fmt.Errorf("error in getting VIP network: %v", err)
+		}
+		lib.SetVipNetworkList(vipList)
+	}
+	lib.SetIPFamily()
+	if lib.GetSEGName() == "" {
+		segName := lib.GetSEGNameEnv()
+		if segName == "" {
+			segName = lib.DEFAULT_SE_GROUP
+		}
+		lib.SetSEGName(segName)
+	}
+	avicache.SharedAviObjCache().CloudKeyCache.AviCacheAdd(utils.CloudName, &avicache.AviCloudPropertyCache{
+		Name:      utils.CloudName,
+		VType:     lib.GetCloudType(),
+		NSIpamDNS: opts.DNSSubDomains,
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informers := k8s.K8sinformers{Cs: kubeClient, OshiftClient: oshiftClient}
+	if err := k8s.SharedAviController().SyncOffline(informers, registeredInformers, stopCh); err != nil {
+		return nil, err
+	}
+
+	modelNames := objects.SharedAviGraphLister().GetAll().(map[string]interface{})
+	var names []string
+	for modelName := range modelNames {
+		names = append(names, modelName)
+	}
+	sort.Strings(names)
+
+	rest.ResetDryRunPlan()
+	restLayer := rest.NewRestOperations(avicache.SharedAviObjCache(), nil)
+	for _, modelName := range names {
+		restLayer.DequeueNodes(modelName)
+	}
+	plan := rest.GetDryRunPlan()
+
+	output := &Output{Models: []Model{}}
+	for _, modelName := range names {
+		model := Model{Name: modelName, Objects: []Object{}}
+		for _, plannedOp := range plan[modelName] {
+			if plannedOp.Operation == rest.PlannedDelete {
+				continue
+			}
+			model.Objects = append(model.Objects, Object{
+				Type:   plannedOp.Model,
+				Tenant: plannedOp.Tenant,
+				Name:   plannedOp.Name,
+				Object: plannedOp.Object,
+			})
+		}
+		if len(model.Objects) > 0 {
+			output.Models = append(output.Models, model)
+		}
+	}
+	return output, nil
+}
+
+// setDefaultObjects adds the objects which are present in a cluster with AKO, but are seldom part of the
+// manifests of the applications: the namespaces of the objects and the default IngressClass of AKO. The namespaced
+// objects without a namespace are placed in the default namespace, like kubectl does.
+func setDefaultObjects(objs *[]runtime.Object) {
+	namespaces := map[string]bool{metav1.NamespaceDefault: false}
+	hasIngressClass := false
+	for _, obj := range *objs {
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		switch obj.(type) {
+		case *corev1.Namespace:
+			namespaces[objMeta.GetName()] = true
+		case *networkingv1.IngressClass:
+			hasIngressClass = true
+		case *corev1.Node, *akov1alpha1.AviInfraSetting:
+		default:
+			if objMeta.GetNamespace() == "" {
+				objMeta.SetNamespace(metav1.NamespaceDefault)
+			}
+			if _, ok := namespaces[objMeta.GetNamespace()]; !ok {
+				namespaces[objMeta.GetNamespace()] = false
+			}
+		}
+	}
+
+	for namespace, found := range namespaces {
+		if !found {
+			*objs = append(*objs, &corev1.Namespace{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			})
+		}
+	}
+	if !hasIngressClass {
+		*objs = append(*objs, &networkingv1.IngressClass{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "IngressClass"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        defaultIngressClass,
+				Annotations: map[string]string{lib.DefaultIngressClassAnnotation: "true"},
+			},
+			Spec: networkingv1.IngressClassSpec{Controller: lib.AviIngressController},
+		})
+	}
+}
@@ -161,6 +161,9 @@ func (rest *RestOperations) AviDSCacheAdd(rest_op *utils.RestOp, vsKey avicache.
 		checksum := lib.DSChecksum(ds_cache_obj.PoolGroups, nil, false)
 		if len(ds_cache_obj.PoolGroups) == 1 {
 			checksum += utils.Hash(fmt.Sprintf(utils.HTTP_DS_SCRIPT_MODIFIED, ds_cache_obj.PoolGroups[0]))
+		} else if dsObj, ok := rest_op.Obj.(avimodels.VSDataScriptSet); ok && len(ds_cache_obj.PoolGroups) > 1 && len(dsObj.Datascript) == 1 {
+			// Datascripts selecting a default backend are built from the hosts of the VS.
+			checksum += utils.Hash(*dsObj.Datascript[0].Script)
 		}
 		ds_cache_obj.CloudConfigCksum = checksum
 
@@ -68,6 +68,9 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		pools_to_delete, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, vs_cache_obj, namespace, rest_ops, key)
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		if aviVsNode.Dedicated {
+			rest_ops = rest.ClientAuthCU(aviVsNode.ClientAuth, namespace, rest_ops, key)
+		}
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -96,6 +99,9 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		_, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		if aviVsNode.Dedicated {
+			rest_ops = rest.ClientAuthCU(aviVsNode.ClientAuth, namespace, rest_ops, key)
+		}
 
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuildForEvh(aviVsNode, utils.RestPost, nil, key)
@@ -121,6 +127,9 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	var rest_ops []*utils.RestOp
 	vsKey = avicache.NamespaceName{Namespace: namespace, Name: vsName}
 	rest_ops = rest.SSLKeyCertDelete(sslkey_cert_delete, namespace, rest_ops, key)
+	if aviVsNode.Dedicated && aviVsNode.ClientAuth == nil {
+		rest_ops = rest.ClientAuthDelete(vsName, namespace, rest_ops, key)
+	}
 	rest_ops = rest.VSVipDelete(vsvip_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
@@ -186,6 +195,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 				sni_pools_to_delete, rest_ops = rest.PoolCU(sni_node.PoolRefs, sni_cache_obj, namespace, rest_ops, key)
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
@@ -204,6 +214,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 			_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -215,6 +226,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		rest_ops = rest.HTTPPolicyDelete(http_policies_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		if sni_node.ClientAuth == nil {
+			rest_ops = rest.ClientAuthDelete(sni_node.Name, namespace, rest_ops, key)
+		}
 		utils.AviLog.Debugf("key: %s, msg: the EVH VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: EVH child %s not found in cache and EVH parent also does not exist in cache", key, sni_node.Name)
@@ -223,6 +237,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -257,6 +272,9 @@ func setDedicatedEvhVSNodeProperties(vs *avimodels.VirtualService, vs_meta *node
 		// hostrule ref overrides defaults
 		vs.ApplicationProfileRef = &vs_meta.AppProfileRef
 	}
+	if vs_meta.ClientAuth != nil {
+		vs.ApplicationProfileRef = proto.String("/api/applicationprofile/?name=" + vs_meta.ClientAuth.Name)
+	}
 
 	if len(vs_meta.ICAPProfileRefs) != 0 {
 		vs.IcapRequestProfileRefs = vs_meta.ICAPProfileRefs
@@ -427,6 +445,7 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 			vs.RemoveListeningPortOnVsDown = &vsDownOnPoolDown
 		}
 		vs.AnalyticsPolicy = vs_meta.GetAnalyticsPolicy()
+		vs.RequestsRateLimit = BuildRequestsRateProfile(vs_meta.GetRequestsRateLimit())
 
 		var rest_ops []*utils.RestOp
 
@@ -477,6 +496,9 @@ func (rest *RestOperations) AviVsChildEvhBuild(vs_meta *nodes.AviEvhVsNode, rest
 		// hostrule ref overrides defaults
 		app_prof = vs_meta.AppProfileRef
 	}
+	if vs_meta.ClientAuth != nil {
+		app_prof = "/api/applicationprofile/?name=" + vs_meta.ClientAuth.Name
+	}
 
 	cloudRef := "/api/cloud?name=" + utils.CloudName
 	network_prof := "/api/networkprofile/?name=" + "System-TCP-Proxy"
@@ -573,6 +595,7 @@ func (rest *RestOperations) AviVsChildEvhBuild(vs_meta *nodes.AviEvhVsNode, rest
 		evhChild.HTTPPolicies = AviVsHttpPSAdd(vs_meta, true)
 	}
 	evhChild.AnalyticsPolicy = vs_meta.GetAnalyticsPolicy()
+	evhChild.RequestsRateLimit = BuildRequestsRateProfile(vs_meta.GetRequestsRateLimit())
 
 	var rest_ops []*utils.RestOp
 	var rest_op utils.RestOp
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+)
+
+// ClientAuthCU creates or updates the PKI profile and the application profile that validate the client
+// certificates for a virtualservice. The PKI profile created for a previous CA Secret is deleted, after the
+// application profile stops referring to it, when the client certificates are validated against an existing
+// PKI profile.
+func (rest *RestOperations) ClientAuthCU(clientAuth *nodes.AviClientAuthNode, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	if clientAuth == nil {
+		return rest_ops
+	}
+	pkiKey := avicache.NamespaceName{Namespace: namespace, Name: clientAuth.Name}
+	pkiCache, pkiFound := rest.cache.PKIProfileCache.AviCacheGet(pkiKey)
+	if clientAuth.PkiProfile != nil {
+		var pkiCacheObj *avicache.AviPkiProfileCache
+		if pkiFound {
+			pkiCacheObj = pkiCache.(*avicache.AviPkiProfileCache)
+		}
+		if pkiCacheObj == nil || pkiCacheObj.CloudConfigCksum != clientAuth.PkiProfile.GetCheckSum() {
+			if restOp := rest.AviPkiProfileBuild(clientAuth.PkiProfile, pkiCacheObj); restOp != nil {
+				rest_ops = append(rest_ops, restOp)
+			}
+		}
+	}
+
+	appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: clientAuth.Name}
+	var appProfileCacheObj *avicache.AviAppProfileCache
+	if appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey); ok {
+		appProfileCacheObj = appProfileCache.(*avicache.AviAppProfileCache)
+	}
+	if appProfileCacheObj == nil || appProfileCacheObj.CloudConfigCksum != clientAuth.GetCheckSum() {
+		if restOp := rest.AviAppProfileBuild(clientAuth, appProfileCacheObj); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+
+	if clientAuth.PkiProfile == nil && pkiFound {
+		rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{pkiKey}, namespace, rest_ops, key)
+	}
+	return rest_ops
+}
+
+// ClientAuthDelete deletes the application profile and the PKI profile that validated the client certificates
+// for the virtualservice, if these exist. It must be called after the virtualservice stops referring to them.
+func (rest *RestOperations) ClientAuthDelete(vsName string, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	clientAuthKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetClientAuthName(vsName)}
+	if appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(clientAuthKey); ok {
+		appProfileCacheObj := appProfileCache.(*avicache.AviAppProfileCache)
+		utils.AviLog.Debugf("key: %s, msg: about to delete application profile %s", key, clientAuthKey.Name)
+		restOp := rest.AviAppProfileDel(appProfileCacheObj.Uuid, namespace)
+		restOp.ObjName = clientAuthKey.Name
+		rest_ops = append(rest_ops, restOp)
+	}
+	if _, ok := rest.cache.PKIProfileCache.AviCacheGet(clientAuthKey); ok {
+		rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{clientAuthKey}, namespace, rest_ops, key)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) AviAppProfileBuild(clientAuth *nodes.AviClientAuthNode, cache_obj *avicache.AviAppProfileCache) *utils.RestOp {
+	if lib.CheckObjectNameLength(clientAuth.Name, lib.ApplicationProfile) {
+		utils.AviLog.Warnf("Not processing application profile")
+		return nil
+	}
+	name := clientAuth.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", clientAuth.Tenant)
+	cr := lib.AKOUser
+	appProfileType := "APPLICATION_PROFILE_TYPE_HTTP"
+	pkiProfileRef := "/api/pkiprofile?name=" + clientAuth.PkiProfileName
+	enable := true
+
+	appProfile := avimodels.ApplicationProfile{
+		Name:      &name,
+		CreatedBy: &cr,
+		TenantRef: &tenant,
+		Type:      &appProfileType,
+		HTTPProfile: &avimodels.HTTPApplicationProfile{
+			HTTPToHTTPS:              &enable,
+			XForwardedProtoEnabled:   &enable,
+			PkiProfileRef:            &pkiProfileRef,
+			SslClientCertificateMode: &clientAuth.Mode,
+		},
+	}
+	appProfile.Markers = lib.GetAllMarkers(clientAuth.AviMarkers)
+
+	rest_op := utils.RestOp{
+		ObjName: clientAuth.Name,
+		Path:    "/api/applicationprofile/",
+		Method:  utils.RestPost,
+		Obj:     appProfile,
+		Tenant:  clientAuth.Tenant,
+		Model:   "ApplicationProfile",
+	}
+	if cache_obj != nil {
+		rest_op.Path = "/api/applicationprofile/" + cache_obj.Uuid
+		rest_op.Method = utils.RestPut
+	}
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAppProfileDel(uuid string, tenant string) *utils.RestOp {
+	path := "/api/applicationprofile/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: utils.RestDelete,
+		Tenant: tenant,
+		Model:  "ApplicationProfile",
+	}
+	utils.AviLog.Infof("ApplicationProfile DELETE Restop %v", utils.Stringify(rest_op))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAppProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for ApplicationProfile", key)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "applicationprofile", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find ApplicationProfile obj in resp %v", key, rest_op.Response)
+		return errors.New("ApplicationProfile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var appProfile avimodels.ApplicationProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			appProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile)
+		case avimodels.ApplicationProfile:
+			appProfile = rest_op.Obj.(avimodels.ApplicationProfile)
+		}
+		var clientCertMode, pkiProfileName string
+		if appProfile.HTTPProfile != nil {
+			clientCertMode = *appProfile.HTTPProfile.SslClientCertificateMode
+			pkiProfileName = strings.TrimPrefix(*appProfile.HTTPProfile.PkiProfileRef, "/api/pkiprofile?name=")
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		appProfileCacheObj := avicache.AviAppProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: lib.ApplicationProfileChecksum(name, clientCertMode, pkiProfileName, emptyIngestionMarkers, appProfile.Markers, true),
+		}
+		if lastModifiedStr, ok := resp["_last_modified"].(string); ok {
+			appProfileCacheObj.LastModified = lastModifiedStr
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AppProfileCache.AviCacheAdd(k, &appProfileCacheObj)
+		utils.AviLog.Infof("key: %s, msg: added ApplicationProfile cache k %v val %v", key, k, utils.Stringify(appProfileCacheObj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviAppProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	appProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Infof("key: %s, msg: deleting ApplicationProfile cache %v", key, appProfileKey)
+	rest.cache.AppProfileCache.AviCacheDelete(appProfileKey)
+	return nil
+}
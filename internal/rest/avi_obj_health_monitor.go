@@ -0,0 +1,227 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+)
+
+// HealthMonitorCU creates or updates a health monitor of a pool, which is derived from the readiness probe of the
+// Pods or defined inline in an HTTPRule. It must be called before the pool, which refers to the health monitor,
+// is created or updated.
+func (rest *RestOperations) HealthMonitorCU(healthMonitor *nodes.AviHealthMonitorNode, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	if healthMonitor == nil {
+		return rest_ops
+	}
+	healthMonitorKey := avicache.NamespaceName{Namespace: namespace, Name: healthMonitor.Name}
+	var healthMonitorCacheObj *avicache.AviHealthMonitorCache
+	if healthMonitorCache, ok := rest.cache.HealthMonitorCache.AviCacheGet(healthMonitorKey); ok {
+		healthMonitorCacheObj = healthMonitorCache.(*avicache.AviHealthMonitorCache)
+	}
+	if healthMonitorCacheObj == nil || healthMonitorCacheObj.CloudConfigCksum != healthMonitor.GetCheckSum() {
+		if restOp := rest.AviHealthMonitorBuild(healthMonitor, healthMonitorCacheObj); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+// HealthMonitorDelete deletes the health monitor of the pool, which is derived from the readiness probe of the Pods,
+// if it exists. It must be called after the pool stops referring to it.
+func (rest *RestOperations) HealthMonitorDelete(poolName string, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	healthMonitorKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetReadinessProbeHealthMonitorName(poolName)}
+	return rest.healthMonitorDelete(healthMonitorKey, rest_ops, key)
+}
+
+// HTTPRuleHealthMonitorsCU creates or updates the health monitors of a pool, which are defined inline in an HTTPRule,
+// and returns the health monitors of the pool cache which are no longer defined. These must be deleted after the
+// pool stops referring to them.
+func (rest *RestOperations) HTTPRuleHealthMonitorsCU(pool *nodes.AviPoolNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var healthMonitorsToDelete []avicache.NamespaceName
+	if pool_cache_obj != nil {
+		healthMonitorsToDelete = make([]avicache.NamespaceName, len(pool_cache_obj.HealthMonitorCollection))
+		copy(healthMonitorsToDelete, pool_cache_obj.HealthMonitorCollection)
+	}
+	for _, healthMonitor := range pool.HTTPRuleHealthMonitors {
+		healthMonitorKey := avicache.NamespaceName{Namespace: namespace, Name: healthMonitor.Name}
+		healthMonitorsToDelete = avicache.RemoveNamespaceName(healthMonitorsToDelete, healthMonitorKey)
+		rest_ops = rest.HealthMonitorCU(healthMonitor, namespace, rest_ops, key)
+	}
+	return healthMonitorsToDelete, rest_ops
+}
+
+// HTTPRuleHealthMonitorsDelete deletes the health monitors of a pool, which were defined inline in an HTTPRule.
+// The other health monitors are skipped. It must be called after the pool stops referring to them.
+func (rest *RestOperations) HTTPRuleHealthMonitorsDelete(healthMonitorsToDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, healthMonitor := range healthMonitorsToDelete {
+		if !strings.HasSuffix(healthMonitor.Name, lib.HTTPRuleHealthMonitorSuffix) {
+			continue
+		}
+		healthMonitorKey := avicache.NamespaceName{Namespace: namespace, Name: healthMonitor.Name}
+		rest_ops = rest.healthMonitorDelete(healthMonitorKey, rest_ops, key)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) healthMonitorDelete(healthMonitorKey avicache.NamespaceName, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	if healthMonitorCache, ok := rest.cache.HealthMonitorCache.AviCacheGet(healthMonitorKey); ok {
+		healthMonitorCacheObj := healthMonitorCache.(*avicache.AviHealthMonitorCache)
+		utils.AviLog.Debugf("key: %s, msg: about to delete health monitor %s", key, healthMonitorKey.Name)
+		restOp := rest.AviHealthMonitorDel(healthMonitorCacheObj.Uuid, healthMonitorKey.Namespace)
+		restOp.ObjName = healthMonitorKey.Name
+		rest_ops = append(rest_ops, restOp)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) AviHealthMonitorBuild(healthMonitor *nodes.AviHealthMonitorNode, cache_obj *avicache.AviHealthMonitorCache) *utils.RestOp {
+	if lib.CheckObjectNameLength(healthMonitor.Name, lib.HealthMonitor) {
+		utils.AviLog.Warnf("Not processing health monitor")
+		return nil
+	}
+	name := healthMonitor.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", healthMonitor.Tenant)
+	monitorType := healthMonitor.Type
+	sendInterval := healthMonitor.SendInterval
+	receiveTimeout := healthMonitor.ReceiveTimeout
+	successfulChecks := healthMonitor.SuccessfulChecks
+	failedChecks := healthMonitor.FailedChecks
+
+	aviHealthMonitor := avimodels.HealthMonitor{
+		Name:             &name,
+		TenantRef:        &tenant,
+		Type:             &monitorType,
+		SendInterval:     &sendInterval,
+		ReceiveTimeout:   &receiveTimeout,
+		SuccessfulChecks: &successfulChecks,
+		FailedChecks:     &failedChecks,
+	}
+	if healthMonitor.MonitorPort != 0 {
+		monitorPort := healthMonitor.MonitorPort
+		aviHealthMonitor.MonitorPort = &monitorPort
+	}
+	if healthMonitor.HTTPRequest != "" {
+		httpRequest := healthMonitor.HTTPRequest
+		httpMonitor := &avimodels.HealthMonitorHTTP{
+			HTTPRequest:      &httpRequest,
+			HTTPResponseCode: healthMonitor.ResponseCodes,
+		}
+		if monitorType == "HEALTH_MONITOR_HTTPS" {
+			aviHealthMonitor.HTTPSMonitor = httpMonitor
+		} else {
+			aviHealthMonitor.HTTPMonitor = httpMonitor
+		}
+	}
+	if healthMonitor.TCPRequest != "" {
+		tcpRequest := healthMonitor.TCPRequest
+		aviHealthMonitor.TCPMonitor = &avimodels.HealthMonitorTCP{
+			TCPRequest: &tcpRequest,
+		}
+	}
+	aviHealthMonitor.Markers = lib.GetAllMarkers(healthMonitor.AviMarkers)
+
+	rest_op := utils.RestOp{
+		ObjName: healthMonitor.Name,
+		Path:    "/api/healthmonitor/",
+		Method:  utils.RestPost,
+		Obj:     aviHealthMonitor,
+		Tenant:  healthMonitor.Tenant,
+		Model:   "HealthMonitor",
+	}
+	if cache_obj != nil {
+		rest_op.Path = "/api/healthmonitor/" + cache_obj.Uuid
+		rest_op.Method = utils.RestPut
+	}
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorDel(uuid string, tenant string) *utils.RestOp {
+	path := "/api/healthmonitor/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: utils.RestDelete,
+		Tenant: tenant,
+		Model:  "HealthMonitor",
+	}
+	utils.AviLog.Infof("HealthMonitor DELETE Restop %v", utils.Stringify(rest_op))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for HealthMonitor", key)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "healthmonitor", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find HealthMonitor obj in resp %v", key, rest_op.Response)
+		return errors.New("HealthMonitor not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var healthMonitor avimodels.HealthMonitor
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			healthMonitor = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor)
+		case avimodels.HealthMonitor:
+			healthMonitor = rest_op.Obj.(avimodels.HealthMonitor)
+		}
+		healthMonitor.Name = &name
+		healthMonitorCacheObj := avicache.AviHealthMonitorCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.HealthMonitorChecksum(&healthMonitor),
+		}
+		if lastModifiedStr, ok := resp["_last_modified"].(string); ok {
+			healthMonitorCacheObj.LastModified = lastModifiedStr
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.HealthMonitorCache.AviCacheAdd(k, &healthMonitorCacheObj)
+		utils.AviLog.Infof("key: %s, msg: added HealthMonitor cache k %v val %v", key, k, utils.Stringify(healthMonitorCacheObj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheDel(rest_op *utils.RestOp, key string) error {
+	healthMonitorKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Infof("key: %s, msg: deleting HealthMonitor cache %v", key, healthMonitorKey)
+	rest.cache.HealthMonitorCache.AviCacheDelete(healthMonitorKey)
+	return nil
+}
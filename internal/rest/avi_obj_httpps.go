@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -47,6 +48,7 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 
 	http_req_pol := avimodels.HTTPRequestPolicy{}
 	http_sec_pol := avimodels.HttpsecurityPolicy{}
+	http_rsp_pol := avimodels.HTTPResponsePolicy{}
 	hps := avimodels.HTTPPolicySet{Name: &name,
 		CreatedBy: &cr, TenantRef: &tenant, HTTPRequestPolicy: &http_req_pol, HTTPSecurityPolicy: &http_sec_pol}
 
@@ -62,7 +64,11 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 		}
 		httpPresentIng.Insert(hppmap.IngName)
 	}
-	sort.Slice(hppmapWithPath, func(i, j int) bool {
+	sort.SliceStable(hppmapWithPath, func(i, j int) bool {
+		if len(hppmapWithPath[i].Path[0]) == len(hppmapWithPath[j].Path[0]) {
+			// For the same path, rules with more header and cookie matches are more specific and must be evaluated first.
+			return numHeaderMatches(hppmapWithPath[i]) > numHeaderMatches(hppmapWithPath[j])
+		}
 		return len(hppmapWithPath[i].Path[0]) > len(hppmapWithPath[j].Path[0])
 	})
 	hppmapAllPaths = append(hppmapAllPaths, hppmapWithPath...)
@@ -90,14 +96,15 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 			continue
 		}
 		action := avimodels.HttpsecurityAction{
-			Action: &sec_rule.Action,
-		}
-		portMatch := avimodels.PortMatch{
-			MatchCriteria: &sec_rule.MatchCriteria,
-			Ports:         []int64{sec_rule.Port},
+			Action:      &sec_rule.Action,
+			RateProfile: buildSecurityRateProfile(sec_rule.RateLimit),
 		}
-		match := avimodels.MatchTarget{
-			VsPort: &portMatch,
+		match := avimodels.MatchTarget{}
+		if sec_rule.Port != 0 {
+			match.VsPort = &avimodels.PortMatch{
+				MatchCriteria: &sec_rule.MatchCriteria,
+				Ports:         []int64{sec_rule.Port},
+			}
 		}
 		var j int32
 		j = idx
@@ -141,29 +148,65 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 			match_target.VsPort = &vsport_match
 		}
 
-		sw_action := avimodels.HttpswitchingAction{}
-		if hppmap.Pool != "" {
-			action := "HTTP_SWITCHING_SELECT_POOL"
-			sw_action.Action = &action
-			pool_ref := fmt.Sprintf("/api/pool/?name=%s", hppmap.Pool)
-			sw_action.PoolRef = &pool_ref
-		} else if hppmap.PoolGroup != "" {
-			action := "HTTP_SWITCHING_SELECT_POOLGROUP"
-			sw_action.Action = &action
-			pg_ref := fmt.Sprintf("/api/poolgroup/?name=%s", hppmap.PoolGroup)
-			sw_action.PoolGroupRef = &pg_ref
+		var rspRule *avimodels.HTTPResponseRule
+		if hppmap.PathRule != nil {
+			match_target.Hdrs = buildHdrMatches(hppmap.PathRule.HeaderMatches)
+			match_target.Cookie = buildCookieMatch(hppmap.PathRule.CookieMatch)
+			if len(hppmap.PathRule.ResponseHeaders) > 0 {
+				rspRule = &avimodels.HTTPResponseRule{
+					Enable: &enable,
+					Name:   &name,
+					Match: &avimodels.ResponseMatchTarget{
+						Path:   match_target.Path,
+						VsPort: match_target.VsPort,
+						Hdrs:   match_target.Hdrs,
+					},
+					HdrAction: buildHdrActions(hppmap.PathRule.ResponseHeaders),
+				}
+			}
 		}
 
 		var j int32
 		j = idx
 		rule := avimodels.HTTPRequestRule{
-			Index:           &j,
-			Enable:          &enable,
-			Name:            &name,
-			Match:           &match_target,
-			SwitchingAction: &sw_action,
+			Index:  &j,
+			Enable: &enable,
+			Name:   &name,
+			Match:  &match_target,
+		}
+		if hppmap.PathRule != nil && hppmap.PathRule.Redirect != nil {
+			rule.RedirectAction = buildRedirectAction(hppmap.PathRule.Redirect)
+		} else {
+			rule.SwitchingAction = buildSwitchingAction(hppmap)
+			if hppmap.PathRule != nil {
+				rule.HdrAction = buildHdrActions(hppmap.PathRule.RequestHeaders)
+				rule.RewriteURLAction = buildRewriteURLAction(hppmap.PathRule.RewriteURL)
+			}
 		}
 		http_req_pol.Rules = append(http_req_pol.Rules, &rule)
+		if hppmap.PathRule != nil && hppmap.PathRule.RateLimit != nil {
+			// The rate limit of the path applies to all the requests for the path, hence only the path and port are matched.
+			secAction := lib.RATE_LIMIT
+			secIdx := int32(len(http_sec_pol.Rules))
+			http_sec_pol.Rules = append(http_sec_pol.Rules, &avimodels.HttpsecurityRule{
+				Index:  &secIdx,
+				Enable: &enable,
+				Name:   &name,
+				Match: &avimodels.MatchTarget{
+					Path:   match_target.Path,
+					VsPort: match_target.VsPort,
+				},
+				Action: &avimodels.HttpsecurityAction{
+					Action:      &secAction,
+					RateProfile: buildSecurityRateProfile(hppmap.PathRule.RateLimit),
+				},
+			})
+		}
+		if rspRule != nil {
+			rspIdx := int32(len(http_rsp_pol.Rules))
+			rspRule.Index = &rspIdx
+			http_rsp_pol.Rules = append(http_rsp_pol.Rules, rspRule)
+		}
 		idx = idx + 1
 
 	}
@@ -232,6 +275,10 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 
 	}
 
+	if len(http_rsp_pol.Rules) > 0 {
+		hps.HTTPResponsePolicy = &http_rsp_pol
+	}
+
 	var path string
 	var rest_op utils.RestOp
 	if cache_obj != nil {
@@ -403,3 +450,185 @@ func (rest *RestOperations) AviHTTPPolicyCacheDel(rest_op *utils.RestOp, vsKey a
 
 	return nil
 }
+
+func numHeaderMatches(hppmap nodes.AviHostPathPortPoolPG) int {
+	if hppmap.PathRule == nil {
+		return 0
+	}
+	if hppmap.PathRule.CookieMatch != nil {
+		return len(hppmap.PathRule.HeaderMatches) + 1
+	}
+	return len(hppmap.PathRule.HeaderMatches)
+}
+
+func buildSwitchingAction(hppmap nodes.AviHostPathPortPoolPG) *avimodels.HttpswitchingAction {
+	sw_action := avimodels.HttpswitchingAction{}
+	if hppmap.Pool != "" {
+		action := "HTTP_SWITCHING_SELECT_POOL"
+		sw_action.Action = &action
+		pool_ref := fmt.Sprintf("/api/pool/?name=%s", hppmap.Pool)
+		sw_action.PoolRef = &pool_ref
+	} else if hppmap.PoolGroup != "" {
+		action := "HTTP_SWITCHING_SELECT_POOLGROUP"
+		sw_action.Action = &action
+		pg_ref := fmt.Sprintf("/api/poolgroup/?name=%s", hppmap.PoolGroup)
+		sw_action.PoolGroupRef = &pg_ref
+	}
+	return &sw_action
+}
+
+func buildHdrMatches(hdrMatches []nodes.AviHTTPHeaderMatch) []*avimodels.HdrMatch {
+	var hdrs []*avimodels.HdrMatch
+	for i := range hdrMatches {
+		hdrMatch := &avimodels.HdrMatch{
+			Hdr:           &hdrMatches[i].Name,
+			MatchCriteria: &hdrMatches[i].MatchCriteria,
+			Value:         hdrMatches[i].Values,
+		}
+		if hdrMatches[i].MatchCase != "" {
+			hdrMatch.MatchCase = &hdrMatches[i].MatchCase
+		}
+		hdrs = append(hdrs, hdrMatch)
+	}
+	return hdrs
+}
+
+func buildCookieMatch(cookieMatch *nodes.AviHTTPCookieMatch) *avimodels.CookieMatch {
+	if cookieMatch == nil {
+		return nil
+	}
+	cookie := &avimodels.CookieMatch{
+		Name:          &cookieMatch.Name,
+		MatchCriteria: &cookieMatch.MatchCriteria,
+	}
+	if cookieMatch.Value != "" {
+		cookie.Value = &cookieMatch.Value
+	}
+	if cookieMatch.MatchCase != "" {
+		cookie.MatchCase = &cookieMatch.MatchCase
+	}
+	return cookie
+}
+
+func buildHdrActions(hdrActions []nodes.AviHTTPHeaderAction) []*avimodels.HTTPHdrAction {
+	var actions []*avimodels.HTTPHdrAction
+	for i := range hdrActions {
+		hdrData := &avimodels.HTTPHdrData{Name: &hdrActions[i].Name}
+		if hdrActions[i].Action != "HTTP_REMOVE_HDR" {
+			hdrData.Value = &avimodels.HTTPHdrValue{Val: &hdrActions[i].Value}
+		}
+		actions = append(actions, &avimodels.HTTPHdrAction{
+			Action: &hdrActions[i].Action,
+			Hdr:    hdrData,
+		})
+	}
+	return actions
+}
+
+func buildStringURIParam(value string) *avimodels.URIParam {
+	paramType := "URI_PARAM_TYPE_TOKENIZED"
+	tokenType := "URI_TOKEN_TYPE_STRING"
+	return &avimodels.URIParam{
+		Type:   &paramType,
+		Tokens: []*avimodels.URIParamToken{{Type: &tokenType, StrValue: &value}},
+	}
+}
+
+// buildPathURIParam returns the path to be used in the rewritten or redirected URL. If path is set, the full
+// path is replaced, otherwise matchPrefix is replaced by pathPrefix and the remaining path is retained.
+func buildPathURIParam(path, pathPrefix, matchPrefix string) *avimodels.URIParam {
+	if path != "" {
+		return buildStringURIParam(strings.Trim(path, "/"))
+	}
+	paramType := "URI_PARAM_TYPE_TOKENIZED"
+	uriParam := &avimodels.URIParam{Type: &paramType}
+	if prefix := strings.Trim(pathPrefix, "/"); prefix != "" {
+		strTokenType := "URI_TOKEN_TYPE_STRING"
+		uriParam.Tokens = append(uriParam.Tokens, &avimodels.URIParamToken{Type: &strTokenType, StrValue: &prefix})
+	}
+	var startIndex int32
+	if matchPrefix = strings.Trim(matchPrefix, "/"); matchPrefix != "" {
+		startIndex = int32(len(strings.Split(matchPrefix, "/")))
+	}
+	// end index 65535 refers to the last token of the incoming path.
+	endIndex := int32(65535)
+	pathTokenType := "URI_TOKEN_TYPE_PATH"
+	uriParam.Tokens = append(uriParam.Tokens, &avimodels.URIParamToken{Type: &pathTokenType, StartIndex: &startIndex, EndIndex: &endIndex})
+	return uriParam
+}
+
+func buildRewriteURLAction(rewrite *nodes.AviHTTPRewriteURL) *avimodels.HTTPRewriteURLAction {
+	if rewrite == nil {
+		return nil
+	}
+	action := &avimodels.HTTPRewriteURLAction{}
+	if rewrite.Host != "" {
+		action.HostHdr = buildStringURIParam(rewrite.Host)
+	}
+	if rewrite.Path != "" || rewrite.PathPrefix != "" {
+		action.Path = buildPathURIParam(rewrite.Path, rewrite.PathPrefix, rewrite.MatchPrefix)
+	}
+	return action
+}
+
+// buildSecurityRateProfile returns the rate profile of the http security rule for the rate limit.
+func buildSecurityRateProfile(rateLimit *nodes.AviRateLimit) *avimodels.HttpsecurityActionRateProfile {
+	if rateLimit == nil {
+		return nil
+	}
+	return &avimodels.HttpsecurityActionRateProfile{
+		PerClientIP: &rateLimit.PerClientIP,
+		RateLimiter: buildRateLimiter(rateLimit),
+		Action:      buildRateLimiterAction(rateLimit),
+	}
+}
+
+// BuildRequestsRateProfile returns the requests rate limit of the vs for the rate limit.
+func BuildRequestsRateProfile(rateLimit *nodes.AviRateLimit) *avimodels.RateProfile {
+	if rateLimit == nil {
+		return nil
+	}
+	rateProfile := &avimodels.RateProfile{
+		RateLimiter: buildRateLimiter(rateLimit),
+		Action:      buildRateLimiterAction(rateLimit),
+	}
+	if rateLimit.Header != "" {
+		rateProfile.HTTPHeader = &rateLimit.Header
+	}
+	return rateProfile
+}
+
+func buildRateLimiter(rateLimit *nodes.AviRateLimit) *avimodels.RateLimiter {
+	return &avimodels.RateLimiter{
+		Count:   &rateLimit.Count,
+		Period:  &rateLimit.Period,
+		BurstSz: &rateLimit.Burst,
+	}
+}
+
+func buildRateLimiterAction(rateLimit *nodes.AviRateLimit) *avimodels.RateLimiterAction {
+	action := &avimodels.RateLimiterAction{Type: &rateLimit.Action}
+	if rateLimit.Redirect != nil {
+		action.Redirect = buildRedirectAction(rateLimit.Redirect)
+	}
+	return action
+}
+
+func buildRedirectAction(redirect *nodes.AviHTTPRedirect) *avimodels.HTTPRedirectAction {
+	keepQuery := true
+	action := &avimodels.HTTPRedirectAction{
+		Protocol:   &redirect.Protocol,
+		StatusCode: &redirect.StatusCode,
+		KeepQuery:  &keepQuery,
+	}
+	if redirect.Host != "" {
+		action.Host = buildStringURIParam(redirect.Host)
+	}
+	if redirect.Port != 0 {
+		action.Port = &redirect.Port
+	}
+	if redirect.Path != "" || redirect.PathPrefix != "" {
+		action.Path = buildPathURIParam(redirect.Path, redirect.PathPrefix, redirect.MatchPrefix)
+	}
+	return action
+}
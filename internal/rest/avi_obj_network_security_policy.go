@@ -0,0 +1,206 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+)
+
+// NetworkSecurityPolicyCU creates or updates the network security policy of a virtualservice. It must be called
+// before the virtualservice, which refers to the network security policy, is created or updated.
+func (rest *RestOperations) NetworkSecurityPolicyCU(networkSecurityPolicy *nodes.AviNetworkSecurityPolicyNode, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	if networkSecurityPolicy == nil {
+		return rest_ops
+	}
+	networkSecurityPolicyKey := avicache.NamespaceName{Namespace: namespace, Name: networkSecurityPolicy.Name}
+	var networkSecurityPolicyCacheObj *avicache.AviNetworkSecurityPolicyCache
+	if networkSecurityPolicyCache, ok := rest.cache.NetworkSecurityPolicyCache.AviCacheGet(networkSecurityPolicyKey); ok {
+		networkSecurityPolicyCacheObj = networkSecurityPolicyCache.(*avicache.AviNetworkSecurityPolicyCache)
+	}
+	if networkSecurityPolicyCacheObj == nil || networkSecurityPolicyCacheObj.CloudConfigCksum != networkSecurityPolicy.GetCheckSum() {
+		if restOp := rest.AviNetworkSecurityPolicyBuild(networkSecurityPolicy, networkSecurityPolicyCacheObj); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+// NetworkSecurityPolicyDelete deletes the network security policy of the virtualservice, if it exists. It must be
+// called after the virtualservice stops referring to it.
+func (rest *RestOperations) NetworkSecurityPolicyDelete(vsName string, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	networkSecurityPolicyKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetNetworkSecurityPolicyName(vsName)}
+	if networkSecurityPolicyCache, ok := rest.cache.NetworkSecurityPolicyCache.AviCacheGet(networkSecurityPolicyKey); ok {
+		networkSecurityPolicyCacheObj := networkSecurityPolicyCache.(*avicache.AviNetworkSecurityPolicyCache)
+		utils.AviLog.Debugf("key: %s, msg: about to delete network security policy %s", key, networkSecurityPolicyKey.Name)
+		restOp := rest.AviNetworkSecurityPolicyDel(networkSecurityPolicyCacheObj.Uuid, namespace)
+		restOp.ObjName = networkSecurityPolicyKey.Name
+		rest_ops = append(rest_ops, restOp)
+	}
+	return rest_ops
+}
+
+// AviNetworkSecurityPolicyBuild builds the network security policy with a single rule, which denies the clients
+// that are not in the source ranges. When there are no source ranges, the rule denies all the clients.
+func (rest *RestOperations) AviNetworkSecurityPolicyBuild(networkSecurityPolicy *nodes.AviNetworkSecurityPolicyNode, cache_obj *avicache.AviNetworkSecurityPolicyCache) *utils.RestOp {
+	if lib.CheckObjectNameLength(networkSecurityPolicy.Name, lib.NetworkSecurityPolicy) {
+		utils.AviLog.Warnf("Not processing network security policy")
+		return nil
+	}
+	name := networkSecurityPolicy.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", networkSecurityPolicy.Tenant)
+	cr := lib.AKOUser
+
+	matchCriteria := "IS_NOT_IN"
+	sourceRanges := networkSecurityPolicy.SourceRanges
+	if len(sourceRanges) == 0 {
+		matchCriteria = "IS_IN"
+		sourceRanges = []string{"0.0.0.0/0", "::/0"}
+	}
+	var prefixes []*avimodels.IPAddrPrefix
+	for _, sourceRange := range sourceRanges {
+		ip, ipNet, err := net.ParseCIDR(sourceRange)
+		if err != nil {
+			continue
+		}
+		addr := ipNet.IP.String()
+		atype := "V4"
+		if ip.To4() == nil {
+			atype = "V6"
+		}
+		ones, _ := ipNet.Mask.Size()
+		mask := int32(ones)
+		prefixes = append(prefixes, &avimodels.IPAddrPrefix{
+			IPAddr: &avimodels.IPAddr{Addr: &addr, Type: &atype},
+			Mask:   &mask,
+		})
+	}
+
+	ruleName := "deny-outside-source-ranges"
+	action := "NETWORK_SECURITY_POLICY_ACTION_TYPE_DENY"
+	enable := true
+	index := int32(1)
+	aviNetworkSecurityPolicy := avimodels.NetworkSecurityPolicy{
+		Name:      &name,
+		CreatedBy: &cr,
+		TenantRef: &tenant,
+		Rules: []*avimodels.NetworkSecurityRule{{
+			Name:   &ruleName,
+			Action: &action,
+			Enable: &enable,
+			Index:  &index,
+			Match: &avimodels.NetworkSecurityMatchTarget{
+				ClientIP: &avimodels.IPAddrMatch{
+					MatchCriteria: &matchCriteria,
+					Prefixes:      prefixes,
+				},
+			},
+		}},
+	}
+	aviNetworkSecurityPolicy.Markers = lib.GetAllMarkers(networkSecurityPolicy.AviMarkers)
+
+	rest_op := utils.RestOp{
+		ObjName: networkSecurityPolicy.Name,
+		Path:    "/api/networksecuritypolicy/",
+		Method:  utils.RestPost,
+		Obj:     aviNetworkSecurityPolicy,
+		Tenant:  networkSecurityPolicy.Tenant,
+		Model:   "NetworkSecurityPolicy",
+	}
+	if cache_obj != nil {
+		rest_op.Path = "/api/networksecuritypolicy/" + cache_obj.Uuid
+		rest_op.Method = utils.RestPut
+	}
+	return &rest_op
+}
+
+func (rest *RestOperations) AviNetworkSecurityPolicyDel(uuid string, tenant string) *utils.RestOp {
+	path := "/api/networksecuritypolicy/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: utils.RestDelete,
+		Tenant: tenant,
+		Model:  "NetworkSecurityPolicy",
+	}
+	utils.AviLog.Infof("NetworkSecurityPolicy DELETE Restop %v", utils.Stringify(rest_op))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviNetworkSecurityPolicyCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for NetworkSecurityPolicy", key)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "networksecuritypolicy", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find NetworkSecurityPolicy obj in resp %v", key, rest_op.Response)
+		return errors.New("NetworkSecurityPolicy not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var networkSecurityPolicy avimodels.NetworkSecurityPolicy
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			networkSecurityPolicy = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy)
+		case avimodels.NetworkSecurityPolicy:
+			networkSecurityPolicy = rest_op.Obj.(avimodels.NetworkSecurityPolicy)
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		networkSecurityPolicyCacheObj := avicache.AviNetworkSecurityPolicyCache{
+			Name:   name,
+			Tenant: rest_op.Tenant,
+			Uuid:   uuid,
+			CloudConfigCksum: lib.NetworkSecurityPolicyChecksum(name, lib.GetNetworkSecurityPolicySourceRanges(&networkSecurityPolicy),
+				emptyIngestionMarkers, networkSecurityPolicy.Markers, true),
+		}
+		if lastModifiedStr, ok := resp["_last_modified"].(string); ok {
+			networkSecurityPolicyCacheObj.LastModified = lastModifiedStr
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.NetworkSecurityPolicyCache.AviCacheAdd(k, &networkSecurityPolicyCacheObj)
+		utils.AviLog.Infof("key: %s, msg: added NetworkSecurityPolicy cache k %v val %v", key, k, utils.Stringify(networkSecurityPolicyCacheObj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviNetworkSecurityPolicyCacheDel(rest_op *utils.RestOp, key string) error {
+	networkSecurityPolicyKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Infof("key: %s, msg: deleting NetworkSecurityPolicy cache %v", key, networkSecurityPolicyKey)
+	rest.cache.NetworkSecurityPolicyCache.AviCacheDelete(networkSecurityPolicyKey)
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+)
+
+// PersistenceProfileCU creates or updates the client IP persistence profile of the pools of a virtualservice.
+// It must be called before the pools, which refer to the persistence profile, are created or updated.
+func (rest *RestOperations) PersistenceProfileCU(persistenceProfile *nodes.AviPersistenceProfileNode, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	if persistenceProfile == nil {
+		return rest_ops
+	}
+	persistenceProfileKey := avicache.NamespaceName{Namespace: namespace, Name: persistenceProfile.Name}
+	var persistenceProfileCacheObj *avicache.AviPersistenceProfileCache
+	if persistenceProfileCache, ok := rest.cache.PersistenceProfileCache.AviCacheGet(persistenceProfileKey); ok {
+		persistenceProfileCacheObj = persistenceProfileCache.(*avicache.AviPersistenceProfileCache)
+	}
+	if persistenceProfileCacheObj == nil || persistenceProfileCacheObj.CloudConfigCksum != persistenceProfile.GetCheckSum() {
+		if restOp := rest.AviPersistenceProfileBuild(persistenceProfile, persistenceProfileCacheObj); restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+// PersistenceProfileDelete deletes the client IP persistence profile of the pools of the virtualservice, if it
+// exists. It must be called after the pools stop referring to it.
+func (rest *RestOperations) PersistenceProfileDelete(vsName string, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	persistenceProfileKey := avicache.NamespaceName{Namespace: namespace, Name: lib.GetPersistenceProfileName(vsName)}
+	if persistenceProfileCache, ok := rest.cache.PersistenceProfileCache.AviCacheGet(persistenceProfileKey); ok {
+		persistenceProfileCacheObj := persistenceProfileCache.(*avicache.AviPersistenceProfileCache)
+		utils.AviLog.Debugf("key: %s, msg: about to delete application persistence profile %s", key, persistenceProfileKey.Name)
+		restOp := rest.AviPersistenceProfileDel(persistenceProfileCacheObj.Uuid, namespace)
+		restOp.ObjName = persistenceProfileKey.Name
+		rest_ops = append(rest_ops, restOp)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) AviPersistenceProfileBuild(persistenceProfile *nodes.AviPersistenceProfileNode, cache_obj *avicache.AviPersistenceProfileCache) *utils.RestOp {
+	if lib.CheckObjectNameLength(persistenceProfile.Name, lib.ApplicationPersistenceProfile) {
+		utils.AviLog.Warnf("Not processing application persistence profile")
+		return nil
+	}
+	name := persistenceProfile.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", persistenceProfile.Tenant)
+	persistenceType := "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS"
+	timeout := persistenceProfile.Timeout
+
+	aviPersistenceProfile := avimodels.ApplicationPersistenceProfile{
+		Name:            &name,
+		TenantRef:       &tenant,
+		PersistenceType: &persistenceType,
+		IPPersistenceProfile: &avimodels.IPPersistenceProfile{
+			IPPersistentTimeout: &timeout,
+		},
+	}
+	aviPersistenceProfile.Markers = lib.GetAllMarkers(persistenceProfile.AviMarkers)
+
+	rest_op := utils.RestOp{
+		ObjName: persistenceProfile.Name,
+		Path:    "/api/applicationpersistenceprofile/",
+		Method:  utils.RestPost,
+		Obj:     aviPersistenceProfile,
+		Tenant:  persistenceProfile.Tenant,
+		Model:   "ApplicationPersistenceProfile",
+	}
+	if cache_obj != nil {
+		rest_op.Path = "/api/applicationpersistenceprofile/" + cache_obj.Uuid
+		rest_op.Method = utils.RestPut
+	}
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileDel(uuid string, tenant string) *utils.RestOp {
+	path := "/api/applicationpersistenceprofile/" + uuid
+	rest_op := utils.RestOp{
+		Path:   path,
+		Method: utils.RestDelete,
+		Tenant: tenant,
+		Model:  "ApplicationPersistenceProfile",
+	}
+	utils.AviLog.Infof("ApplicationPersistenceProfile DELETE Restop %v", utils.Stringify(rest_op))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for ApplicationPersistenceProfile", key)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems := rest.restOperator.RestRespArrToObjByType(rest_op, "applicationpersistenceprofile", key)
+	if resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find ApplicationPersistenceProfile obj in resp %v", key, rest_op.Response)
+		return errors.New("ApplicationPersistenceProfile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var persistenceProfile avimodels.ApplicationPersistenceProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			persistenceProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile)
+		case avimodels.ApplicationPersistenceProfile:
+			persistenceProfile = rest_op.Obj.(avimodels.ApplicationPersistenceProfile)
+		}
+		var timeout int32
+		if persistenceProfile.IPPersistenceProfile != nil && persistenceProfile.IPPersistenceProfile.IPPersistentTimeout != nil {
+			timeout = *persistenceProfile.IPPersistenceProfile.IPPersistentTimeout
+		}
+		emptyIngestionMarkers := utils.AviObjectMarkers{}
+		persistenceProfileCacheObj := avicache.AviPersistenceProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: lib.PersistenceProfileChecksum(name, timeout, emptyIngestionMarkers, persistenceProfile.Markers, true),
+		}
+		if lastModifiedStr, ok := resp["_last_modified"].(string); ok {
+			persistenceProfileCacheObj.LastModified = lastModifiedStr
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.PersistenceProfileCache.AviCacheAdd(k, &persistenceProfileCacheObj)
+		utils.AviLog.Infof("key: %s, msg: added ApplicationPersistenceProfile cache k %v val %v", key, k, utils.Stringify(persistenceProfileCacheObj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	persistenceProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Infof("key: %s, msg: deleting ApplicationPersistenceProfile cache %v", key, persistenceProfileKey)
+	rest.cache.PersistenceProfileCache.AviCacheDelete(persistenceProfileKey)
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"strconv"
 	"strings"
@@ -149,12 +150,28 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 			sn := server.ServerNode
 			s.ServerNode = &sn
 		}
+		if server.Hostname != "" {
+			hostname := server.Hostname
+			s.Hostname = &hostname
+			s.ResolveServerByDNS = proto.Bool(true)
+		}
+		if server.Draining {
+			// The disabled server does not receive new connections, while the existing ones are
+			// closed only after the graceful disable timeout of the pool.
+			enabled := false
+			s.Enabled = &enabled
+			gracefulDisableTimeout := int32(math.Ceil(lib.GetServerDrainPeriod().Minutes()))
+			pool.GracefulDisableTimeout = &gracefulDisableTimeout
+		}
 		pool.Servers = append(pool.Servers, &s)
 	}
 
 	// overwrite with healthmonitors provided by CRD
 	if len(pool_meta.HealthMonitors) > 0 {
 		pool.HealthMonitorRefs = pool_meta.HealthMonitors
+	} else if pool_meta.HealthMonitor != nil {
+		// health monitor derived from the readiness probe of the Pods
+		pool.HealthMonitorRefs = []string{fmt.Sprintf("/api/healthmonitor/?name=%s", pool_meta.HealthMonitor.Name)}
 	} else {
 		var hm string
 		if pool_meta.Protocol == utils.UDP {
@@ -280,6 +297,15 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 			}
 		}
 
+		var healthMonitorRefs []string
+		if refs, ok := resp["health_monitor_refs"].([]interface{}); ok {
+			for _, ref := range refs {
+				if refStr, ok := ref.(string); ok {
+					healthMonitorRefs = append(healthMonitorRefs, refStr)
+				}
+			}
+		}
+
 		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
 		oldCacheServiceMetadataCRD := lib.CRDMetadata{}
 		if poolCache, ok := rest.cache.PoolCache.AviCacheGet(k); ok {
@@ -289,13 +315,14 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 		}
 
 		pool_cache_obj := avicache.AviPoolCache{
-			Name:                 name,
-			Tenant:               rest_op.Tenant,
-			Uuid:                 uuid,
-			CloudConfigCksum:     cksum,
-			ServiceMetadataObj:   svc_mdata_obj,
-			PkiProfileCollection: pkiKey,
-			LastModified:         lastModifiedStr,
+			Name:                    name,
+			Tenant:                  rest_op.Tenant,
+			Uuid:                    uuid,
+			CloudConfigCksum:        cksum,
+			ServiceMetadataObj:      svc_mdata_obj,
+			PkiProfileCollection:    pkiKey,
+			HealthMonitorCollection: rest.cache.GetHealthMonitorCollection(healthMonitorRefs),
+			LastModified:            lastModifiedStr,
 		}
 		if lastModifiedStr == "" {
 			pool_cache_obj.InvalidData = true
@@ -354,6 +381,12 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 							if pool_cache_obj.ServiceMetadataObj.IsMCIIngress {
 								statusOption.ObjType = lib.MultiClusterIngress
 							}
+							if pool_cache_obj.ServiceMetadataObj.IsHTTPRoute {
+								statusOption.ObjType = lib.HTTPRoute
+							}
+							if pool_cache_obj.ServiceMetadataObj.IsTLSRoute {
+								statusOption.ObjType = lib.TLSRoute
+							}
 							utils.AviLog.Debugf("key: %s Publishing to status queue, options: %v", updateOptions.ServiceMetadata.IngressName, utils.Stringify(statusOption))
 							status.PublishToStatusQueue(updateOptions.ServiceMetadata.IngressName, statusOption)
 						}
@@ -436,6 +469,12 @@ func (rest *RestOperations) DeletePoolIngressStatus(poolKey avicache.NamespaceNa
 				if pool_cache_obj.ServiceMetadataObj.IsMCIIngress {
 					statusOption.ObjType = lib.MultiClusterIngress
 				}
+				if pool_cache_obj.ServiceMetadataObj.IsHTTPRoute {
+					statusOption.ObjType = lib.HTTPRoute
+				}
+				if pool_cache_obj.ServiceMetadataObj.IsTLSRoute {
+					statusOption.ObjType = lib.TLSRoute
+				}
 				utils.AviLog.Debugf("key: %s Publishing to status queue, options: %v", updateOptions.ServiceMetadata.IngressName, utils.Stringify(statusOption))
 				status.PublishToStatusQueue(updateOptions.ServiceMetadata.IngressName, statusOption)
 			}
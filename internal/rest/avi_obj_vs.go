@@ -58,6 +58,9 @@ func setDedicatedVSNodeProperties(vs *avimodels.VirtualService, vs_meta *nodes.A
 		// hostrule ref overrides defaults
 		vs.ApplicationProfileRef = &vs_meta.AppProfileRef
 	}
+	if vs_meta.ClientAuth != nil {
+		vs.ApplicationProfileRef = proto.String("/api/applicationprofile/?name=" + vs_meta.ClientAuth.Name)
+	}
 
 	if len(vs_meta.ICAPProfileRefs) != 0 {
 		vs.IcapRequestProfileRefs = vs_meta.ICAPProfileRefs
@@ -237,7 +240,11 @@ func (rest *RestOperations) AviVsBuild(vs_meta *nodes.AviVsNode, rest_method uti
 			}
 			vs.L4Policies = l4Policies
 		}
+		if vs_meta.NetworkSecurityPolicy != nil {
+			vs.NetworkSecurityPolicyRef = proto.String("/api/networksecuritypolicy/?name=" + vs_meta.NetworkSecurityPolicy.Name)
+		}
 		vs.AnalyticsPolicy = vs_meta.GetAnalyticsPolicy()
+		vs.RequestsRateLimit = BuildRequestsRateProfile(vs_meta.GetRequestsRateLimit())
 
 		var rest_ops []*utils.RestOp
 
@@ -285,6 +292,9 @@ func (rest *RestOperations) AviVsSniBuild(vs_meta *nodes.AviVsNode, rest_method
 		// hostrule ref overrides defaults
 		app_prof = vs_meta.AppProfileRef
 	}
+	if vs_meta.ClientAuth != nil {
+		app_prof = "/api/applicationprofile/?name=" + vs_meta.ClientAuth.Name
+	}
 
 	cloudRef := "/api/cloud?name=" + utils.CloudName
 	network_prof := "/api/networkprofile/?name=" + "System-TCP-Proxy"
@@ -308,6 +318,7 @@ func (rest *RestOperations) AviVsSniBuild(vs_meta *nodes.AviVsNode, rest_method
 		Enabled:               vs_meta.Enabled,
 	}
 	sniChild.AnalyticsPolicy = vs_meta.GetAnalyticsPolicy()
+	sniChild.RequestsRateLimit = BuildRequestsRateProfile(vs_meta.GetRequestsRateLimit())
 	if vs_meta.VrfContext != "" {
 		sniChild.VrfContextRef = proto.String("/api/vrfcontext?name=" + vs_meta.VrfContext)
 	}
@@ -503,6 +514,12 @@ func (rest *RestOperations) StatusUpdateForPool(restMethod utils.RestMethod, vs_
 						if pool_cache_obj.ServiceMetadataObj.IsMCIIngress {
 							statusOption.ObjType = lib.MultiClusterIngress
 						}
+						if pool_cache_obj.ServiceMetadataObj.IsHTTPRoute {
+							statusOption.ObjType = lib.HTTPRoute
+						}
+						if pool_cache_obj.ServiceMetadataObj.IsTLSRoute {
+							statusOption.ObjType = lib.TLSRoute
+						}
 						utils.AviLog.Debugf("key: %s Publishing to status queue, options: %v", updateOptions.ServiceMetadata.IngressName, utils.Stringify(statusOption))
 						status.PublishToStatusQueue(updateOptions.ServiceMetadata.IngressName, statusOption)
 					}
@@ -532,6 +549,9 @@ func (rest *RestOperations) StatusUpdateForVS(restMethod utils.RestMethod, vsCac
 		if lib.UseServicesAPI() {
 			statusOption.ObjType = lib.SERVICES_API
 		}
+		if serviceMetadataObj.IsGatewayAPI {
+			statusOption.ObjType = lib.GatewayAPIGateway
+		}
 		utils.AviLog.Infof("key: %s Publishing to status queue, options: %v", updateOptions.ServiceMetadata.Gateway, utils.Stringify(statusOption))
 		status.PublishToStatusQueue(updateOptions.ServiceMetadata.Gateway, statusOption)
 	case lib.ServiceTypeLBVS:
@@ -754,6 +774,9 @@ func (rest *RestOperations) AviVsCacheDel(rest_op *utils.RestOp, vsKey avicache.
 					Key:     key,
 					Options: &updateOptions,
 				}
+				if vs_cache_obj.ServiceMetadataObj.IsGatewayAPI {
+					statusOption.ObjType = lib.GatewayAPIGateway
+				}
 				utils.AviLog.Infof("key: %s Publishing to status queue, options: %v", updateOptions.ServiceMetadata.Gateway, utils.Stringify(statusOption))
 				status.PublishToStatusQueue(updateOptions.ServiceMetadata.Gateway, statusOption)
 				// The pools would have service metadata for backend services, corresponding to which
@@ -895,6 +918,9 @@ func (rest *RestOperations) GetIPAddrsFromCache(vsCache *avicache.AviVsCache) []
 			if found {
 				if len(vsvip_cache_obj.Fips) != 0 {
 					IPAddrs = vsvip_cache_obj.Fips
+				} else if len(vsvip_cache_obj.V6IPs) != 0 && len(vsvip_cache_obj.Vips) != 0 {
+					// Both the IPv4 and the IPv6 VIPs of a dual-stack vsvip are published.
+					IPAddrs = append(append([]string{}, vsvip_cache_obj.Vips...), vsvip_cache_obj.V6IPs...)
 				} else if len(vsvip_cache_obj.V6IPs) != 0 {
 					IPAddrs = vsvip_cache_obj.V6IPs
 				} else {
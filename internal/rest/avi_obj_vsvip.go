@@ -105,7 +105,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 
 			// This would throw an error for advl4 the error is propagated to the gateway status.
 			if vsvip_meta.IPAddress != "" {
-				vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
+				setVipStaticIP(vip, vsvip_meta.IPAddress)
 			}
 
 			if lib.IsPublicCloud() && lib.GetCloudType() != lib.CLOUD_GCP {
@@ -129,6 +129,9 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 					if vsvip_meta.VipNetworks[0].V6Cidr != "" {
 						lib.UpdateV6(vip, &vsvip_meta.VipNetworks[0])
 					}
+					if vsvip_meta.IPFamily != "" {
+						lib.UpdateVipIPFamily(vip, vsvip_meta.IPFamily)
+					}
 					if lib.GetCloudType() == lib.CLOUD_NSXT &&
 						lib.GetNSXTTransportZone() == lib.VLAN_TRANSPORT_ZONE {
 						setVipPlacementNetwork(vip, vsvip_meta.VipNetworks[0].Cidr, &networkRef)
@@ -170,7 +173,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 
 		// configuring static IP, from gateway.Addresses (advl4, svcapi) and service.loadBalancerIP (l4)
 		if vsvip_meta.IPAddress != "" {
-			vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
+			setVipStaticIP(&vip, vsvip_meta.IPAddress)
 		}
 
 		// selecting network with user input, in case user input is not provided AKO relies on
@@ -226,6 +229,10 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 					lib.UpdateV6(&vip, &vipNetwork)
 				}
 			}
+			// The IP families of a Service override the ones of the VIP network.
+			if vsvip_meta.IPFamily != "" {
+				lib.UpdateVipIPFamily(&vip, vsvip_meta.IPFamily)
+			}
 		}
 
 		if len(vips) == 0 {
@@ -713,6 +720,16 @@ func networkNamesToVips(vipNetworks []akov1alpha1.AviInfraSettingVipNetwork, ena
 	return vipList
 }
 
+// setVipStaticIP sets the static IP of the VIP, which is either an IPv4 or an IPv6 address.
+func setVipStaticIP(vip *avimodels.Vip, ipAddress string) {
+	ip := ipAddress
+	if utils.IsV4(ip) {
+		vip.IPAddress = &avimodels.IPAddr{Type: proto.String("V4"), Addr: &ip}
+	} else {
+		vip.Ip6Address = &avimodels.IPAddr{Type: proto.String("V6"), Addr: &ip}
+	}
+}
+
 func setVipPlacementNetwork(vip *avimodels.Vip, cidr string, networkRef *string) {
 	_, ipnet, _ := net.ParseCIDR(cidr)
 	addr := ipnet.IP.String()
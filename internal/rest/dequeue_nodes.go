@@ -51,6 +51,9 @@ func (rest *RestOperations) CleanupVS(key string, skipVS bool) {
 	namespace, name := utils.ExtractNamespaceObjectName(key)
 	vsKey := avicache.NamespaceName{Namespace: namespace, Name: name}
 	vs_cache_obj := rest.getVsCacheObj(vsKey, key)
+	if lib.IsDryRunEnabled() {
+		resetKeyPlan(key)
+	}
 	utils.AviLog.Infof("key: %s, msg: cleanup mode, removing all stale objects", key)
 	rest.DeleteVSOper(vsKey, vs_cache_obj, namespace, key, skipVS, false)
 	utils.AviLog.Infof("key: %s, msg: cleanup mode, stale object removal done", key)
@@ -58,6 +61,9 @@ func (rest *RestOperations) CleanupVS(key string, skipVS bool) {
 
 func (rest *RestOperations) DequeueNodes(key string) {
 	utils.AviLog.Infof("key: %s, msg: start rest layer sync.", key)
+	if lib.IsDryRunEnabled() {
+		resetKeyPlan(key)
+	}
 
 	// Got the key from the Graph Layer - let's fetch the model
 	ok, avimodelIntf := objects.SharedAviGraphLister().Get(key)
@@ -317,11 +323,16 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			// which shuld be the new SSLKeyCertCollection
 			sslkey_cert_delete, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, sslkey_cert_delete, namespace, rest_ops, key)
 		}
+		rest_ops = rest.PersistenceProfileCU(aviVsNode.PersistenceProfile, namespace, rest_ops, key)
 		pools_to_delete, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, vs_cache_obj, namespace, rest_ops, key)
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
 		ds_to_delete, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, vs_cache_obj, namespace, rest_ops, key)
 		l4pol_to_delete, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		if aviVsNode.Dedicated {
+			rest_ops = rest.ClientAuthCU(aviVsNode.ClientAuth, namespace, rest_ops, key)
+		}
+		rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicy, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -349,11 +360,16 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			_, rest_ops = rest.CACertCU(aviVsNode.CACertRefs, []avicache.NamespaceName{}, namespace, rest_ops, key)
 			_, rest_ops = rest.SSLKeyCertCU(aviVsNode.SSLKeyCertRefs, nil, namespace, rest_ops, key)
 		}
+		rest_ops = rest.PersistenceProfileCU(aviVsNode.PersistenceProfile, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.L4PolicyCU(aviVsNode.L4PolicyRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, nil, namespace, rest_ops, key)
+		if aviVsNode.Dedicated {
+			rest_ops = rest.ClientAuthCU(aviVsNode.ClientAuth, namespace, rest_ops, key)
+		}
+		rest_ops = rest.NetworkSecurityPolicyCU(aviVsNode.NetworkSecurityPolicy, namespace, rest_ops, key)
 
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuild(aviVsNode, utils.RestPost, nil, key)
@@ -381,12 +397,21 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	rest_ops = rest.VSVipDelete(vsvip_to_delete, namespace, rest_ops, key)
 	if aviVsNode.Dedicated {
 		rest_ops = rest.SSLKeyCertDelete(sslkey_cert_delete, namespace, rest_ops, key)
+		if aviVsNode.ClientAuth == nil {
+			rest_ops = rest.ClientAuthDelete(vsName, namespace, rest_ops, key)
+		}
 	}
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.DSDelete(ds_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
+	if aviVsNode.PersistenceProfile == nil {
+		rest_ops = rest.PersistenceProfileDelete(vsName, namespace, rest_ops, key)
+	}
+	if aviVsNode.NetworkSecurityPolicy == nil {
+		rest_ops = rest.NetworkSecurityPolicyDelete(vsName, namespace, rest_ops, key)
+	}
 	if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
 		return
 	}
@@ -513,6 +538,8 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 			if ok {
 				rest_ops = append(rest_ops, rest_op)
 			}
+			rest_ops = rest.ClientAuthDelete(vsKey.Name, namespace, rest_ops, key)
+			rest_ops = rest.NetworkSecurityPolicyDelete(vsKey.Name, namespace, rest_ops, key)
 		}
 		if !skipVSVip {
 			rest_ops = rest.VSVipDelete(vs_cache_obj.VSVipKeyCollection, namespace, rest_ops, key)
@@ -523,6 +550,7 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 		rest_ops = rest.L4PolicyDelete(vs_cache_obj.L4PolicyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.PersistenceProfileDelete(vsKey.Name, namespace, rest_ops, key)
 		success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, nil, key, false)
 		if success {
 			vsKeysPending := rest.cache.VsCacheMeta.AviGetAllKeys()
@@ -557,6 +585,7 @@ func (rest *RestOperations) deleteSniVs(vsKey avicache.NamespaceName, vs_cache_o
 		if ok {
 			rest_ops = append(rest_ops, rest_op)
 		}
+		rest_ops = rest.ClientAuthDelete(vsKey.Name, namespace, rest_ops, key)
 		rest_ops = rest.DataScriptDelete(vs_cache_obj.DSKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.SSLKeyCertDelete(vs_cache_obj.SSLKeyCertCollection, namespace, rest_ops, key)
 		rest_ops = rest.HTTPPolicyDelete(vs_cache_obj.HTTPKeyCollection, namespace, rest_ops, key)
@@ -569,6 +598,12 @@ func (rest *RestOperations) deleteSniVs(vsKey avicache.NamespaceName, vs_cache_o
 }
 
 func (rest *RestOperations) ExecuteRestAndPopulateCache(rest_ops []*utils.RestOp, aviObjKey avicache.NamespaceName, avimodel *nodes.AviObjectGraph, key string, isEvh bool, sslKey ...utils.NamespaceName) (bool, bool) {
+	if lib.IsDryRunEnabled() {
+		// The cache is not populated, hence the objects are planned again on each sync, and the
+		// Kubernetes objects are not updated with the status of the objects.
+		rest.planRestOperations(rest_ops, key)
+		return true, true
+	}
 	// Choose a avi client based on the model name hash. This would ensure that the same worker queue processes updates for a given VS all the time.
 	shardSize := lib.GetshardSize()
 	if shardSize == 0 {
@@ -729,6 +764,14 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviVrfCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VsVip" {
 			rest.AviVsVipCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheAdd(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheAdd(rest_op, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheAdd(rest_op, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheAdd(rest_op, key)
 		}
 
 	} else if (rest_op.Err == nil || aviErr.HttpStatusCode == 404) &&
@@ -752,6 +795,14 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviVsVipCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VSDataScriptSet" {
 			rest.AviDSCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheDel(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheDel(rest_op, key)
+		} else if rest_op.Model == "NetworkSecurityPolicy" {
+			rest.AviNetworkSecurityPolicyCacheDel(rest_op, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheDel(rest_op, key)
 		}
 	}
 }
@@ -772,12 +823,14 @@ func (rest *RestOperations) DataScriptDelete(dsToDelete []avicache.NamespaceName
 
 func (rest *RestOperations) PublishKeyToRetryLayer(parentVsKey string, key string) {
 	fastRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.FAST_RETRY_LAYER)
+	utils.TraceEnqueue(lib.FAST_RETRY_LAYER, parentVsKey, key)
 	fastRetryQueue.Workqueue[0].AddRateLimited(parentVsKey)
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to fast path retry queue: %s", key, parentVsKey)
 }
 
 func (rest *RestOperations) PublishKeyToSlowRetryLayer(parentVsKey string, key string) {
 	slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.SLOW_RETRY_LAYER)
+	utils.TraceEnqueue(lib.SLOW_RETRY_LAYER, parentVsKey, key)
 	slowRetryQueue.Workqueue[0].AddRateLimited(parentVsKey)
 	utils.AviLog.Infof("key: %s, msg: Published key with vs_key to slow path retry queue: %s", key, parentVsKey)
 }
@@ -948,6 +1001,54 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					rest_op.ObjName = PKIprofile
 				}
 				rest.AviPkiProfileCacheDel(rest_op, aviObjKey, key)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				if ApplicationProfile != "" {
+					rest_op.ObjName = ApplicationProfile
+				}
+				rest.AviAppProfileCacheDel(rest_op, key)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				if ApplicationPersistenceProfile != "" {
+					rest_op.ObjName = ApplicationPersistenceProfile
+				}
+				rest.AviPersistenceProfileCacheDel(rest_op, key)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				if NetworkSecurityPolicy != "" {
+					rest_op.ObjName = NetworkSecurityPolicy
+				}
+				rest.AviNetworkSecurityPolicyCacheDel(rest_op, key)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				if HealthMonitor != "" {
+					rest_op.ObjName = HealthMonitor
+				}
+				rest.AviHealthMonitorCacheDel(rest_op, key)
 			case "VirtualService":
 				rest.AviVsCacheDel(rest_op, aviObjKey, key)
 			case "VSDataScriptSet":
@@ -1033,6 +1134,42 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					PKIprofile = *rest_op.Obj.(avimodels.PKIprofile).Name
 				}
 				aviObjCache.AviPopulateOnePKICache(c, utils.CloudName, PKIprofile)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				aviObjCache.AviPopulateOneAppProfileCache(c, utils.CloudName, ApplicationProfile)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				aviObjCache.AviPopulateOnePersistenceProfileCache(c, utils.CloudName, ApplicationPersistenceProfile)
+			case "NetworkSecurityPolicy":
+				var NetworkSecurityPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					NetworkSecurityPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.NetworkSecurityPolicy).Name
+				case avimodels.NetworkSecurityPolicy:
+					NetworkSecurityPolicy = *rest_op.Obj.(avimodels.NetworkSecurityPolicy).Name
+				}
+				aviObjCache.AviPopulateOneNetworkSecurityPolicyCache(c, utils.CloudName, NetworkSecurityPolicy)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				aviObjCache.AviPopulateOneHealthMonitorCache(c, utils.CloudName, HealthMonitor)
 			case "VirtualService":
 				aviObjCache.AviObjOneVSCachePopulate(c, utils.CloudName, aviObjKey.Name)
 				vsObjMeta, ok := rest.cache.VsCacheMeta.AviCacheGet(aviObjKey)
@@ -1104,6 +1241,8 @@ func (rest *RestOperations) PoolDelete(pools_to_delete []avicache.NamespaceName,
 			if pkiProfile.Name != "" {
 				rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{pkiProfile}, namespace, rest_ops, key)
 			}
+			rest_ops = rest.HealthMonitorDelete(del_pool.Name, namespace, rest_ops, key)
+			rest_ops = rest.HTTPRuleHealthMonitorsDelete(pool_cache_obj.HealthMonitorCollection, namespace, rest_ops, key)
 		}
 	}
 	return rest_ops
@@ -1185,6 +1324,7 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 		utils.AviLog.Debugf("key: %s, msg: the cached pools are: %v", key, utils.Stringify(cache_pool_nodes))
 
 		for _, pool := range pool_nodes {
+			var pool_healthmonitor_delete []avicache.NamespaceName
 			// check in the pool cache to see if this pool exists in AVI
 			pool_key := avicache.NamespaceName{Namespace: namespace, Name: pool.Name}
 			found := utils.HasElem(cache_pool_nodes, pool_key)
@@ -1196,6 +1336,8 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 				if ok {
 					pool_cache_obj, _ := pool_cache.(*avicache.AviPoolCache)
 					pool_pkiprofile_delete, rest_ops = rest.PkiProfileCU(pool.PkiProfile, pool_cache_obj, namespace, rest_ops, key)
+					rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, namespace, rest_ops, key)
+					pool_healthmonitor_delete, rest_ops = rest.HTTPRuleHealthMonitorsCU(pool, pool_cache_obj, namespace, rest_ops, key)
 
 					// Cache found. Let's compare the checksums
 					utils.AviLog.Debugf("key: %s, msg: poolcache: %v", key, pool_cache_obj)
@@ -1213,12 +1355,18 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 			} else {
 				utils.AviLog.Debugf("key: %s, msg: pool %s not found in cache, operation: POST", key, pool.Name)
 				_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+				rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, namespace, rest_ops, key)
+				_, rest_ops = rest.HTTPRuleHealthMonitorsCU(pool, nil, namespace, rest_ops, key)
 				// Not found - it should be a POST call.
 				restOp := rest.AviPoolBuild(pool, nil, key)
 				if restOp != nil {
 					rest_ops = append(rest_ops, restOp)
 				}
 			}
+			if pool.HealthMonitor == nil {
+				rest_ops = rest.HealthMonitorDelete(pool.Name, namespace, rest_ops, key)
+			}
+			rest_ops = rest.HTTPRuleHealthMonitorsDelete(pool_healthmonitor_delete, namespace, rest_ops, key)
 			if len(pool_pkiprofile_delete) > 0 {
 				rest_ops = rest.PkiProfileDelete(pool_pkiprofile_delete, namespace, rest_ops, key)
 			}
@@ -1228,12 +1376,17 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 		// Everything is a POST call
 		for _, pool := range pool_nodes {
 			_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+			rest_ops = rest.HealthMonitorCU(pool.HealthMonitor, namespace, rest_ops, key)
+			_, rest_ops = rest.HTTPRuleHealthMonitorsCU(pool, nil, namespace, rest_ops, key)
 
 			utils.AviLog.Debugf("key: %s, msg: pool cache does not exist %s, operation: POST", key, pool.Name)
 			restOp := rest.AviPoolBuild(pool, nil, key)
 			if restOp != nil {
 				rest_ops = append(rest_ops, restOp)
 			}
+			if pool.HealthMonitor == nil {
+				rest_ops = rest.HealthMonitorDelete(pool.Name, namespace, rest_ops, key)
+			}
 		}
 
 	}
@@ -1307,6 +1460,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 				sni_pools_to_delete, rest_ops = rest.PoolCU(sni_node.PoolRefs, sni_cache_obj, namespace, rest_ops, key)
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
 					restOp := rest.AviVsBuild(sni_node, utils.RestPut, sni_cache_obj, key)
@@ -1324,6 +1478,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 			_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -1335,6 +1490,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		rest_ops = rest.HTTPPolicyDelete(http_policies_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		if sni_node.ClientAuth == nil {
+			rest_ops = rest.ClientAuthDelete(sni_node.Name, namespace, rest_ops, key)
+		}
 		utils.AviLog.Debugf("key: %s, msg: the SNI VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: sni child %s not found in cache and SNI parent also does not exist in cache", key, sni_node.Name)
@@ -1343,6 +1501,7 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		rest_ops = rest.ClientAuthCU(sni_node.ClientAuth, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -0,0 +1,286 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	PlannedCreate = "create"
+	PlannedUpdate = "update"
+	PlannedPatch  = "patch"
+	PlannedDelete = "delete"
+
+	redactedValue = "REDACTED"
+)
+
+// PlannedRestOp is a REST operation which AKO would have made to the Avi Controller, if it were not running in
+// the dry run mode.
+type PlannedRestOp struct {
+	Key       string          `json:"key"`
+	Operation string          `json:"operation"`
+	Model     string          `json:"model"`
+	Tenant    string          `json:"tenant"`
+	Name      string          `json:"name"`
+	Path      string          `json:"path"`
+	Object    interface{}     `json:"object,omitempty"`
+	Cached    interface{}     `json:"cached,omitempty"`
+	Changes   []PlannedChange `json:"changes,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// PlannedChange is a field of an object which differs between the object in the AviObjCache and the object
+// which would have been sent to the Avi Controller.
+type PlannedChange struct {
+	Field   string      `json:"field"`
+	Cached  interface{} `json:"cached"`
+	Planned interface{} `json:"planned"`
+}
+
+type restOpsPlan struct {
+	plansLock sync.RWMutex
+	// key synced by the rest layer, usually the model name tenant/virtual service name -> planned operations
+	plans map[string][]*PlannedRestOp
+}
+
+var dryRunPlan = &restOpsPlan{plans: make(map[string][]*PlannedRestOp)}
+
+// GetDryRunPlan returns the REST operations planned in the dry run mode, for each key synced by the rest layer.
+func GetDryRunPlan() map[string][]*PlannedRestOp {
+	dryRunPlan.plansLock.RLock()
+	defer dryRunPlan.plansLock.RUnlock()
+	plans := make(map[string][]*PlannedRestOp, len(dryRunPlan.plans))
+	for objKey, plannedOps := range dryRunPlan.plans {
+		plans[objKey] = plannedOps
+	}
+	return plans
+}
+
+// ResetDryRunPlan removes all the REST operations planned in the dry run mode.
+func ResetDryRunPlan() {
+	dryRunPlan.plansLock.Lock()
+	defer dryRunPlan.plansLock.Unlock()
+	dryRunPlan.plans = make(map[string][]*PlannedRestOp)
+}
+
+// resetKeyPlan removes the operations planned by the previous sync of the key. Since the cache is not updated
+// in the dry run mode, each sync plans all the operations needed for the key.
+func resetKeyPlan(key string) {
+	dryRunPlan.plansLock.Lock()
+	defer dryRunPlan.plansLock.Unlock()
+	delete(dryRunPlan.plans, key)
+}
+
+// planRestOperations records the rest_ops in the plan of the key, in place of calling the Avi Controller.
+func (rest *RestOperations) planRestOperations(rest_ops []*utils.RestOp, key string) {
+	if len(rest_ops) == 0 {
+		return
+	}
+	var plannedOps []*PlannedRestOp
+	for _, rest_op := range rest_ops {
+		plannedOp := rest.planRestOperation(rest_op, key)
+		utils.AviLog.Infof("key: %s, msg: dry run, planned %s of %s %s/%s, changes: %s", key, plannedOp.Operation,
+			plannedOp.Model, plannedOp.Tenant, plannedOp.Name, utils.Stringify(plannedOp.Changes))
+		plannedOps = append(plannedOps, plannedOp)
+	}
+
+	dryRunPlan.plansLock.Lock()
+	defer dryRunPlan.plansLock.Unlock()
+	dryRunPlan.plans[key] = append(dryRunPlan.plans[key], plannedOps...)
+}
+
+func (rest *RestOperations) planRestOperation(rest_op *utils.RestOp, key string) *PlannedRestOp {
+	plannedOp := &PlannedRestOp{
+		Key:       key,
+		Model:     rest_op.Model,
+		Tenant:    rest_op.Tenant,
+		Name:      rest_op.ObjName,
+		Path:      rest_op.Path,
+		Timestamp: time.Now(),
+	}
+	switch rest_op.Method {
+	case utils.RestPost:
+		plannedOp.Operation = PlannedCreate
+	case utils.RestPut:
+		plannedOp.Operation = PlannedUpdate
+	case utils.RestPatch:
+		plannedOp.Operation = PlannedPatch
+	case utils.RestDelete:
+		plannedOp.Operation = PlannedDelete
+	default:
+		plannedOp.Operation = string(rest_op.Method)
+	}
+
+	var planned map[string]interface{}
+	if rest_op.Obj != nil {
+		planned = restOpObjectFields(rest_op)
+		plannedOp.Object = planned
+		if name, ok := planned["name"].(string); ok && plannedOp.Name == "" {
+			plannedOp.Name = name
+		}
+	}
+
+	aviCache := rest.cacheForModel(rest_op.Model)
+	if aviCache == nil {
+		return plannedOp
+	}
+	if plannedOp.Name == "" && rest_op.Method == utils.RestDelete {
+		// The path of the deletes ends with the uuid of the object.
+		uuid := rest_op.Path[strings.LastIndex(rest_op.Path, "/")+1:]
+		if name, found := aviCache.AviCacheGetNameByUuid(uuid); found {
+			plannedOp.Name = name.(string)
+		} else if vsKey, found := aviCache.AviCacheGetKeyByUuid(uuid); found {
+			plannedOp.Name = vsKey.(avicache.NamespaceName).Name
+		}
+	}
+	if cached, found := aviCache.AviCacheGet(avicache.NamespaceName{Namespace: rest_op.Tenant, Name: plannedOp.Name}); found && cached != nil {
+		if vsCache, ok := cached.(*avicache.AviVsCache); ok {
+			if vsCopy, ok := vsCache.GetVSCopy(); ok {
+				cached = vsCopy
+			}
+		}
+		plannedOp.Cached = cached
+		if planned != nil {
+			plannedOp.Changes = diffCachedObject(cached, planned)
+		}
+	}
+	return plannedOp
+}
+
+// restOpObjectFields returns the fields of the object of the rest_op, as they are sent to the Avi Controller,
+// with the private keys redacted.
+func restOpObjectFields(rest_op *utils.RestOp) map[string]interface{} {
+	obj := rest_op.Obj
+	if macro, ok := obj.(utils.AviRestObjMacro); ok {
+		obj = macro.Data
+	}
+	fields := make(map[string]interface{})
+	objJSON, err := json.Marshal(obj)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal the %s object: %v", rest_op.Model, err)
+		return fields
+	}
+	if err = json.Unmarshal(objJSON, &fields); err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal the %s object: %v", rest_op.Model, err)
+		return fields
+	}
+	if _, ok := fields["key"]; ok && rest_op.Model == "SSLKeyAndCertificate" {
+		fields["key"] = redactedValue
+	}
+	return fields
+}
+
+// diffCachedObject compares the fields of the cached object with the fields of the planned object of the same name,
+// ignoring the case and the underscores, such as CloudConfigCksum and cloud_config_cksum. The AviObjCache holds
+// the checksums and the references of the objects, hence only the fields of the cached object which are strings,
+// numbers, booleans or lists of these are compared.
+func diffCachedObject(cached interface{}, planned map[string]interface{}) []PlannedChange {
+	cachedValue := reflect.Indirect(reflect.ValueOf(cached))
+	if cachedValue.Kind() != reflect.Struct {
+		return nil
+	}
+	plannedFields := make(map[string]string, len(planned))
+	for field := range planned {
+		plannedFields[normalizeFieldName(field)] = field
+	}
+
+	var changes []PlannedChange
+	for i := 0; i < cachedValue.NumField(); i++ {
+		structField := cachedValue.Type().Field(i)
+		if structField.PkgPath != "" || !isComparableField(cachedValue.Field(i)) {
+			continue
+		}
+		plannedField, ok := plannedFields[normalizeFieldName(structField.Name)]
+		if !ok {
+			continue
+		}
+		cachedFieldValue := cachedValue.Field(i).Interface()
+		plannedFieldValue := planned[plannedField]
+		if fmt.Sprint(cachedFieldValue) != fmt.Sprint(plannedFieldValue) {
+			changes = append(changes, PlannedChange{
+				Field:   plannedField,
+				Cached:  cachedFieldValue,
+				Planned: plannedFieldValue,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func isComparableField(value reflect.Value) bool {
+	kind := value.Kind()
+	if kind == reflect.Slice {
+		kind = value.Type().Elem().Kind()
+	}
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func normalizeFieldName(field string) string {
+	return strings.ToLower(strings.ReplaceAll(field, "_", ""))
+}
+
+func (rest *RestOperations) cacheForModel(model string) *avicache.AviCache {
+	switch model {
+	case "VirtualService":
+		return rest.cache.VsCacheMeta
+	case "Pool":
+		return rest.cache.PoolCache
+	case "PoolGroup":
+		return rest.cache.PgCache
+	case "VsVip":
+		return rest.cache.VSVIPCache
+	case "HTTPPolicySet":
+		return rest.cache.HTTPPolicyCache
+	case "L4PolicySet":
+		return rest.cache.L4PolicyCache
+	case "VSDataScriptSet":
+		return rest.cache.DSCache
+	case "SSLKeyAndCertificate":
+		return rest.cache.SSLKeyCache
+	case "PKIprofile":
+		return rest.cache.PKIProfileCache
+	case "ApplicationProfile":
+		return rest.cache.AppProfileCache
+	case "ApplicationPersistenceProfile":
+		return rest.cache.PersistenceProfileCache
+	case "NetworkSecurityPolicy":
+		return rest.cache.NetworkSecurityPolicyCache
+	case "HealthMonitor":
+		return rest.cache.HealthMonitorCache
+	case "VrfContext":
+		return rest.cache.VrfCache
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ package rest
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -196,7 +197,7 @@ func (v *L4PolicySetSchema) removePoolRef(op *utils.RestOp, objRef string) bool
 	}
 
 	for i, rule := range l4PolSet.L4ConnectionPolicy.Rules {
-		if strings.EqualFold(*rule.Action.SelectPool.PoolRef, objRef) {
+		if rule.Action.SelectPool.PoolRef != nil && strings.EqualFold(*rule.Action.SelectPool.PoolRef, objRef) {
 			l4PolSet.L4ConnectionPolicy.Rules = append(l4PolSet.L4ConnectionPolicy.Rules[:i], l4PolSet.L4ConnectionPolicy.Rules[i+1:]...)
 		}
 	}
@@ -284,6 +285,7 @@ func (l *leader) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp,
 			SetVersion := session.SetVersion(op.Version)
 			SetVersion(c.AviSession)
 		}
+		start := time.Now()
 		switch op.Method {
 		case utils.RestPost:
 			op.Err = c.AviSession.Post(op.Path, op.Obj, &op.Response)
@@ -300,6 +302,8 @@ func (l *leader) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp,
 			utils.AviLog.Errorf("Unknown RestOp %v", op.Method)
 			op.Err = fmt.Errorf("Unknown RestOp %v", op.Method)
 		}
+		utils.ObserveAviRestOperation(op.Model, string(op.Method), restOpStatusCode(op.Err), start)
+		traceAviRestOperation(key, op, op.Method, start)
 		if op.Err != nil {
 			utils.AviLog.Warnf("key: %s, msg: RestOp method %v path %v tenant %v Obj %s returned err %s with response %s",
 				key, op.Method, op.Path, op.Tenant, utils.Stringify(op.Obj), utils.Stringify(op.Err), utils.Stringify(op.Response))
@@ -374,7 +378,10 @@ func (f *follower) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp
 		}
 
 		utils.AviLog.Debugf("key: %s, msg: Got a REST operation: %s, %s", key, op.ObjName, op.Path)
+		start := time.Now()
 		op.Err = c.AviSession.Get(op.Path, &op.Response)
+		utils.ObserveAviRestOperation(op.Model, string(utils.RestGet), restOpStatusCode(op.Err), start)
+		traceAviRestOperation(key, op, utils.RestGet, start)
 		if op.Err != nil {
 			utils.AviLog.Warnf("key: %s, msg: RestOp method %v path %v tenant %v Obj %s returned err %s with response %s",
 				key, op.Method, op.Path, op.Tenant, utils.Stringify(op.Obj), utils.Stringify(op.Err), utils.Stringify(op.Response))
@@ -416,3 +423,30 @@ func (f *follower) AviRestOperate(c *clients.AviClient, rest_ops []*utils.RestOp
 	}
 	return nil
 }
+
+// restOpStatusCode returns the HTTP status code of a failed rest operation, or error if the request did not get a
+// response, for the metrics.
+func restOpStatusCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if aviErr, ok := err.(session.AviError); ok {
+		return strconv.Itoa(aviErr.HttpStatusCode)
+	}
+	return "error"
+}
+
+// traceAviRestOperation records the span of a REST call to the Avi Controller for the key, since start.
+func traceAviRestOperation(key string, op *utils.RestOp, method utils.RestMethod, start time.Time) {
+	span := utils.StartSpan("avi.rest", key, start)
+	span.SetClientKind()
+	span.SetAttribute("http.method", string(method))
+	span.SetAttribute("ako.avi.model", op.Model)
+	span.SetAttribute("ako.avi.object", op.ObjName)
+	span.SetAttribute("ako.avi.tenant", op.Tenant)
+	if statusCode := restOpStatusCode(op.Err); statusCode != "" {
+		span.SetAttribute("http.status_code", statusCode)
+	}
+	span.SetError(op.Err)
+	span.End()
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Status of the gateway.networking.k8s.io objects. The Accepted and ResolvedRefs conditions are set
+// synchronously while validating the objects, the Gateway addresses and Programmed condition are set
+// from the status queue once the virtual services hosting the routes are created in Avi.
+
+// SetGatewayAPICondition adds or updates the condition of conditionType in conditions.
+func SetGatewayAPICondition(conditions *[]metav1.Condition, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string, generation int64) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
+
+// UpdateGatewayAPIRouteStatus publishes the VIPs of the virtual services hosting the HTTPRoutes and
+// TLSRoutes in the addresses of their parent Gateways.
+func (l *leader) UpdateGatewayAPIRouteStatus(options []UpdateOptions, bulk bool) {
+	for _, option := range options {
+		if len(option.Vip) == 0 {
+			continue
+		}
+		ns, name := option.ServiceMetadata.Namespace, option.ServiceMetadata.IngressName
+		routeKey := lib.GetGatewayAPIRouteKey(lib.HTTPRoute, ns, name)
+		if option.ServiceMetadata.IsTLSRoute {
+			routeKey = lib.GetGatewayAPIRouteKey(lib.TLSRoute, ns, name)
+		}
+		_, gateways := objects.GatewayAPIObjLister().GetRouteToGateways(routeKey)
+		for _, gwNSName := range gateways {
+			updateGatewayAPIGatewayAddresses(option.Key, gwNSName, option.Vip)
+		}
+	}
+}
+
+// DeleteGatewayAPIRouteStatus resets the addresses of the AKO managed Gateways which have no routes attached to them anymore.
+// The conditions of the routes are maintained while validating the routes, and are removed along with the object.
+func (l *leader) DeleteGatewayAPIRouteStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error {
+	resetGatewayAPIGatewayAddresses(key, objects.GatewayAPIObjLister().GwGwClassStore.GetAllKeys())
+	return nil
+}
+
+// UpdateGatewayAPIGatewayStatus publishes the VIP of the virtual service created for the TCP and UDP listeners of the Gateway.
+func (l *leader) UpdateGatewayAPIGatewayStatus(options []UpdateOptions, bulk bool) {
+	for _, option := range options {
+		if len(option.Vip) == 0 {
+			continue
+		}
+		updateGatewayAPIGatewayAddresses(option.Key, option.ServiceMetadata.Gateway, option.Vip)
+	}
+}
+
+func (l *leader) DeleteGatewayAPIGatewayStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error {
+	resetGatewayAPIGatewayAddresses(key, []string{svcMetadataObj.Gateway})
+	return nil
+}
+
+func (f *follower) UpdateGatewayAPIRouteStatus(options []UpdateOptions, bulk bool) {
+	for _, option := range options {
+		utils.AviLog.Debugf("key: %s, AKO is not a leader, not updating the Gateway API route status", option.Key)
+	}
+}
+
+func (f *follower) DeleteGatewayAPIRouteStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error {
+	utils.AviLog.Debugf("key: %s, AKO is not a leader, not deleting the Gateway API route status", key)
+	return nil
+}
+
+func (f *follower) UpdateGatewayAPIGatewayStatus(options []UpdateOptions, bulk bool) {
+	for _, option := range options {
+		utils.AviLog.Debugf("key: %s, AKO is not a leader, not updating the Gateway API gateway status", option.Key)
+	}
+}
+
+func (f *follower) DeleteGatewayAPIGatewayStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error {
+	utils.AviLog.Debugf("key: %s, AKO is not a leader, not deleting the Gateway API gateway status", key)
+	return nil
+}
+
+// updateGatewayAPIGatewayAddresses adds the vips to the addresses of the Gateway and sets the Programmed condition.
+func updateGatewayAPIGatewayAddresses(key, gwNSName string, vips []string) {
+	gwNS, gwName := splitNSName(gwNSName)
+	gw, err := lib.GetGatewayAPIGateway(gwNS, gwName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Could not get the Gateway %s for status update: %v", key, gwNSName, err)
+		return
+	}
+	gwStatus := gw.Status.DeepCopy()
+	addressType := gatewayv1.IPAddressType
+	for _, vip := range vips {
+		found := false
+		for _, address := range gwStatus.Addresses {
+			if address.Value == vip {
+				found = true
+				break
+			}
+		}
+		if !found {
+			gwStatus.Addresses = append(gwStatus.Addresses, gatewayv1.GatewayStatusAddress{Type: &addressType, Value: vip})
+		}
+	}
+	SetGatewayAPICondition(&gwStatus.Conditions, gatewayv1.GatewayConditionProgrammed, metav1.ConditionTrue, gatewayv1.GatewayReasonProgrammed, "Virtual Service has been created", gw.Generation)
+	UpdateGatewayAPIGatewayStatusObject(key, gw, gwStatus)
+}
+
+// resetGatewayAPIGatewayAddresses resets the addresses of the gateways, which have no routes attached to them.
+func resetGatewayAPIGatewayAddresses(key string, gateways []string) {
+	for _, gwNSName := range gateways {
+		if found, _ := objects.GatewayAPIObjLister().GetGatewayToRoutes(gwNSName); found {
+			continue
+		}
+		gwNS, gwName := splitNSName(gwNSName)
+		gw, err := lib.GetGatewayAPIGateway(gwNS, gwName)
+		if err != nil {
+			continue
+		}
+		if len(gw.Status.Addresses) == 0 {
+			continue
+		}
+		gwStatus := gw.Status.DeepCopy()
+		gwStatus.Addresses = nil
+		SetGatewayAPICondition(&gwStatus.Conditions, gatewayv1.GatewayConditionProgrammed, metav1.ConditionFalse, gatewayv1.GatewayReasonAddressNotAssigned, "No routes are attached to the Gateway", gw.Generation)
+		UpdateGatewayAPIGatewayStatusObject(key, gw, gwStatus)
+	}
+}
+
+func splitNSName(nsName string) (string, string) {
+	nsNameSplit := strings.SplitN(nsName, "/", 2)
+	if len(nsNameSplit) != 2 {
+		return "", nsName
+	}
+	return nsNameSplit[0], nsNameSplit[1]
+}
+
+// UpdateGatewayAPIGatewayClassStatusObject patches the status of the GatewayClass.
+func UpdateGatewayAPIGatewayClassStatusObject(key string, gwClass *gatewayv1.GatewayClass, updateStatus *gatewayv1.GatewayClassStatus) {
+	if reflect.DeepEqual(gwClass.Status.Conditions, updateStatus.Conditions) {
+		return
+	}
+	patchGatewayAPIStatus(key, lib.GatewayClassGVR, "", gwClass.Name, updateStatus)
+}
+
+// UpdateGatewayAPIGatewayStatusObject patches the status of the Gateway.
+func UpdateGatewayAPIGatewayStatusObject(key string, gw *gatewayv1.Gateway, updateStatus *gatewayv1.GatewayStatus) {
+	if reflect.DeepEqual(&gw.Status, updateStatus) {
+		return
+	}
+	patchGatewayAPIStatus(key, lib.GatewayGVR, gw.Namespace, gw.Name, updateStatus)
+}
+
+// UpdateGatewayAPIRouteStatusObject patches the status of the route of the kind, the status of all the
+// route kinds is made up of the parent statuses.
+func UpdateGatewayAPIRouteStatusObject(key, kind, namespace, name string, oldStatus, updateStatus *gatewayv1.RouteStatus) {
+	if reflect.DeepEqual(oldStatus, updateStatus) {
+		return
+	}
+	var gvr schema.GroupVersionResource
+	switch kind {
+	case lib.HTTPRoute:
+		gvr = lib.HTTPRouteGVR
+	case lib.TLSRoute:
+		gvr = lib.TLSRouteGVR
+	case lib.TCPRoute:
+		gvr = lib.TCPRouteGVR
+	case lib.UDPRoute:
+		gvr = lib.UDPRouteGVR
+	default:
+		utils.AviLog.Warnf("key: %s, msg: unsupported route kind %s for status update", key, kind)
+		return
+	}
+	patchGatewayAPIStatus(key, gvr, namespace, name, updateStatus)
+}
+
+func patchGatewayAPIStatus(key string, gvr schema.GroupVersionResource, namespace, name string, updateStatus interface{}, retryNum ...int) {
+	if !lib.AKOControlConfig().IsLeader() {
+		utils.AviLog.Debugf("key: %s, AKO is not a leader, not updating the status of %s %s/%s", key, gvr.Resource, namespace, name)
+		return
+	}
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: status update of %s %s/%s retried 5 times, aborting", key, gvr.Resource, namespace, name)
+			return
+		}
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": updateStatus,
+	})
+	var err error
+	if namespace == "" {
+		_, err = lib.GetDynamicClientSet().Resource(gvr).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	} else {
+		_, err = lib.GetDynamicClientSet().Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	}
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: %d there was an error in updating the %s %s/%s status: %+v", key, retry, gvr.Resource, namespace, name, err)
+		if strings.Contains(err.Error(), utils.K8S_ETIMEDOUT) {
+			patchGatewayAPIStatus(key, gvr, namespace, name, updateStatus, retry+1)
+		}
+		return
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the %s %s/%s status %+v", key, gvr.Resource, namespace, name, utils.Stringify(updateStatus))
+}
@@ -45,6 +45,12 @@ type StatusPublisher interface {
 	UpdateMultiClusterIngressStatusAndAnnotation(key string, option *UpdateOptions)
 	DeleteMultiClusterIngressStatusAndAnnotation(key string, option *UpdateOptions)
 
+	UpdateGatewayAPIRouteStatus(options []UpdateOptions, bulk bool)
+	DeleteGatewayAPIRouteStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error
+
+	UpdateGatewayAPIGatewayStatus(options []UpdateOptions, bulk bool)
+	DeleteGatewayAPIGatewayStatus(svcMetadataObj lib.ServiceMetadataObj, key string) error
+
 	AddStatefulSetAnnotation(reason string)
 	ResetStatefulSetAnnotation()
 }
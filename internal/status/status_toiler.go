@@ -32,6 +32,7 @@ type StatusOptions struct {
 func PublishToStatusQueue(key string, statusOption StatusOptions) {
 	statusQueue := utils.SharedWorkQueue().GetQueueByName(utils.StatusQueue)
 	bkt := utils.Bkt(key, statusQueue.NumWorkers)
+	utils.TraceEnqueue(utils.StatusQueue, statusOption.Key, statusOption.Key)
 	statusQueue.Workqueue[bkt].AddRateLimited(statusOption)
 }
 
@@ -85,6 +86,18 @@ func (l *leader) DequeueStatus(objIntf interface{}) error {
 		} else if obj.Op == lib.DeleteStatus {
 			l.DeleteMultiClusterIngressStatusAndAnnotation(obj.Key, obj.Options)
 		}
+	case lib.HTTPRoute, lib.TLSRoute:
+		if obj.Op == lib.UpdateStatus {
+			l.UpdateGatewayAPIRouteStatus([]UpdateOptions{*obj.Options}, false)
+		} else if obj.Op == lib.DeleteStatus {
+			l.DeleteGatewayAPIRouteStatus(obj.Options.ServiceMetadata, obj.Options.Key)
+		}
+	case lib.GatewayAPIGateway:
+		if obj.Op == lib.UpdateStatus {
+			l.UpdateGatewayAPIGatewayStatus([]UpdateOptions{*obj.Options}, false)
+		} else if obj.Op == lib.DeleteStatus {
+			l.DeleteGatewayAPIGatewayStatus(obj.Options.ServiceMetadata, obj.Options.Key)
+		}
 	}
 	return nil
 }
@@ -60,42 +60,46 @@ func (l *leader) UpdateL4LBStatus(options []UpdateOptions, bulk bool) {
 			if len(svcMetadata.HostNames) > 0 {
 				svcHostname = svcMetadata.HostNames[0]
 			}
+			// All the VIPs are published, which are the IPv4 and the IPv6 VIPs of a dual-stack Service.
+			var lbIngress []corev1.LoadBalancerIngress
 			for _, vip := range option.Vip {
-				service.Status = corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{
-							IP:       vip,
-							Hostname: svcHostname,
-						}}}}
+				lbIngress = append(lbIngress, corev1.LoadBalancerIngress{
+					IP:       vip,
+					Hostname: svcHostname,
+				})
+			}
+			service.Status = corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: lbIngress,
+				}}
 
-				sameStatus, _, _ := compareLBStatus(oldServiceStatus, &service.Status.LoadBalancer)
-				var updatedSvc *corev1.Service
-				var err error
-				if !sameStatus {
-					patchPayload, _ := json.Marshal(map[string]interface{}{
-						"status": service.Status,
-					})
+			sameStatus, _, _ := compareLBStatus(oldServiceStatus, &service.Status.LoadBalancer)
+			var updatedSvc *corev1.Service
+			var err error
+			if !sameStatus {
+				patchPayload, _ := json.Marshal(map[string]interface{}{
+					"status": service.Status,
+				})
 
-					updatedSvc, err = utils.GetInformers().ClientSet.CoreV1().Services(service.Namespace).Patch(context.TODO(), service.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
-					if err != nil {
-						utils.AviLog.Errorf("key: %s, msg: there was an error in updating the loadbalancer status: %v", key, err)
+				updatedSvc, err = utils.GetInformers().ClientSet.CoreV1().Services(service.Namespace).Patch(context.TODO(), service.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+				if err != nil {
+					utils.AviLog.Errorf("key: %s, msg: there was an error in updating the loadbalancer status: %v", key, err)
+				} else {
+					if len(service.Status.LoadBalancer.Ingress) > 0 {
+						lib.AKOControlConfig().EventRecorder().Eventf(service, corev1.EventTypeNormal, lib.Synced, "Added virtualservice %s for %s", option.VSName, service.Name)
 					} else {
-						if len(service.Status.LoadBalancer.Ingress) > 0 {
-							lib.AKOControlConfig().EventRecorder().Eventf(service, corev1.EventTypeNormal, lib.Synced, "Added virtualservice %s for %s", option.VSName, service.Name)
-						} else {
-							lib.AKOControlConfig().EventRecorder().Eventf(service, corev1.EventTypeNormal, lib.Removed, "Removed virtualservice for %s", service.Name)
-						}
-						utils.AviLog.Infof("key: %s, msg: Successfully updated the status of serviceLB: %s old: %+v new %+v",
-							key, option.IngSvc, oldServiceStatus.Ingress, service.Status.LoadBalancer.Ingress)
+						lib.AKOControlConfig().EventRecorder().Eventf(service, corev1.EventTypeNormal, lib.Removed, "Removed virtualservice for %s", service.Name)
 					}
-				} else {
-					utils.AviLog.Debugf("key: %s, msg: No changes detected in service status. old: %+v new: %+v",
-						key, oldServiceStatus.Ingress, service.Status.LoadBalancer.Ingress)
+					utils.AviLog.Infof("key: %s, msg: Successfully updated the status of serviceLB: %s old: %+v new %+v",
+						key, option.IngSvc, oldServiceStatus.Ingress, service.Status.LoadBalancer.Ingress)
 				}
+			} else {
+				utils.AviLog.Debugf("key: %s, msg: No changes detected in service status. old: %+v new: %+v",
+					key, oldServiceStatus.Ingress, service.Status.LoadBalancer.Ingress)
+			}
 
-				if err = updateSvcAnnotations(updatedSvc, option, service, svcHostname); err != nil {
-					utils.AviLog.Errorf("key: %s, msg: there was an error in updating the service annotations: %v", key, err)
-				}
+			if err = updateSvcAnnotations(updatedSvc, option, service, svcHostname); err != nil {
+				utils.AviLog.Errorf("key: %s, msg: there was an error in updating the service annotations: %v", key, err)
 			}
 		}
 		skipDelete[option.IngSvc] = true
@@ -168,6 +172,12 @@ func (l *leader) DeleteL4LBStatus(svc_mdata_obj lib.ServiceMetadataObj, vsName,
 			continue
 		}
 
+		// The status of a service of a loadBalancerClass not handled by AKO is owned by another load balancer implementation.
+		if serviceObj := serviceMap[service]; serviceObj != nil && serviceObj.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+			!lib.IsServiceLBClassAccepted(serviceObj) {
+			continue
+		}
+
 		updatedSvc, err := utils.GetInformers().ClientSet.CoreV1().Services(serviceNSName[0]).Patch(context.TODO(), serviceNSName[1], types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
 		if err != nil {
 			utils.AviLog.Warnf("key: %s, msg: there was an error in resetting the loadbalancer status: %v", key, err)
@@ -230,7 +240,7 @@ func getServices(serviceNSNames []string, bulk bool, retryNum ...int) map[string
 		for i := range serviceLBList {
 			svc := serviceLBList[i].DeepCopy()
 			if !lib.UseServicesAPI() {
-				if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && lib.IsServiceLBClassAccepted(svc) {
 					//Do not perform status update on service if namespace is not accepted.
 					if utils.CheckIfNamespaceAccepted(svc.Namespace) {
 						serviceMap[svc.Namespace+"/"+svc.Name] = svc
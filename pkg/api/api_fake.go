@@ -31,6 +31,7 @@ func (a *FakeApiServer) initModels() {
 	// add common models in ApiServer
 	genericModels := []models.ApiModel{
 		models.RestStatus,
+		models.Metrics,
 	}
 	a.Models = append(a.Models, genericModels...)
 
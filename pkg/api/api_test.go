@@ -17,9 +17,12 @@ package api
 import (
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 )
@@ -56,3 +59,35 @@ func TestApiServerStatusModel(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// TestApiServerMetricsModel tests that the AKO metrics are served in the Prometheus format
+func TestApiServerMetricsModel(t *testing.T) {
+	metricsApi := NewServer("12346", []models.ApiModel{})
+	metricsApi.InitApi()
+	defer metricsApi.ShutDown()
+
+	// wait for the API server to start listening
+	for i := 0; i < 50; i++ {
+		if conn, err := net.Dial("tcp", "localhost:12346"); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	resp, err := http.Get("http://localhost:12346/metrics")
+	if err != nil {
+		t.Fatalf("error in getting the metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("error in reading the metrics: %v", err)
+	}
+
+	for _, metric := range []string{"ako_leader", "go_goroutines"} {
+		if !strings.Contains(string(body), metric) {
+			t.Errorf("metric %s not found", metric)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+var Metrics = &MetricsModel{}
+
+// MetricsModel implements ApiModel, and serves the metrics of utils.MetricsRegistry in the Prometheus format.
+type MetricsModel struct {
+	handler http.Handler
+}
+
+func (a *MetricsModel) InitModel() {
+	if a.handler == nil {
+		a.handler = promhttp.HandlerFor(utils.MetricsRegistry, promhttp.HandlerOpts{})
+	}
+}
+
+func (a *MetricsModel) ApiOperationMap() []OperationMap {
+	var operationMapList []OperationMap
+
+	get := OperationMap{
+		Route:  "/metrics",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			a.handler.ServeHTTP(w, r)
+		},
+	}
+
+	operationMapList = append(operationMapList, get)
+	return operationMapList
+}
@@ -34,9 +34,10 @@ type AviInfraSetting struct {
 
 // AviInfraSettingSpec consists of the main AviInfraSetting settings
 type AviInfraSettingSpec struct {
-	Network    AviInfraSettingNetwork `json:"network,omitempty"`
-	SeGroup    AviInfraSettingSeGroup `json:"seGroup,omitempty"`
-	L7Settings AviInfraL7Settings     `json:"l7Settings,omitempty"`
+	Network       AviInfraSettingNetwork       `json:"network,omitempty"`
+	SeGroup       AviInfraSettingSeGroup       `json:"seGroup,omitempty"`
+	L7Settings    AviInfraL7Settings           `json:"l7Settings,omitempty"`
+	ZoneAwareness AviInfraSettingZoneAwareness `json:"zoneAwareness,omitempty"`
 }
 
 type AviInfraSettingNetwork struct {
@@ -66,6 +67,12 @@ type AviInfraL7Settings struct {
 	ShardSize string `json:"shardSize,omitempty"`
 }
 
+// AviInfraSettingZoneAwareness splits the pool servers by zone, in the order of preference of the zones,
+// so that the servers of a zone receive traffic only once the servers of the preferred zones are down.
+type AviInfraSettingZoneAwareness struct {
+	Zones []string `json:"zones,omitempty"`
+}
+
 // AviInfraSettingStatus holds the status of the AviInfraSetting
 type AviInfraSettingStatus struct {
 	Status string `json:"status,omitempty"`
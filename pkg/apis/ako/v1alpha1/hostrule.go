@@ -75,6 +75,31 @@ type HostRuleVirtualHost struct {
 	TCPSettings        *HostRuleTCPSettings     `json:"tcpSettings,omitempty"`
 	Aliases            []string                 `json:"aliases,omitempty"`
 	ICAPProfile        []string                 `json:"icapProfile,omitempty"`
+	RateLimit          *RateLimit               `json:"rateLimit,omitempty"`
+}
+
+// RateLimit limits the number of requests permitted in each period, per client IP
+// or per value of a request header, and takes the action on the requests above the limit
+type RateLimit struct {
+	Requests int32           `json:"requests,omitempty"`
+	Period   int32           `json:"period,omitempty"`
+	Burst    int32           `json:"burst,omitempty"`
+	Key      string          `json:"key,omitempty"`
+	Header   string          `json:"header,omitempty"`
+	Action   RateLimitAction `json:"action,omitempty"`
+}
+
+// RateLimitAction is the action taken on the requests above the rate limit
+type RateLimitAction struct {
+	Type     string             `json:"type,omitempty"`
+	Redirect *RateLimitRedirect `json:"redirect,omitempty"`
+}
+
+// RateLimitRedirect holds the target of the redirect for the requests above the rate limit
+type RateLimitRedirect struct {
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Path     string `json:"path,omitempty"`
 }
 
 // HostRuleTCPSettings allows for customizing TCP settings
@@ -95,6 +120,14 @@ type HostRuleTLS struct {
 	SSLKeyCertificate HostRuleSSLKeyCertificate `json:"sslKeyCertificate,omitempty"`
 	SSLProfile        string                    `json:"sslProfile,omitempty"`
 	Termination       string                    `json:"termination,omitempty"`
+	ClientAuth        *HostRuleClientAuth       `json:"clientAuth,omitempty"`
+}
+
+// HostRuleClientAuth holds the client certificate validation properties of the secure host.
+// The CA is either a K8s Secret with the CA bundle, or an Avi PKI profile reference.
+type HostRuleClientAuth struct {
+	Mode string         `json:"mode,omitempty"`
+	CA   HostRuleSecret `json:"ca,omitempty"`
 }
 
 // HostRuleSecret is required to provide distinction between Avi SSLKeyCertificate
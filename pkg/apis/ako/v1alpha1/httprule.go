@@ -39,11 +39,73 @@ type HTTPRuleSpec struct {
 
 // HTTPRulePaths has settings for a specific target path
 type HTTPRulePaths struct {
-	Target                 string           `json:"target,omitempty"`
-	LoadBalancerPolicy     HTTPRuleLBPolicy `json:"loadBalancerPolicy,omitempty"`
-	TLS                    HTTPRuleTLS      `json:"tls,omitempty"`
-	HealthMonitors         []string         `json:"healthMonitors,omitempty"`
-	ApplicationPersistence string           `json:"applicationPersistence,omitempty"`
+	Target                 string                  `json:"target,omitempty"`
+	LoadBalancerPolicy     HTTPRuleLBPolicy        `json:"loadBalancerPolicy,omitempty"`
+	TLS                    HTTPRuleTLS             `json:"tls,omitempty"`
+	HealthMonitors         []string                `json:"healthMonitors,omitempty"`
+	InlineHealthMonitors   []HTTPRuleHealthMonitor `json:"inlineHealthMonitors,omitempty"`
+	ApplicationPersistence string                  `json:"applicationPersistence,omitempty"`
+	Matches                []HTTPRuleMatch         `json:"matches,omitempty"`
+	RequestHeaders         *HTTPRuleHeaders        `json:"requestHeaders,omitempty"`
+	ResponseHeaders        *HTTPRuleHeaders        `json:"responseHeaders,omitempty"`
+	Rewrite                *HTTPRuleRewrite        `json:"rewrite,omitempty"`
+	RateLimit              *RateLimit              `json:"rateLimit,omitempty"`
+}
+
+// HTTPRuleHealthMonitor is a health monitor of the pools of the target path,
+// which AKO creates and deletes along with the pools
+type HTTPRuleHealthMonitor struct {
+	Name             string   `json:"name,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	SendString       string   `json:"sendString,omitempty"`
+	ResponseCodes    []string `json:"responseCodes,omitempty"`
+	MonitorPort      int32    `json:"monitorPort,omitempty"`
+	SendInterval     int32    `json:"sendInterval,omitempty"`
+	ReceiveTimeout   int32    `json:"receiveTimeout,omitempty"`
+	SuccessfulChecks int32    `json:"successfulChecks,omitempty"`
+	FailedChecks     int32    `json:"failedChecks,omitempty"`
+}
+
+// HTTPRuleMatch switches the requests for the target path, which match all the
+// headers and the cookie, to the backend service
+type HTTPRuleMatch struct {
+	Headers []HTTPRuleHeaderMatch `json:"headers,omitempty"`
+	Cookie  *HTTPRuleHeaderMatch  `json:"cookie,omitempty"`
+	Backend HTTPRuleBackend       `json:"backend,omitempty"`
+}
+
+// HTTPRuleHeaderMatch matches a request header or cookie by its value
+type HTTPRuleHeaderMatch struct {
+	Name      string `json:"name,omitempty"`
+	Value     string `json:"value,omitempty"`
+	MatchType string `json:"matchType,omitempty"`
+}
+
+// HTTPRuleBackend is a service in the namespace of the HTTPRule
+type HTTPRuleBackend struct {
+	Service string `json:"service,omitempty"`
+	Port    int32  `json:"port,omitempty"`
+}
+
+// HTTPRuleHeaders adds, replaces and removes the headers of the requests or
+// responses for the target path
+type HTTPRuleHeaders struct {
+	Add     []HTTPRuleHeader `json:"add,omitempty"`
+	Replace []HTTPRuleHeader `json:"replace,omitempty"`
+	Remove  []string         `json:"remove,omitempty"`
+}
+
+// HTTPRuleHeader is a header name and its value
+type HTTPRuleHeader struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPRuleRewrite rewrites the path prefix and the host header of the requests
+// for the target path, before these are sent to the backend
+type HTTPRuleRewrite struct {
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	Host       string `json:"host,omitempty"`
 }
 
 // HTTPRuleLBPolicy holds a path/pool's load balancer policies
@@ -204,6 +204,7 @@ func (in *AviInfraSettingSpec) DeepCopyInto(out *AviInfraSettingSpec) {
 	in.Network.DeepCopyInto(&out.Network)
 	out.SeGroup = in.SeGroup
 	out.L7Settings = in.L7Settings
+	in.ZoneAwareness.DeepCopyInto(&out.ZoneAwareness)
 	return
 }
 
@@ -249,6 +250,27 @@ func (in *AviInfraSettingVipNetwork) DeepCopy() *AviInfraSettingVipNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AviInfraSettingZoneAwareness) DeepCopyInto(out *AviInfraSettingZoneAwareness) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AviInfraSettingZoneAwareness.
+func (in *AviInfraSettingZoneAwareness) DeepCopy() *AviInfraSettingZoneAwareness {
+	if in == nil {
+		return nil
+	}
+	out := new(AviInfraSettingZoneAwareness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfig) DeepCopyInto(out *BackendConfig) {
 	*out = *in
@@ -455,6 +477,106 @@ func (in *HTTPRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleBackend) DeepCopyInto(out *HTTPRuleBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleBackend.
+func (in *HTTPRuleBackend) DeepCopy() *HTTPRuleBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeader) DeepCopyInto(out *HTTPRuleHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeader.
+func (in *HTTPRuleHeader) DeepCopy() *HTTPRuleHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeaderMatch) DeepCopyInto(out *HTTPRuleHeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeaderMatch.
+func (in *HTTPRuleHeaderMatch) DeepCopy() *HTTPRuleHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeaders) DeepCopyInto(out *HTTPRuleHeaders) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replace != nil {
+		in, out := &in.Replace, &out.Replace
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeaders.
+func (in *HTTPRuleHeaders) DeepCopy() *HTTPRuleHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHealthMonitor) DeepCopyInto(out *HTTPRuleHealthMonitor) {
+	*out = *in
+	if in.ResponseCodes != nil {
+		in, out := &in.ResponseCodes, &out.ResponseCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHealthMonitor.
+func (in *HTTPRuleHealthMonitor) DeepCopy() *HTTPRuleHealthMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHealthMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleLBPolicy) DeepCopyInto(out *HTTPRuleLBPolicy) {
 	*out = *in
@@ -504,6 +626,33 @@ func (in *HTTPRuleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleMatch) DeepCopyInto(out *HTTPRuleMatch) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPRuleHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(HTTPRuleHeaderMatch)
+		**out = **in
+	}
+	out.Backend = in.Backend
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleMatch.
+func (in *HTTPRuleMatch) DeepCopy() *HTTPRuleMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRulePaths) DeepCopyInto(out *HTTPRulePaths) {
 	*out = *in
@@ -514,6 +663,40 @@ func (in *HTTPRulePaths) DeepCopyInto(out *HTTPRulePaths) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InlineHealthMonitors != nil {
+		in, out := &in.InlineHealthMonitors, &out.InlineHealthMonitors
+		*out = make([]HTTPRuleHealthMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]HTTPRuleMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(HTTPRuleHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(HTTPRuleHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rewrite != nil {
+		in, out := &in.Rewrite, &out.Rewrite
+		*out = new(HTTPRuleRewrite)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -527,6 +710,22 @@ func (in *HTTPRulePaths) DeepCopy() *HTTPRulePaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRewrite) DeepCopyInto(out *HTTPRuleRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRewrite.
+func (in *HTTPRuleRewrite) DeepCopy() *HTTPRuleRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleSpec) DeepCopyInto(out *HTTPRuleSpec) {
 	*out = *in
@@ -636,6 +835,23 @@ func (in *HostRuleAnalyticsPolicy) DeepCopy() *HostRuleAnalyticsPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleClientAuth) DeepCopyInto(out *HostRuleClientAuth) {
+	*out = *in
+	out.CA = in.CA
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleClientAuth.
+func (in *HostRuleClientAuth) DeepCopy() *HostRuleClientAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleClientAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleGSLB) DeepCopyInto(out *HostRuleGSLB) {
 	*out = *in
@@ -813,6 +1029,11 @@ func (in *HostRuleTCPSettings) DeepCopy() *HostRuleTCPSettings {
 func (in *HostRuleTLS) DeepCopyInto(out *HostRuleTLS) {
 	*out = *in
 	out.SSLKeyCertificate = in.SSLKeyCertificate
+	if in.ClientAuth != nil {
+		in, out := &in.ClientAuth, &out.ClientAuth
+		*out = new(HostRuleClientAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -841,7 +1062,7 @@ func (in *HostRuleVirtualHost) DeepCopyInto(out *HostRuleVirtualHost) {
 	}
 	in.HTTPPolicy.DeepCopyInto(&out.HTTPPolicy)
 	out.Gslb = in.Gslb
-	out.TLS = in.TLS
+	in.TLS.DeepCopyInto(&out.TLS)
 	if in.AnalyticsPolicy != nil {
 		in, out := &in.AnalyticsPolicy, &out.AnalyticsPolicy
 		*out = new(HostRuleAnalyticsPolicy)
@@ -862,6 +1083,11 @@ func (in *HostRuleVirtualHost) DeepCopyInto(out *HostRuleVirtualHost) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1028,6 +1254,60 @@ func (in *MultiClusterIngressStatus) DeepCopy() *MultiClusterIngressStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	in.Action.DeepCopyInto(&out.Action)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitAction) DeepCopyInto(out *RateLimitAction) {
+	*out = *in
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(RateLimitRedirect)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitAction.
+func (in *RateLimitAction) DeepCopy() *RateLimitAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitRedirect) DeepCopyInto(out *RateLimitRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRedirect.
+func (in *RateLimitRedirect) DeepCopy() *RateLimitRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -16,6 +16,7 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -92,6 +93,17 @@ func (aviLogger *AviLogger) SetLevel(l string) {
 	aviLogger.atom.SetLevel(LogLevelMap[l])
 }
 
+// SetOutput writes the logs to w, such as to os.Stderr for the commands which print their output on os.Stdout.
+func (aviLogger *AviLogger) SetOutput(w io.Writer) {
+	logger := zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(consoleEncoderConfig()),
+		zapcore.Lock(zapcore.AddSync(w)),
+		aviLogger.atom,
+	))
+	aviLogger.logger = logger.WithOptions(zap.AddCaller(), zap.AddCallerSkip(1))
+	aviLogger.sugar = aviLogger.logger.Sugar()
+}
+
 func (aviLogger AviLogger) Enabled() bool {
 	return aviLogger.sugar != nil
 }
@@ -131,6 +143,14 @@ func getPodName() string {
 
 var AviLog AviLogger
 
+func consoleEncoderConfig() zapcore.EncoderConfig {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder // colored capital case LEVEL
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder        // format 2020-05-08T03:26:08.943+0530
+	encoderCfg.EncodeCaller = zapcore.ShortCallerEncoder      // caller format package_name/filename.go
+	return encoderCfg
+}
+
 func init() {
 	atom := zap.NewAtomicLevel()
 	// default level set to Info
@@ -142,10 +162,7 @@ func init() {
 
 	usePVC := os.Getenv("USE_PVC")
 
-	encoderCfg := zap.NewProductionEncoderConfig()
-	encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder // colored capital case LEVEL
-	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder        // format 2020-05-08T03:26:08.943+0530
-	encoderCfg.EncodeCaller = zapcore.ShortCallerEncoder      // caller format package_name/filename.go
+	encoderCfg := consoleEncoderConfig()
 
 	if usePVC != "true" {
 		logger := zap.New(zapcore.NewCore(
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package utils
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const metricsNamespace = "ako"
+
+// MetricsRegistry holds the metrics which are served by the API server at /metrics.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	workQueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "workqueue", "depth"),
+		"Number of keys waiting to be processed in the worker queue.",
+		[]string{"queue"}, nil,
+	)
+
+	workQueueProcessingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "processing_duration_seconds",
+		Help:      "Time taken to process a key of the worker queue.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"queue"})
+
+	aviRestRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "avi_rest",
+		Name:      "requests_total",
+		Help:      "Number of REST calls to the Avi Controller.",
+	}, []string{"model", "method", "status_code"})
+
+	aviRestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "avi_rest",
+		Name:      "errors_total",
+		Help:      "Number of REST calls to the Avi Controller which returned an error.",
+	}, []string{"model", "method", "status_code"})
+
+	aviRestRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "avi_rest",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the REST calls to the Avi Controller.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"model", "method"})
+
+	fullSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "full_sync",
+		Name:      "duration_seconds",
+		Help:      "Time taken by the full sync of the Kubernetes objects or of the Avi Controller cache.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"type"})
+
+	fullSyncLastTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "full_sync",
+		Name:      "last_timestamp_seconds",
+		Help:      "Unix time at which the last full sync completed.",
+	}, []string{"type"})
+
+	leaderState = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "leader",
+		Help:      "Whether this AKO instance is the leader (1) or a follower (0).",
+	})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		workQueueDepthCollector{},
+		workQueueProcessingDuration,
+		aviRestRequests,
+		aviRestErrors,
+		aviRestRequestDuration,
+		fullSyncDuration,
+		fullSyncLastTimestamp,
+		leaderState,
+	)
+}
+
+// RegisterMetricsCollector registers a collector of the metrics which are computed when these are scraped.
+func RegisterMetricsCollector(collector prometheus.Collector) {
+	if err := MetricsRegistry.Register(collector); err != nil {
+		AviLog.Warnf("Unable to register metrics collector: %v", err)
+	}
+}
+
+// ObserveWorkQueueProcessing records the time taken to process a key of the worker queue, since start.
+func ObserveWorkQueueProcessing(queueName string, start time.Time) {
+	workQueueProcessingDuration.WithLabelValues(queueName).Observe(time.Since(start).Seconds())
+}
+
+// ObserveAviRestOperation records a REST call to the Avi Controller for the model, since start. The statusCode
+// is empty if the call succeeded.
+func ObserveAviRestOperation(model, method, statusCode string, start time.Time) {
+	aviRestRequestDuration.WithLabelValues(model, method).Observe(time.Since(start).Seconds())
+	if statusCode == "" {
+		aviRestRequests.WithLabelValues(model, method, "2xx").Inc()
+		return
+	}
+	aviRestRequests.WithLabelValues(model, method, statusCode).Inc()
+	aviRestErrors.WithLabelValues(model, method, statusCode).Inc()
+}
+
+// ObserveFullSync records the time taken by a full sync of the given type, since start.
+func ObserveFullSync(syncType string, start time.Time) {
+	fullSyncDuration.WithLabelValues(syncType).Observe(time.Since(start).Seconds())
+	fullSyncLastTimestamp.WithLabelValues(syncType).SetToCurrentTime()
+}
+
+// SetLeaderMetric records whether this instance is the leader.
+func SetLeaderMetric(isLeader bool) {
+	if isLeader {
+		leaderState.Set(1)
+	} else {
+		leaderState.Set(0)
+	}
+}
+
+// workQueueDepthCollector reports the number of keys in each of the worker queues, when the metrics are scraped.
+// The keys which are added to the retry queues after a delay are reported once they are added.
+type workQueueDepthCollector struct{}
+
+func (workQueueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workQueueDepthDesc
+}
+
+func (workQueueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	wrapper := getQueueInstance()
+	if wrapper == nil {
+		return
+	}
+	for queueName, queue := range wrapper.queues() {
+		depth := 0
+		for _, workqueue := range queue.Workqueue {
+			depth += workqueue.Len()
+		}
+		ch <- prometheus.MustNewConstMetric(workQueueDepthDesc, prometheus.GaugeValue, float64(depth), queueName)
+	}
+}
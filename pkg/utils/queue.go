@@ -25,37 +25,64 @@ import (
 
 var queuewrapper sync.Once
 var queueInstance *WorkQueueWrapper
+var queueInstanceLock sync.RWMutex
 var fixedQueues = [...]*WorkerQueue{{NumWorkers: NumWorkersIngestion, WorkqueueName: ObjectIngestionLayer}, {NumWorkers: NumWorkersGraph, WorkqueueName: GraphLayer}}
 
 type WorkQueueWrapper struct {
 	// This struct should manage a set of WorkerQueues for the various layers
 	queueCollection map[string]*WorkerQueue
+	lock            sync.RWMutex
 }
 
 func (w *WorkQueueWrapper) GetQueueByName(queueName string) *WorkerQueue {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
 	workqueue, _ := w.queueCollection[queueName]
 	return workqueue
 }
 
+// queues returns a snapshot of the worker queues by name.
+func (w *WorkQueueWrapper) queues() map[string]*WorkerQueue {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	queues := make(map[string]*WorkerQueue, len(w.queueCollection))
+	for queueName, queue := range w.queueCollection {
+		queues[queueName] = queue
+	}
+	return queues
+}
+
 func SharedWorkQueue(queueParams ...*WorkerQueue) *WorkQueueWrapper {
 	queuewrapper.Do(func() {
-		queueInstance = &WorkQueueWrapper{}
-		queueInstance.queueCollection = make(map[string]*WorkerQueue)
+		wrapper := &WorkQueueWrapper{}
+		wrapper.lock.Lock()
+		defer wrapper.lock.Unlock()
+		wrapper.queueCollection = make(map[string]*WorkerQueue)
 		if len(queueParams) != 0 {
 			for _, queue := range queueParams {
 				workqueue := NewWorkQueue(queue.NumWorkers, queue.WorkqueueName, queue.SlowSyncTime)
-				queueInstance.queueCollection[queue.WorkqueueName] = workqueue
+				wrapper.queueCollection[queue.WorkqueueName] = workqueue
 			}
 		} else {
 			for _, queue := range fixedQueues {
 				workqueue := NewWorkQueue(queue.NumWorkers, queue.WorkqueueName)
-				queueInstance.queueCollection[queue.WorkqueueName] = workqueue
+				wrapper.queueCollection[queue.WorkqueueName] = workqueue
 			}
 		}
+		queueInstanceLock.Lock()
+		queueInstance = wrapper
+		queueInstanceLock.Unlock()
 	})
 	return queueInstance
 }
 
+// getQueueInstance returns the shared worker queues, if these are created, without creating these.
+func getQueueInstance() *WorkQueueWrapper {
+	queueInstanceLock.RLock()
+	defer queueInstanceLock.RUnlock()
+	return queueInstance
+}
+
 // Common utils like processing worker queue, that is common for all objects.
 type WorkerQueue struct {
 	NumWorkers    uint32
@@ -77,11 +104,35 @@ func NewWorkQueue(num_workers uint32, workerQueueName string, slowSyncTime ...in
 		queue.SlowSyncTime = slowSyncTime[0]
 	}
 	for i := uint32(0); i < num_workers; i++ {
-		queue.Workqueue[i] = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("avi-%s", workerQueueName))
+		queue.Workqueue[i] = &tracedWorkqueue{
+			RateLimitingInterface: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("avi-%s", workerQueueName)),
+			name:                  workerQueueName,
+		}
 	}
 	return queue
 }
 
+// tracedWorkqueue records the time at which the keys are added to the queue, for the spans of the workers.
+type tracedWorkqueue struct {
+	workqueue.RateLimitingInterface
+	name string
+}
+
+func (q *tracedWorkqueue) Add(item interface{}) {
+	traceEnqueued(q.name, item)
+	q.RateLimitingInterface.Add(item)
+}
+
+func (q *tracedWorkqueue) AddAfter(item interface{}, duration time.Duration) {
+	traceEnqueued(q.name, item)
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *tracedWorkqueue) AddRateLimited(item interface{}) {
+	traceEnqueued(q.name, item)
+	q.RateLimitingInterface.AddRateLimited(item)
+}
+
 func (c *WorkerQueue) Run(stopCh <-chan struct{}, wg *sync.WaitGroup) error {
 	AviLog.Infof("Starting workers to drain the %s layer queues", c.WorkqueueName)
 	if c.SyncFunc == nil {
@@ -151,7 +202,9 @@ func (c *WorkerQueue) processSingleWorkItem(worker_id uint32, wg *sync.WaitGroup
 		// period.
 		defer c.Workqueue[worker_id].Done(obj)
 		// Run the syncToAvi, passing it the ev resource to be synced.
+		start := time.Now()
 		err := c.SyncFunc(obj, wg)
+		ObserveWorkQueueProcessing(c.WorkqueueName, start)
 		if err != nil {
 			AviLog.Errorf("There was an error while syncing the key: %s", ev)
 		}
@@ -0,0 +1,610 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TraceExporterOTLP = "otlp"
+	TraceExporterFile = "file"
+
+	// TraceKeyAttribute is the attribute of the spans which holds the key being processed, that is the key of the
+	// Kubernetes object in the ingestion layer, and the name of the model in the later layers.
+	TraceKeyAttribute = "ako.key"
+
+	traceScopeName      = "ako"
+	traceBatchSize      = 512
+	traceBufferSize     = 4096
+	traceExportInterval = 5 * time.Second
+	traceExportTimeout  = 10 * time.Second
+	// maxPendingTraces bounds the parents recorded for the keys which are yet to be dequeued, in case the keys are
+	// published to a queue without a worker, such as when the sync is disabled.
+	maxPendingTraces = 10000
+	// pendingTraceTTL is the time after which the parents recorded for a key which is not dequeued are dropped,
+	// so that the keys which are never processed do not fill up the pending traces. It is longer than the time
+	// the keys wait in the queues, including the slow retry queue.
+	pendingTraceTTL = 15 * time.Minute
+)
+
+// OTLP span kinds and status codes.
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+	statusCodeError  = 2
+)
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{}
+}
+
+// Span records the processing of a key by a layer of AKO, or a REST call to the Avi Controller. The methods of a
+// nil Span do nothing, so that the spans can be used unconditionally when the tracing is disabled.
+type Span struct {
+	name       string
+	kind       int
+	context    SpanContext
+	parent     SpanContext
+	links      []SpanContext
+	start      time.Time
+	end        time.Time
+	attributes map[string]interface{}
+	err        error
+}
+
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.context
+}
+
+// SetAttribute sets an attribute of the span, the value is one of string, int, int64 and bool.
+func (s *Span) SetAttribute(name string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.attributes[name] = value
+}
+
+// SetClientKind marks the span as a call to a remote service, such as the Avi Controller.
+func (s *Span) SetClientKind() {
+	if s == nil {
+		return
+	}
+	s.kind = spanKindClient
+}
+
+// SetError sets the status of the span to error, if err is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.err = err
+}
+
+// End ends the span and queues it for export.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	aviTracer.removeActive(s)
+	aviTracer.queue(s)
+}
+
+// pendingTrace holds the spans which published a key to a queue, and the time at which the key was first published.
+type pendingTrace struct {
+	parents  []SpanContext
+	enqueued time.Time
+}
+
+type spanExporter interface {
+	export(payload []byte) error
+	close()
+}
+
+type tracer struct {
+	exporter spanExporter
+	resource []otlpAttribute
+	spans    chan *Span
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	dropped  int
+
+	lock    sync.Mutex
+	active  map[string][]*Span
+	pending map[string]*pendingTrace
+}
+
+var aviTracer *tracer
+
+// InitTracing starts exporting the spans of AKO, either to the OTLP/HTTP endpoint, such as
+// http://otel-collector:4318, or to the file at endpoint, as the JSON encoded OTLP trace requests, one per line.
+// The resource attributes identify this instance of AKO in the traces.
+func InitTracing(exporterType, endpoint string, resource map[string]string) error {
+	var exporter spanExporter
+	var err error
+	switch strings.ToLower(exporterType) {
+	case TraceExporterOTLP:
+		exporter, err = newOTLPExporter(endpoint)
+	case TraceExporterFile:
+		exporter, err = newFileExporter(endpoint)
+	default:
+		return fmt.Errorf("unknown trace exporter %q, supported exporters are %s and %s", exporterType, TraceExporterOTLP, TraceExporterFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	t := &tracer{
+		exporter: exporter,
+		spans:    make(chan *Span, traceBufferSize),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		active:   make(map[string][]*Span),
+		pending:  make(map[string]*pendingTrace),
+	}
+	t.resource = append(t.resource, newOTLPAttribute("service.name", traceScopeName))
+	for name, value := range resource {
+		t.resource = append(t.resource, newOTLPAttribute(name, value))
+	}
+	go t.run()
+	aviTracer = t
+	AviLog.Infof("Exporting traces with the %s exporter to %s", exporterType, endpoint)
+	return nil
+}
+
+// StopTracing exports the spans which have ended, and stops the export. The spans which end later are dropped.
+func StopTracing() {
+	t := aviTracer
+	if t == nil {
+		return
+	}
+	t.stopOnce.Do(func() {
+		close(t.stopCh)
+		<-t.doneCh
+	})
+}
+
+func IsTracingEnabled() bool {
+	return aviTracer != nil
+}
+
+// StartSpan starts a span as a child of the active span of key, such as for the building of the graphs of a key or
+// for a REST call to the Avi Controller. The span is the active span of key until it ends. No span is started for a
+// key which is not processed by a worker, such as during the full sync. The start time can be given for the spans
+// which are recorded after the fact.
+func StartSpan(name, key string, start ...time.Time) *Span {
+	parent := ActiveSpanContext(key)
+	if !parent.IsValid() {
+		return nil
+	}
+	s := newSpan(name, parent, key)
+	if len(start) > 0 {
+		s.start = start[0]
+	}
+	aviTracer.addActive(s)
+	return s
+}
+
+// StartKeySpan starts the span of a worker of queueName which processes key. The span is a child of the span which
+// published the key to the queue, if any, or else the root of a new trace. The spans of the other publishers of
+// the same key are linked, as the key is processed once for all of these. The span is the active span of key until
+// it ends.
+func StartKeySpan(name, queueName, key string) *Span {
+	t := aviTracer
+	if t == nil {
+		return nil
+	}
+	t.lock.Lock()
+	pending := t.pending[queueName+"/"+key]
+	delete(t.pending, queueName+"/"+key)
+	t.lock.Unlock()
+
+	var parent SpanContext
+	if pending != nil && len(pending.parents) > 0 {
+		parent = pending.parents[0]
+	}
+	s := newSpan(name, parent, key)
+	s.SetAttribute("ako.queue", queueName)
+	if pending != nil {
+		if len(pending.parents) > 1 {
+			s.links = pending.parents[1:]
+		}
+		s.SetAttribute("ako.queue.wait_ms", s.start.Sub(pending.enqueued).Milliseconds())
+	}
+	t.addActive(s)
+	return s
+}
+
+func newSpan(name string, parent SpanContext, key string) *Span {
+	s := &Span{
+		name:       name,
+		kind:       spanKindInternal,
+		parent:     parent,
+		start:      time.Now(),
+		attributes: map[string]interface{}{TraceKeyAttribute: key},
+	}
+	if parent.IsValid() {
+		s.context.TraceID = parent.TraceID
+	} else {
+		rand.Read(s.context.TraceID[:])
+	}
+	rand.Read(s.context.SpanID[:])
+	return s
+}
+
+// ActiveSpanContext returns the context of the span which is processing key, if any.
+func ActiveSpanContext(key string) SpanContext {
+	t := aviTracer
+	if t == nil {
+		return SpanContext{}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if spans := t.active[key]; len(spans) > 0 {
+		return spans[len(spans)-1].context
+	}
+	return SpanContext{}
+}
+
+// TraceEnqueue records the active span of publisherKey as the publisher of key to queueName, for the span of the
+// worker which processes it.
+func TraceEnqueue(queueName, key, publisherKey string) {
+	t := aviTracer
+	if t == nil {
+		return
+	}
+	parent := ActiveSpanContext(publisherKey)
+	if !parent.IsValid() {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	pending := t.addPending(queueName, key)
+	if pending == nil {
+		return
+	}
+	for _, publisher := range pending.parents {
+		if publisher == parent {
+			return
+		}
+	}
+	pending.parents = append(pending.parents, parent)
+}
+
+// traceEnqueued records the time at which key is first added to queueName, for the span of the worker.
+func traceEnqueued(queueName string, item interface{}) {
+	t := aviTracer
+	if t == nil {
+		return
+	}
+	key, ok := item.(string)
+	if !ok {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.addPending(queueName, key)
+}
+
+func (t *tracer) addPending(queueName, key string) *pendingTrace {
+	if pending, ok := t.pending[queueName+"/"+key]; ok {
+		return pending
+	}
+	if len(t.pending) >= maxPendingTraces {
+		return nil
+	}
+	pending := &pendingTrace{enqueued: time.Now()}
+	t.pending[queueName+"/"+key] = pending
+	return pending
+}
+
+// evictPending drops the parents recorded for the keys which were published before pendingTraceTTL.
+func (t *tracer) evictPending() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	evicted := 0
+	for queueKey, pending := range t.pending {
+		if time.Since(pending.enqueued) > pendingTraceTTL {
+			delete(t.pending, queueKey)
+			evicted++
+		}
+	}
+	if evicted > 0 {
+		AviLog.Debugf("Evicted the pending traces of %d keys which were not dequeued", evicted)
+	}
+}
+
+func (t *tracer) addActive(s *Span) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key, _ := s.attributes[TraceKeyAttribute].(string)
+	t.active[key] = append(t.active[key], s)
+}
+
+func (t *tracer) removeActive(s *Span) {
+	if t == nil {
+		return
+	}
+	key, _ := s.attributes[TraceKeyAttribute].(string)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	spans := t.active[key]
+	for i := range spans {
+		if spans[i] == s {
+			spans = append(spans[:i], spans[i+1:]...)
+			break
+		}
+	}
+	if len(spans) == 0 {
+		delete(t.active, key)
+	} else {
+		t.active[key] = spans
+	}
+}
+
+func (t *tracer) queue(s *Span) {
+	if t == nil {
+		return
+	}
+	select {
+	case t.spans <- s:
+	default:
+		// The spans are dropped rather than blocking the workers, if the exporter can not keep up.
+		t.lock.Lock()
+		t.dropped++
+		t.lock.Unlock()
+	}
+}
+
+func (t *tracer) run() {
+	defer close(t.doneCh)
+	ticker := time.NewTicker(traceExportInterval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, traceBatchSize)
+	for {
+		select {
+		case s := <-t.spans:
+			batch = append(batch, s)
+			if len(batch) >= traceBatchSize {
+				batch = t.export(batch)
+			}
+		case <-ticker.C:
+			batch = t.export(batch)
+			t.evictPending()
+		case <-t.stopCh:
+			for {
+				select {
+				case s := <-t.spans:
+					batch = append(batch, s)
+				default:
+					t.export(batch)
+					t.exporter.close()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (t *tracer) export(batch []*Span) []*Span {
+	t.lock.Lock()
+	dropped := t.dropped
+	t.dropped = 0
+	t.lock.Unlock()
+	if dropped > 0 {
+		AviLog.Warnf("Dropped %d spans as the trace exporter is not keeping up", dropped)
+	}
+	if len(batch) == 0 {
+		return batch
+	}
+
+	payload, err := json.Marshal(t.otlpRequest(batch))
+	if err != nil {
+		AviLog.Warnf("Unable to marshal the spans: %v", err)
+	} else if err = t.exporter.export(payload); err != nil {
+		AviLog.Warnf("Unable to export %d spans: %v", len(batch), err)
+	}
+	return batch[:0]
+}
+
+// The spans are exported in the JSON encoding of the OTLP ExportTraceServiceRequest, in which the trace and span
+// ids are hex encoded.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Links             []otlpLink      `json:"links,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func newOTLPAttribute(key string, value interface{}) otlpAttribute {
+	attr := otlpAttribute{Key: key}
+	switch v := value.(type) {
+	case bool:
+		attr.Value.BoolValue = &v
+	case int:
+		intValue := strconv.Itoa(v)
+		attr.Value.IntValue = &intValue
+	case int64:
+		intValue := strconv.FormatInt(v, 10)
+		attr.Value.IntValue = &intValue
+	default:
+		stringValue := fmt.Sprint(v)
+		attr.Value.StringValue = &stringValue
+	}
+	return attr
+}
+
+func (t *tracer) otlpRequest(batch []*Span) otlpRequest {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.context.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.context.SpanID[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		if s.parent.IsValid() {
+			span.ParentSpanID = hex.EncodeToString(s.parent.SpanID[:])
+		}
+		for name, value := range s.attributes {
+			span.Attributes = append(span.Attributes, newOTLPAttribute(name, value))
+		}
+		for _, link := range s.links {
+			span.Links = append(span.Links, otlpLink{
+				TraceID: hex.EncodeToString(link.TraceID[:]),
+				SpanID:  hex.EncodeToString(link.SpanID[:]),
+			})
+		}
+		if s.err != nil {
+			span.Status = &otlpStatus{Code: statusCodeError, Message: s.err.Error()}
+		}
+		spans = append(spans, span)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: t.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: traceScopeName}, Spans: spans}},
+	}}}
+}
+
+// otlpExporter posts the spans to an OTLP/HTTP endpoint, such as the OpenTelemetry Collector.
+type otlpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func newOTLPExporter(endpoint string) (*otlpExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL such as http://otel-collector:4318", endpoint)
+	}
+	// As with OTEL_EXPORTER_OTLP_ENDPOINT, the path of the traces is appended to the base URL of the endpoint.
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	return &otlpExporter{url: u.String(), client: &http.Client{Timeout: traceExportTimeout}}, nil
+}
+
+func (e *otlpExporter) export(payload []byte) error {
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", e.url, resp.Status)
+	}
+	return nil
+}
+
+func (e *otlpExporter) close() {}
+
+// fileExporter appends the spans to a file, in the format of the file exporter of the OpenTelemetry Collector,
+// which can be read back by the otlpjsonfile receiver of the collector.
+type fileExporter struct {
+	file *os.File
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the path of the trace file is not set")
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the trace file: %v", err)
+	}
+	return &fileExporter{file: file}, nil
+}
+
+func (e *fileExporter) export(payload []byte) error {
+	_, err := e.file.Write(append(payload, '\n'))
+	return err
+}
+
+func (e *fileExporter) close() {
+	e.file.Close()
+}
@@ -0,0 +1,785 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package endpointslicetests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8scache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+var KubeClient *k8sfake.Clientset
+var CRDClient *crdfake.Clientset
+var ctrl *k8s.AviController
+var akoApiServer *api.FakeApiServer
+
+func TestMain(m *testing.M) {
+	os.Setenv("INGRESS_API", "extensionv1")
+	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+	os.Setenv("AUTO_L4_FQDN", "default")
+	os.Setenv("ENABLE_POD_READINESS_GATE", "true")
+
+	akoControlConfig := lib.AKOControlConfig()
+	KubeClient = k8sfake.NewSimpleClientset()
+	CRDClient = crdfake.NewSimpleClientset()
+	akoControlConfig.SetCRDClientset(CRDClient)
+	akoControlConfig.SetAKOInstanceFlag(true)
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, KubeClient, true)
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointSlicesInformer,
+		utils.PodInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient}
+	k8s.NewCRDInformers(CRDClient)
+
+	mcache := cache.SharedAviObjCache()
+	cloudObj := &cache.AviCloudPropertyCache{Name: "Default-Cloud", VType: "mock"}
+	subdomains := []string{"avi.internal", ".com"}
+	cloudObj.NSIpamDNS = subdomains
+	mcache.CloudKeyCache.AviCacheAdd("Default-Cloud", cloudObj)
+
+	akoApiServer = integrationtest.InitializeFakeAKOAPIServer()
+
+	integrationtest.NewAviFakeClientInstance(KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+	wgLeaderElection := &sync.WaitGroup{}
+	waitGroupMap["leaderElection"] = wgLeaderElection
+
+	integrationtest.AddConfigMap(KubeClient)
+	integrationtest.PollForSyncStart(ctrl, 10)
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddDefaultIngressClass()
+	ctrl.SetSEGroupCloudNameFromNSAnnotations()
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+type fakeEndpoint struct {
+	ip    string
+	ready *bool
+}
+
+func endpointSlice(ns, svcName, name string, endpoints ...fakeEndpoint) *discovery.EndpointSlice {
+	portName := "foo0"
+	port := int32(8080)
+	protocol := corev1.ProtocolTCP
+	epSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    map[string]string{discovery.LabelServiceName: svcName},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{{
+			Name:     &portName,
+			Port:     &port,
+			Protocol: &protocol,
+		}},
+	}
+	for _, endpoint := range endpoints {
+		epSlice.Endpoints = append(epSlice.Endpoints, discovery.Endpoint{
+			Addresses:  []string{endpoint.ip},
+			Conditions: discovery.EndpointConditions{Ready: endpoint.ready},
+		})
+	}
+	return epSlice
+}
+
+func createEndpointSlice(t *testing.T, epSlice *discovery.EndpointSlice) {
+	if _, err := KubeClient.DiscoveryV1().EndpointSlices(epSlice.Namespace).Create(context.TODO(), epSlice, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating EndpointSlice: %v", err)
+	}
+}
+
+func updateEndpointSlice(t *testing.T, epSlice *discovery.EndpointSlice) {
+	epSlice.ResourceVersion = "2"
+	if _, err := KubeClient.DiscoveryV1().EndpointSlices(epSlice.Namespace).Update(context.TODO(), epSlice, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating EndpointSlice: %v", err)
+	}
+}
+
+func deleteEndpointSlice(t *testing.T, ns, name string) {
+	if err := KubeClient.DiscoveryV1().EndpointSlices(ns).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting EndpointSlice: %v", err)
+	}
+}
+
+func getServerIPs(pool *avinodes.AviPoolNode) []string {
+	var ips []string
+	for _, server := range pool.Servers {
+		ips = append(ips, *server.Ip.Addr)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+func TestEndpointSliceServersForIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	objects.SharedAviGraphLister().Delete(modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc", corev1.ServiceTypeClusterIP, false)
+	notReady := false
+	createEndpointSlice(t, endpointSlice("default", "avisvc", "avisvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2", ready: &notReady}))
+	createEndpointSlice(t, endpointSlice("default", "avisvc", "avisvc-def",
+		fakeEndpoint{ip: "1.1.1.3"},
+		fakeEndpoint{ip: "1.1.1.1"}))
+	// A slice of another Service is not merged.
+	createEndpointSlice(t, endpointSlice("default", "othersvc", "othersvc-abc",
+		fakeEndpoint{ip: "1.1.1.4"}))
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-slices",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getPool := func() *avinodes.AviPoolNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].PoolRefs[0]
+	}
+	// The ready endpoints of all the slices of the Service are merged, without duplicates.
+	g.Eventually(func() []string {
+		if pool := getPool(); pool != nil {
+			return getServerIPs(pool)
+		}
+		return nil
+	}, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1", "1.1.1.3"}))
+	g.Expect(getPool().Port).To(gomega.Equal(int32(8080)))
+
+	// An endpoint that becomes ready is added to the pool.
+	updateEndpointSlice(t, endpointSlice("default", "avisvc", "avisvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2"}))
+	g.Eventually(func() []string {
+		if pool := getPool(); pool != nil {
+			return getServerIPs(pool)
+		}
+		return nil
+	}, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1", "1.1.1.2", "1.1.1.3"}))
+
+	// The endpoints of a deleted slice are removed from the pool.
+	deleteEndpointSlice(t, "default", "avisvc-def")
+	g.Eventually(func() []string {
+		if pool := getPool(); pool != nil {
+			return getServerIPs(pool)
+		}
+		return nil
+	}, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1", "1.1.1.2"}))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-slices", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	deleteEndpointSlice(t, "default", "avisvc-abc")
+	deleteEndpointSlice(t, "default", "othersvc-abc")
+	integrationtest.DelSVC(t, "default", "avisvc")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestEndpointSliceServersForL4Service(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2"}))
+	integrationtest.CreateSVC(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getServers := func() []string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return getServerIPs(nodes[0].PoolRefs[0])
+	}
+	g.Eventually(getServers, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1", "1.1.1.2"}))
+
+	// A terminating endpoint is not ready, and is removed from the pool.
+	notReady := false
+	updateEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2", ready: &notReady}))
+	g.Eventually(getServers, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1"}))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestEndpointSliceTerminatingServersAreDrained(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("SERVER_DRAIN_PERIOD", "5")
+	defer os.Unsetenv("SERVER_DRAIN_PERIOD")
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2"}))
+	integrationtest.CreateSVC(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getServers := func() map[string]bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		servers := make(map[string]bool)
+		for _, server := range nodes[0].PoolRefs[0].Servers {
+			servers[*server.Ip.Addr] = server.Draining
+		}
+		return servers
+	}
+	g.Eventually(getServers, 20*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false, "1.1.1.2": false}))
+
+	// The terminating endpoint is kept in the pool as draining, until the drain period ends.
+	notReady, terminating := false, true
+	epSlice := endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2", ready: &notReady})
+	epSlice.Endpoints[1].Conditions.Terminating = &terminating
+	updateEndpointSlice(t, epSlice)
+	g.Eventually(getServers, 4*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false, "1.1.1.2": true}))
+	g.Eventually(getServers, 20*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false}))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestEndpointSliceDrainNotResetByOtherPools(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("SERVER_DRAIN_PERIOD", "3")
+	defer os.Unsetenv("SERVER_DRAIN_PERIOD")
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	// The slice has the first two ports of the Service, the pool of the third port has no server.
+	withSecondPort := func(epSlice *discovery.EndpointSlice) *discovery.EndpointSlice {
+		portName, port := "foo1", int32(8081)
+		epSlice.Ports = append(epSlice.Ports, discovery.EndpointPort{Name: &portName, Port: &port, Protocol: epSlice.Ports[0].Protocol})
+		return epSlice
+	}
+	createEndpointSlice(t, withSecondPort(endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2"})))
+	integrationtest.CreateSVC(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, true)
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getServers := func() map[string]bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		for _, pool := range nodes[0].PoolRefs {
+			if pool.PortName != "foo0" {
+				continue
+			}
+			servers := make(map[string]bool)
+			for _, server := range pool.Servers {
+				servers[*server.Ip.Addr] = server.Draining
+			}
+			return servers
+		}
+		return nil
+	}
+	g.Eventually(getServers, 20*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false, "1.1.1.2": false}))
+
+	notReady, terminating := false, true
+	epSlice := withSecondPort(endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.2", ready: &notReady}))
+	epSlice.Endpoints[1].Conditions.Terminating = &terminating
+	updateEndpointSlice(t, epSlice)
+	g.Eventually(getServers, 2*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false, "1.1.1.2": true}))
+
+	// The pools are built again during the drain period, the drain still ends after the drain period.
+	for i := 3; i < 9; i++ {
+		epSlice.ResourceVersion = strconv.Itoa(i)
+		if _, err := KubeClient.DiscoveryV1().EndpointSlices("red-ns").Update(context.TODO(), epSlice, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating EndpointSlice: %v", err)
+		}
+		time.Sleep(time.Second)
+	}
+	g.Eventually(getServers, 2*time.Second).Should(gomega.Equal(map[string]bool{"1.1.1.1": false}))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+// fakePoolServerRuntime serves the runtime of the pool servers with the given state.
+func fakePoolServerRuntime(addr string, port int32, state *string) {
+	integrationtest.FakeServerMiddleware = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.Contains(r.URL.EscapedPath(), "/runtime/server") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"server_ip":{"addr":"` + addr + `","type":"V4"},"port":` + strconv.Itoa(int(port)) + `,"oper_status":{"state":"` + *state + `"}}]`))
+			return
+		}
+		integrationtest.NormalControllerServer(w, r)
+	}
+}
+
+func getPodReadinessGateCondition(g *gomega.WithT, ns, name string) *corev1.PodCondition {
+	pod, err := KubeClient.CoreV1().Pods(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == lib.PodReadinessGateConditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestPodReadinessGateSetOnPoolServerUp(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	state := "OPER_DOWN"
+	fakePoolServerRuntime("1.1.1.5", 8080, &state)
+	defer integrationtest.ResetMiddleware()
+
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red-ns", Name: "gated-pod"},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: lib.PodReadinessGateConditionType}},
+		},
+		Status: corev1.PodStatus{
+			PodIP:  "1.1.1.5",
+			PodIPs: []corev1.PodIP{{IP: "1.1.1.5"}},
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods("red-ns").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods("red-ns").Get("gated-pod")
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	// The endpoint of the Pod, which is not ready only because of the readiness gate, is added to the pool.
+	notReady := false
+	epSlice := endpointSlice("red-ns", "testsvc", "testsvc-abc",
+		fakeEndpoint{ip: "1.1.1.1"},
+		fakeEndpoint{ip: "1.1.1.5", ready: &notReady})
+	epSlice.Endpoints[1].TargetRef = &corev1.ObjectReference{Kind: "Pod", Namespace: "red-ns", Name: "gated-pod"}
+	createEndpointSlice(t, epSlice)
+	integrationtest.CreateSVC(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() []string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return getServerIPs(nodes[0].PoolRefs[0])
+	}, 20*time.Second).Should(gomega.Equal([]string{"1.1.1.1", "1.1.1.5"}))
+
+	// The condition is False while the server is not up in the pool, and True once it is up.
+	g.Eventually(func() corev1.ConditionStatus {
+		if condition := getPodReadinessGateCondition(g, "red-ns", "gated-pod"); condition != nil {
+			return condition.Status
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal(corev1.ConditionFalse))
+	state = "OPER_UP"
+	g.Eventually(func() corev1.ConditionStatus {
+		if condition := getPodReadinessGateCondition(g, "red-ns", "gated-pod"); condition != nil {
+			return condition.Status
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal(corev1.ConditionTrue))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	KubeClient.CoreV1().Pods("red-ns").Delete(context.TODO(), "gated-pod", metav1.DeleteOptions{})
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestPodReadinessGateNotAPoolMember(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red-ns", Name: "gated-pod", Labels: map[string]string{"app": "gated"}},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: lib.PodReadinessGateConditionType}},
+		},
+		Status: corev1.PodStatus{
+			PodIP:  "1.1.1.6",
+			PodIPs: []corev1.PodIP{{IP: "1.1.1.6"}},
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods("red-ns").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods("red-ns").Get("gated-pod")
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	// The condition is not set while a LoadBalancer Service of the Pod has a pool, which the Pod is not yet a server of.
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc", fakeEndpoint{ip: "1.1.1.1"}))
+	integrationtest.CreateServiceWithSelectors(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false, map[string]string{"app": "gated"})
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Consistently(func() *corev1.PodCondition {
+		return getPodReadinessGateCondition(g, "red-ns", "gated-pod")
+	}, 2*k8s.PodReadinessGateSyncInterval*time.Second).Should(gomega.BeNil())
+
+	// The condition is True once none of the Services of the Pod have pools.
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	g.Eventually(func() string {
+		if condition := getPodReadinessGateCondition(g, "red-ns", "gated-pod"); condition != nil && condition.Status == corev1.ConditionTrue {
+			return condition.Reason
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal("NotAPoolMember"))
+
+	KubeClient.CoreV1().Pods("red-ns").Delete(context.TODO(), "gated-pod", metav1.DeleteOptions{})
+}
+
+func TestReadinessProbeHealthMonitor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("READINESS_PROBE_HEALTH_MONITOR", "true")
+	defer os.Unsetenv("READINESS_PROBE_HEALTH_MONITOR")
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red-ns", Name: "probe-pod", Labels: map[string]string{"app": "probe"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "web",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {Name: "health", ContainerPort: 8081}},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("health")},
+					},
+					PeriodSeconds:    5,
+					TimeoutSeconds:   2,
+					FailureThreshold: 4,
+				},
+			}},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods("red-ns").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods("red-ns").Get("probe-pod")
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc", fakeEndpoint{ip: "1.1.1.1"}))
+	integrationtest.CreateServiceWithSelectors(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false, map[string]string{"app": "probe"})
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getHealthMonitor := func() *avinodes.AviHealthMonitorNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].PoolRefs[0].HealthMonitor
+	}
+	g.Eventually(getHealthMonitor, 20*time.Second).ShouldNot(gomega.BeNil())
+	healthMonitor := getHealthMonitor()
+	g.Expect(healthMonitor.Name).To(gomega.Equal("cluster--red-ns-testsvc-TCP-8080--readiness-probe"))
+	g.Expect(healthMonitor.Type).To(gomega.Equal("HEALTH_MONITOR_HTTP"))
+	g.Expect(healthMonitor.HTTPRequest).To(gomega.Equal("GET /healthz HTTP/1.0"))
+	g.Expect(healthMonitor.MonitorPort).To(gomega.Equal(int32(8081)))
+	g.Expect(healthMonitor.SendInterval).To(gomega.Equal(int32(5)))
+	g.Expect(healthMonitor.ReceiveTimeout).To(gomega.Equal(int32(2)))
+	g.Expect(healthMonitor.SuccessfulChecks).To(gomega.Equal(int32(1)))
+	g.Expect(healthMonitor.FailedChecks).To(gomega.Equal(int32(4)))
+
+	mcache := cache.SharedAviObjCache()
+	healthMonitorKey := cache.NamespaceName{Namespace: "admin", Name: healthMonitor.Name}
+	g.Eventually(func() bool {
+		_, found := mcache.HealthMonitorCache.AviCacheGet(healthMonitorKey)
+		return found
+	}, 20*time.Second).Should(gomega.BeTrue())
+
+	// The health monitor is deleted along with the pool.
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	g.Eventually(func() bool {
+		_, found := mcache.HealthMonitorCache.AviCacheGet(healthMonitorKey)
+		return found
+	}, 20*time.Second).Should(gomega.BeFalse())
+
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	KubeClient.CoreV1().Pods("red-ns").Delete(context.TODO(), "probe-pod", metav1.DeleteOptions{})
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestReadinessProbeHTTPSHealthMonitor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("READINESS_PROBE_HEALTH_MONITOR", "true")
+	defer os.Unsetenv("READINESS_PROBE_HEALTH_MONITOR")
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red-ns", Name: "https-probe-pod", Labels: map[string]string{"app": "https-probe"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "web",
+				Ports: []corev1.ContainerPort{{Name: "https", ContainerPort: 8080}},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path:        "/ready",
+							Port:        intstr.FromInt(8080),
+							Host:        "probe.example.com",
+							Scheme:      corev1.URISchemeHTTPS,
+							HTTPHeaders: []corev1.HTTPHeader{{Name: "X-Probe", Value: "ako"}},
+						},
+					},
+				},
+			}},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods("red-ns").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods("red-ns").Get("https-probe-pod")
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc", fakeEndpoint{ip: "1.1.1.1"}))
+	integrationtest.CreateServiceWithSelectors(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false, map[string]string{"app": "https-probe"})
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getHealthMonitor := func() *avinodes.AviHealthMonitorNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].PoolRefs[0].HealthMonitor
+	}
+	g.Eventually(getHealthMonitor, 20*time.Second).ShouldNot(gomega.BeNil())
+	healthMonitor := getHealthMonitor()
+	g.Expect(healthMonitor.Type).To(gomega.Equal("HEALTH_MONITOR_HTTPS"))
+	g.Expect(healthMonitor.HTTPRequest).To(gomega.Equal("GET /ready HTTP/1.0\r\nHost: probe.example.com\r\nX-Probe: ako"))
+	g.Expect(healthMonitor.MonitorPort).To(gomega.Equal(int32(0)))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	KubeClient.CoreV1().Pods("red-ns").Delete(context.TODO(), "https-probe-pod", metav1.DeleteOptions{})
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
+func TestReadinessProbeGRPCHealthMonitor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	os.Setenv("READINESS_PROBE_HEALTH_MONITOR", "true")
+	defer os.Unsetenv("READINESS_PROBE_HEALTH_MONITOR")
+	modelName := "admin/cluster--red-ns-testsvc"
+	objects.SharedAviGraphLister().Delete(modelName)
+
+	// The gRPC probe is not decoded into the typed Pod, it is read from the Pod cached by the dynamic Pod informer.
+	podGVR := corev1.SchemeGroupVersion.WithResource("pods")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		podGVR:                "PodList",
+		lib.ReferenceGrantGVR: "ReferenceGrantList",
+	})
+	unstructuredPod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"namespace": "red-ns", "name": "grpc-pod"},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{
+				"name":           "grpc",
+				"readinessProbe": map[string]interface{}{"grpc": map[string]interface{}{"port": int64(9090)}},
+			}},
+		},
+	}}
+	if _, err := dynamicClient.Resource(podGVR).Namespace("red-ns").Create(context.TODO(), unstructuredPod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding unstructured Pod: %v", err)
+	}
+	dynamicInformers := lib.NewDynamicInformers(dynamicClient, false)
+	defer lib.NewDynamicInformers(nil, false)
+	informerStopCh := make(chan struct{})
+	defer close(informerStopCh)
+	go dynamicInformers.PodInformer.Informer().Run(informerStopCh)
+	if !k8scache.WaitForCacheSync(informerStopCh, dynamicInformers.PodInformer.Informer().HasSynced) {
+		t.Fatalf("error in syncing the dynamic Pod informer")
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red-ns", Name: "grpc-pod", Labels: map[string]string{"app": "grpc"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:           "grpc",
+				Ports:          []corev1.ContainerPort{{Name: "grpc", ContainerPort: 8080}},
+				ReadinessProbe: &corev1.Probe{PeriodSeconds: 5},
+			}},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods("red-ns").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods("red-ns").Get("grpc-pod")
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+
+	createEndpointSlice(t, endpointSlice("red-ns", "testsvc", "testsvc-abc", fakeEndpoint{ip: "1.1.1.1"}))
+	integrationtest.CreateServiceWithSelectors(t, "red-ns", "testsvc", corev1.ServiceTypeLoadBalancer, false, map[string]string{"app": "grpc"})
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getHealthMonitor := func() *avinodes.AviHealthMonitorNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].PoolRefs[0].HealthMonitor
+	}
+	g.Eventually(getHealthMonitor, 20*time.Second).ShouldNot(gomega.BeNil())
+	healthMonitor := getHealthMonitor()
+	g.Expect(healthMonitor.Name).To(gomega.Equal("cluster--red-ns-testsvc-TCP-8080--readiness-probe"))
+	g.Expect(healthMonitor.Type).To(gomega.Equal("HEALTH_MONITOR_TCP"))
+	g.Expect(healthMonitor.MonitorPort).To(gomega.Equal(int32(9090)))
+	g.Expect(healthMonitor.SendInterval).To(gomega.Equal(int32(5)))
+	g.Expect(healthMonitor.ReceiveTimeout).To(gomega.Equal(int32(1)))
+
+	integrationtest.DelSVC(t, "red-ns", "testsvc")
+	deleteEndpointSlice(t, "red-ns", "testsvc-abc")
+	KubeClient.CoreV1().Pods("red-ns").Delete(context.TODO(), "grpc-pod", metav1.DeleteOptions{})
+	objects.SharedAviGraphLister().Delete(modelName)
+}
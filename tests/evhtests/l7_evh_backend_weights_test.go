@@ -0,0 +1,105 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package evhtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvhIngressBackendWeights(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-canary",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	ingrFake.Spec.Rules[0].HTTP.Paths = append(ingrFake.Spec.Rules[0].HTTP.Paths, networkingv1.HTTPIngressPath{
+		Path: "/foo",
+		Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: "avisvc-canary",
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		}},
+	})
+	ingrFake.Annotations = map[string]string{lib.BackendWeightsAnnotation: `{"avisvc": 80, "avisvc-canary": 20}`}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	evhNodeName := lib.Encode("cluster--foo.com", lib.EVHVS)
+	pgName := lib.GetEvhPGName("foo-canary", "default", "foo.com", "/foo", "", false)
+	getRatios := func() map[string]int32 {
+		ratios := make(map[string]int32)
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return ratios
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return ratios
+		}
+		evhNode := nodes[0].GetEvhNodeForName(evhNodeName)
+		if evhNode == nil {
+			return ratios
+		}
+		for _, pg := range evhNode.PoolGroupRefs {
+			if pg.Name != pgName {
+				continue
+			}
+			for _, member := range pg.Members {
+				ratios[*member.PoolRef] = *member.Ratio
+			}
+		}
+		return ratios
+	}
+	poolRef := "/api/pool?name=" + lib.GetEvhPoolName("foo-canary", "default", "foo.com", "/foo", "", "avisvc", false)
+	canaryPoolRef := "/api/pool?name=" + lib.GetEvhPoolName("foo-canary", "default", "foo.com", "/foo", "", "avisvc-canary", false)
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolRef: 80, canaryPoolRef: 20}))
+
+	ingrFake.Annotations = map[string]string{lib.BackendWeightsAnnotation: `{"avisvc": 20, "avisvc-canary": 80}`}
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolRef: 20, canaryPoolRef: 80}))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-canary", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.BeEmpty())
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
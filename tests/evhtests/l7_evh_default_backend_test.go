@@ -0,0 +1,110 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package evhtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvhIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-default", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-default", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-default",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	ingrFake.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: "avisvc-default",
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	// The default backend is set on the EVH child of the host.
+	evhNodeName := lib.Encode("cluster--foo.com", lib.EVHVS)
+	getEvhNode := func() *avinodes.AviEvhVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		return nodes[0].GetEvhNodeForName(evhNodeName)
+	}
+	g.Eventually(func() string {
+		if evhNode := getEvhNode(); evhNode != nil {
+			return evhNode.DefaultPool
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal(lib.GetDefaultBackendName(evhNodeName)))
+	evhNode := getEvhNode()
+	var defaultPool *avinodes.AviPoolNode
+	for _, pool := range evhNode.PoolRefs {
+		if pool.Name == evhNode.DefaultPool {
+			defaultPool = pool
+		}
+	}
+	g.Expect(defaultPool).NotTo(gomega.BeNil())
+	g.Expect(defaultPool.Servers).To(gomega.HaveLen(1))
+	g.Expect(*defaultPool.Servers[0].Ip.Addr).To(gomega.HavePrefix("1.2.1"))
+
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		if evhNode := getEvhNode(); evhNode != nil {
+			return evhNode.DefaultPool
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal(""))
+	for _, pool := range getEvhNode().PoolRefs {
+		g.Expect(pool.Name).NotTo(gomega.Equal(lib.GetDefaultBackendName(evhNodeName)))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-default", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, "default", "avisvc-default")
+	integrationtest.DelEP(t, "default", "avisvc-default")
+	TearDownTestForIngress(t, modelName)
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package evhtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvhHTTPRuleMatches(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrname := "samplerr-match"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-matches",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := &v1alpha1.HTTPRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      rrname,
+		},
+		Spec: v1alpha1.HTTPRuleSpec{
+			Fqdn: "foo.com",
+			Paths: []v1alpha1.HTTPRulePaths{{
+				Target: "/foo",
+				Matches: []v1alpha1.HTTPRuleMatch{{
+					Headers: []v1alpha1.HTTPRuleHeaderMatch{{Name: "x-canary", Value: "tr", MatchType: lib.MatchTypePrefix}},
+					Cookie:  &v1alpha1.HTTPRuleHeaderMatch{Name: "canary", Value: "always"},
+					Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+				}},
+			}},
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	evhNodeName := lib.Encode("cluster--foo.com", lib.EVHVS)
+	matchName := lib.GetHTTPRuleMatchName(lib.GetEvhPGName("foo-with-matches", "default", "foo.com", "/foo", "", false), 0)
+	getEvhNode := func() *avinodes.AviEvhVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		return nodes[0].GetEvhNodeForName(evhNodeName)
+	}
+	getMatchRule := func() *avinodes.AviHostPathPortPoolPG {
+		if evhNode := getEvhNode(); evhNode != nil {
+			for _, policy := range evhNode.HttpPolicyRefs {
+				for i := range policy.HppMap {
+					if policy.HppMap[i].Name == matchName {
+						return &policy.HppMap[i]
+					}
+				}
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getMatchRule() != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	hppmap := getMatchRule()
+	g.Expect(hppmap.PoolGroup).To(gomega.Equal(matchName))
+	g.Expect(hppmap.PathRule.HeaderMatches).To(gomega.HaveLen(1))
+	g.Expect(hppmap.PathRule.HeaderMatches[0].MatchCriteria).To(gomega.Equal("HDR_BEGINS_WITH"))
+	g.Expect(hppmap.PathRule.CookieMatch.Name).To(gomega.Equal("canary"))
+	g.Expect(hppmap.PathRule.CookieMatch.Value).To(gomega.Equal("always"))
+	g.Expect(hppmap.PathRule.CookieMatch.MatchCriteria).To(gomega.Equal("HDR_EQUALS"))
+	evhNode := getEvhNode()
+	g.Expect(evhNode.PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(evhNode.PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pool := range evhNode.PoolRefs {
+		if pool.Name == matchName {
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+			g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.HavePrefix("1.2.1"))
+		}
+	}
+
+	// Deleting the HTTPRule removes the pool, poolgroup and http policy rule of the match.
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		return getMatchRule() == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Expect(getEvhNode().PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(getEvhNode().PoolGroupRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-matches", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package evhtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+
+	"github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvhHTTPRuleHeadersAndRewrite(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrname := "samplerr-rewrite"
+	SetUpTestForIngress(t, modelName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-rewrite",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	pathType := networkingv1.PathTypeExact
+	ingrFake.Spec.Rules[0].HTTP.Paths[0].PathType = &pathType
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := &v1alpha1.HTTPRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      rrname,
+		},
+		Spec: v1alpha1.HTTPRuleSpec{
+			Fqdn: "foo.com",
+			Paths: []v1alpha1.HTTPRulePaths{{
+				Target: "/foo",
+				ResponseHeaders: &v1alpha1.HTTPRuleHeaders{
+					Add: []v1alpha1.HTTPRuleHeader{{Name: "x-served-by", Value: "ako"}},
+				},
+				Rewrite: &v1alpha1.HTTPRuleRewrite{PathPrefix: "/bar"},
+			}},
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	evhNodeName := lib.Encode("cluster--foo.com", lib.EVHVS)
+	hppMapName := lib.GetEvhPGName("foo-with-rewrite", "default", "foo.com", "/foo", "", false)
+	getPathRule := func() *avinodes.AviHTTPPathRule {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		evhNode := nodes[0].GetEvhNodeForName(evhNodeName)
+		if evhNode == nil {
+			return nil
+		}
+		for _, policy := range evhNode.HttpPolicyRefs {
+			for _, hppmap := range policy.HppMap {
+				if hppmap.Name == hppMapName {
+					return hppmap.PathRule
+				}
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getPathRule() != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	pathRule := getPathRule()
+	g.Expect(pathRule.RequestHeaders).To(gomega.BeEmpty())
+	g.Expect(pathRule.ResponseHeaders).To(gomega.Equal([]avinodes.AviHTTPHeaderAction{
+		{Action: "HTTP_ADD_HDR", Name: "x-served-by", Value: "ako"},
+	}))
+	// The full path is rewritten for an exact path.
+	g.Expect(*pathRule.RewriteURL).To(gomega.Equal(avinodes.AviHTTPRewriteURL{Path: "/bar"}))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		return getPathRule() == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-rewrite", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	TearDownTestForIngress(t, modelName)
+}
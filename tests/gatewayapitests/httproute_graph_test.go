@@ -0,0 +1,330 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gatewayapitests
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+var KubeClient *k8sfake.Clientset
+var CRDClient *crdfake.Clientset
+var DynamicClient *dynamicfake.FakeDynamicClient
+var ctrl *k8s.AviController
+
+func TestMain(m *testing.M) {
+	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+	os.Setenv("ENABLE_GATEWAY_API", "true")
+
+	akoControlConfig := lib.AKOControlConfig()
+	KubeClient = k8sfake.NewSimpleClientset()
+	CRDClient = crdfake.NewSimpleClientset()
+	DynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		lib.GatewayClassGVR:   "GatewayClassList",
+		lib.GatewayGVR:        "GatewayList",
+		lib.HTTPRouteGVR:      "HTTPRouteList",
+		lib.TLSRouteGVR:       "TLSRouteList",
+		lib.TCPRouteGVR:       "TCPRouteList",
+		lib.UDPRouteGVR:       "UDPRouteList",
+		lib.ReferenceGrantGVR: "ReferenceGrantList",
+	})
+	lib.SetDynamicClientSet(DynamicClient)
+	akoControlConfig.SetCRDClientset(CRDClient)
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, KubeClient, true)
+	akoControlConfig.SetAKOInstanceFlag(true)
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient, DynamicClient: DynamicClient}
+	k8s.NewCRDInformers(CRDClient)
+
+	mcache := cache.SharedAviObjCache()
+	cloudObj := &cache.AviCloudPropertyCache{Name: "Default-Cloud", VType: "mock"}
+	subdomains := []string{"avi.internal", ".com"}
+	cloudObj.NSIpamDNS = subdomains
+	mcache.CloudKeyCache.AviCacheAdd("Default-Cloud", cloudObj)
+
+	integrationtest.InitializeFakeAKOAPIServer()
+
+	integrationtest.NewAviFakeClientInstance(KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+	wgLeaderElection := &sync.WaitGroup{}
+	waitGroupMap["leaderElection"] = wgLeaderElection
+
+	integrationtest.AddConfigMap(KubeClient)
+	integrationtest.PollForSyncStart(ctrl, 10)
+
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddDefaultIngressClass()
+	ctrl.SetSEGroupCloudNameFromNSAnnotations()
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+func toUnstructured(t *testing.T, obj interface{}, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("error in converting object to unstructured: %v", err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+func SetUpGatewayAPIObjects(t *testing.T, gwClassName, gwName, namespace string, listeners []gatewayv1.Listener) {
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: gwClassName},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: lib.GatewayAPIAviController},
+	}
+	if _, err := DynamicClient.Resource(lib.GatewayClassGVR).Create(context.TODO(), toUnstructured(t, gwClass, gatewayv1.SchemeGroupVersion.WithKind("GatewayClass")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding GatewayClass: %v", err)
+	}
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: gwName, Namespace: namespace},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(gwClassName),
+			Listeners:        listeners,
+		},
+	}
+	if _, err := DynamicClient.Resource(lib.GatewayGVR).Namespace(namespace).Create(context.TODO(), toUnstructured(t, gw, gatewayv1.SchemeGroupVersion.WithKind("Gateway")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Gateway: %v", err)
+	}
+}
+
+func TearDownGatewayAPIObjects(t *testing.T, gwClassName, gwName, namespace string) {
+	if err := DynamicClient.Resource(lib.GatewayGVR).Namespace(namespace).Delete(context.TODO(), gwName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting Gateway: %v", err)
+	}
+	if err := DynamicClient.Resource(lib.GatewayClassGVR).Delete(context.TODO(), gwClassName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting GatewayClass: %v", err)
+	}
+}
+
+func getHTTPRoute(name, namespace, gwName string, hostnames []string, path string, backends map[string]int32) *gatewayv1.HTTPRoute {
+	pathType := gatewayv1.PathMatchPathPrefix
+	rule := gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &path}}},
+	}
+	for svc, weight := range backends {
+		port := gatewayv1.PortNumber(8080)
+		backendWeight := weight
+		rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(svc), Port: &port},
+				Weight:                 &backendWeight,
+			},
+		})
+	}
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gwName)}}},
+			Rules:           []gatewayv1.HTTPRouteRule{rule},
+		},
+	}
+	for _, host := range hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(host))
+	}
+	return route
+}
+
+func CreateHTTPRoute(t *testing.T, route *gatewayv1.HTTPRoute) {
+	if _, err := DynamicClient.Resource(lib.HTTPRouteGVR).Namespace(route.Namespace).Create(context.TODO(), toUnstructured(t, route, gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRoute: %v", err)
+	}
+}
+
+func UpdateHTTPRoute(t *testing.T, route *gatewayv1.HTTPRoute) {
+	existing, err := DynamicClient.Resource(lib.HTTPRouteGVR).Namespace(route.Namespace).Get(context.TODO(), route.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting HTTPRoute: %v", err)
+	}
+	updated := toUnstructured(t, route, gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+	updated.SetResourceVersion(existing.GetResourceVersion() + "1")
+	if _, err := DynamicClient.Resource(lib.HTTPRouteGVR).Namespace(route.Namespace).Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRoute: %v", err)
+	}
+}
+
+func DeleteHTTPRoute(t *testing.T, name, namespace string) {
+	if err := DynamicClient.Resource(lib.HTTPRouteGVR).Namespace(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting HTTPRoute: %v", err)
+	}
+}
+
+func httpsListener(name, secretName string) gatewayv1.Listener {
+	mode := gatewayv1.TLSModeTerminate
+	return gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Port:     443,
+		Protocol: gatewayv1.HTTPSProtocolType,
+		TLS: &gatewayv1.GatewayTLSConfig{
+			Mode:            &mode,
+			CertificateRefs: []gatewayv1.SecretObjectReference{{Name: gatewayv1.ObjectName(secretName)}},
+		},
+	}
+}
+
+func TestHTTPRouteSecureHost(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.CreateSVC(t, "default", "avisvc", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc", false, false, "1.1.1")
+	SetUpGatewayAPIObjects(t, "avi-lb", "gw-secure", "default", []gatewayv1.Listener{httpsListener("https", "my-secret")})
+
+	g.Eventually(func() bool {
+		found, _ := objects.GatewayAPIObjLister().GetGatewayToGatewayClass("default/gw-secure")
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	CreateHTTPRoute(t, getHTTPRoute("route-secure", "default", "gw-secure", []string{"foo.com"}, "/foo", map[string]int32{"avisvc": 1}))
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].SniNodes)
+	}, 30*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	sniNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0]
+	g.Expect(sniNode.VHDomainNames).To(gomega.ContainElement("foo.com"))
+	g.Expect(sniNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(sniNode.PoolRefs[0].ServiceMetadata.IsHTTPRoute).To(gomega.Equal(true))
+	g.Expect(sniNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(sniNode.HttpPolicyRefs).To(gomega.HaveLen(1))
+	g.Expect(sniNode.HttpPolicyRefs[0].HppMap[0].Path).To(gomega.ContainElement("/foo"))
+
+	found, gateways := objects.GatewayAPIObjLister().GetRouteToGateways("default/route-secure")
+	g.Expect(found).To(gomega.Equal(true))
+	g.Expect(gateways).To(gomega.ConsistOf("default/gw-secure"))
+
+	DeleteHTTPRoute(t, "route-secure", "default")
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].SniNodes)
+	}, 30*time.Second).Should(gomega.Equal(0))
+
+	TearDownGatewayAPIObjects(t, "avi-lb", "gw-secure", "default")
+	integrationtest.DelSVC(t, "default", "avisvc")
+	integrationtest.DelEP(t, "default", "avisvc")
+	integrationtest.DeleteSecret("my-secret", "default")
+}
+
+func TestHTTPRouteWithoutAKOGateway(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	integrationtest.CreateSVC(t, "default", "avisvc", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc", false, false, "1.1.1")
+
+	CreateHTTPRoute(t, getHTTPRoute("route-nogw", "default", "gw-missing", []string{"foo.com"}, "/foo", map[string]int32{"avisvc": 1}))
+
+	g.Consistently(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].SniNodes) + len(nodes[0].PoolRefs)
+	}, 5*time.Second).Should(gomega.Equal(0))
+
+	found, _ := objects.GatewayAPIObjLister().GetRouteToGateways("default/route-nogw")
+	g.Expect(found).To(gomega.Equal(false))
+
+	DeleteHTTPRoute(t, "route-nogw", "default")
+	integrationtest.DelSVC(t, "default", "avisvc")
+	integrationtest.DelEP(t, "default", "avisvc")
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gatewayapitests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+	gatewayv1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1alpha2"
+)
+
+func getBackendRef(svc string, port int32) gatewayv1.BackendRef {
+	backendPort := gatewayv1.PortNumber(port)
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(svc), Port: &backendPort},
+	}
+}
+
+func CreateTCPRoute(t *testing.T, name, namespace, gwName, svc string, port int32) {
+	route := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gwName)}}},
+			Rules:           []gatewayv1alpha2.TCPRouteRule{{BackendRefs: []gatewayv1.BackendRef{getBackendRef(svc, port)}}},
+		},
+	}
+	if _, err := DynamicClient.Resource(lib.TCPRouteGVR).Namespace(namespace).Create(context.TODO(), toUnstructured(t, route, gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TCPRoute: %v", err)
+	}
+}
+
+func CreateTLSRoute(t *testing.T, name, namespace, gwName string, hostnames []string, svc string, port int32) {
+	route := &gatewayv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gwName)}}},
+			Rules:           []gatewayv1alpha2.TLSRouteRule{{BackendRefs: []gatewayv1.BackendRef{getBackendRef(svc, port)}}},
+		},
+	}
+	for _, host := range hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(host))
+	}
+	if _, err := DynamicClient.Resource(lib.TLSRouteGVR).Namespace(namespace).Create(context.TODO(), toUnstructured(t, route, gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TLSRoute: %v", err)
+	}
+}
+
+func TestTCPRouteL4VirtualService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--default-gw-tcp"
+	integrationtest.CreateSVC(t, "default", "avisvc-tcp", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-tcp", false, false, "1.1.1")
+	SetUpGatewayAPIObjects(t, "avi-lb-tcp", "gw-tcp", "default", []gatewayv1.Listener{{
+		Name:     "tcp",
+		Port:     8081,
+		Protocol: gatewayv1.TCPProtocolType,
+	}})
+
+	g.Eventually(func() bool {
+		found, _ := objects.GatewayAPIObjLister().GetGatewayToGatewayClass("default/gw-tcp")
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	CreateTCPRoute(t, "route-tcp", "default", "gw-tcp", "avisvc-tcp", 8080)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return found && aviModel != nil && len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()) == 1
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	vsNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0]
+	g.Expect(vsNode.ServiceMetadata.IsGatewayAPI).To(gomega.Equal(true))
+	g.Expect(vsNode.ServiceMetadata.Gateway).To(gomega.Equal("default/gw-tcp"))
+	g.Expect(vsNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PortProto[0].Port).To(gomega.Equal(int32(8081)))
+	g.Expect(vsNode.PortProto[0].Protocol).To(gomega.Equal("TCP"))
+	g.Expect(vsNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolRefs[0].Name).To(gomega.Equal("cluster--default-avisvc-tcp-gw-tcp-TCP-8081"))
+	g.Expect(vsNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(vsNode.L4PolicyRefs).To(gomega.HaveLen(1))
+
+	found, gateways := objects.GatewayAPIObjLister().GetServiceToL4Gateways("default/avisvc-tcp")
+	g.Expect(found).To(gomega.Equal(true))
+	g.Expect(gateways).To(gomega.ConsistOf("default/gw-tcp"))
+
+	if err := DynamicClient.Resource(lib.TCPRouteGVR).Namespace("default").Delete(context.TODO(), "route-tcp", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TCPRoute: %v", err)
+	}
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return found && aviModel == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	TearDownGatewayAPIObjects(t, "avi-lb-tcp", "gw-tcp", "default")
+	integrationtest.DelSVC(t, "default", "avisvc-tcp")
+	integrationtest.DelEP(t, "default", "avisvc-tcp")
+}
+
+func TestTLSRoutePassthrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/" + lib.GetPassthroughShardVSName("bar.com", "", "", lib.PassthroughShardSize())
+	integrationtest.CreateSVC(t, "default", "avisvc-tls", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-tls", false, false, "1.1.1")
+	mode := gatewayv1.TLSModePassthrough
+	SetUpGatewayAPIObjects(t, "avi-lb-tls", "gw-tls", "default", []gatewayv1.Listener{{
+		Name:     "tls",
+		Port:     443,
+		Protocol: gatewayv1.TLSProtocolType,
+		TLS:      &gatewayv1.GatewayTLSConfig{Mode: &mode},
+	}})
+
+	g.Eventually(func() bool {
+		found, _ := objects.GatewayAPIObjLister().GetGatewayToGatewayClass("default/gw-tls")
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	CreateTLSRoute(t, "route-tls", "default", "gw-tls", []string{"bar.com"}, "avisvc-tls", 8080)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs)
+	}, 30*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	vsNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0]
+	g.Expect(vsNode.PoolRefs[0].ServiceMetadata.IsTLSRoute).To(gomega.Equal(true))
+	g.Expect(vsNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PassthroughChildNodes).To(gomega.HaveLen(0))
+
+	found, gateways := objects.GatewayAPIObjLister().GetRouteToGateways("TLSRoute/default/route-tls")
+	g.Expect(found).To(gomega.Equal(true))
+	g.Expect(gateways).To(gomega.ConsistOf("default/gw-tls"))
+
+	if err := DynamicClient.Resource(lib.TLSRouteGVR).Namespace("default").Delete(context.TODO(), "route-tls", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TLSRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs)
+	}, 30*time.Second).Should(gomega.Equal(0))
+
+	TearDownGatewayAPIObjects(t, "avi-lb-tls", "gw-tls", "default")
+	integrationtest.DelSVC(t, "default", "avisvc-tls")
+	integrationtest.DelEP(t, "default", "avisvc-tls")
+}
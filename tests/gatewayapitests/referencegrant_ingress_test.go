@@ -0,0 +1,176 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gatewayapitests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+	gatewayv1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1beta1"
+)
+
+func CreateReferenceGrant(t *testing.T, name, namespace, fromNamespace, svcName string) {
+	svc := gatewayv1.ObjectName(svcName)
+	grant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: lib.IngressGroup, Kind: "Ingress", Namespace: gatewayv1.Namespace(fromNamespace)}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Group: lib.CoreGroup, Kind: "Service", Name: &svc}},
+		},
+	}
+	if _, err := DynamicClient.Resource(lib.ReferenceGrantGVR).Namespace(namespace).Create(context.TODO(), toUnstructured(t, grant, gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant")), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding ReferenceGrant: %v", err)
+	}
+}
+
+// crossNamespacePools returns the pools of the model created for the ingress.
+func crossNamespacePools(modelName, ingName string) []*avinodes.AviPoolNode {
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if aviModel == nil {
+		return nil
+	}
+	var pools []*avinodes.AviPoolNode
+	for _, vsNode := range aviModel.(*avinodes.AviObjectGraph).GetAviVS() {
+		for _, pool := range vsNode.PoolRefs {
+			if strings.HasSuffix(pool.Name, ingName) {
+				pools = append(pools, pool)
+			}
+		}
+	}
+	return pools
+}
+
+func TestIngressCrossNamespaceBackendWithReferenceGrant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	integrationtest.CreateSVC(t, "red", "avisvc-xns", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "red", "avisvc-xns", false, false, "1.1.1")
+
+	ingress := (integrationtest.FakeIngress{
+		Name:        "ingress-xns",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/xns"},
+		ServiceName: "avisvc-xns",
+	}).Ingress()
+	ingress.Annotations = map[string]string{lib.BackendNamespacesAnnotation: `{"avisvc-xns": "red"}`}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingress, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		found, ingresses := objects.SharedSvcLister().IngressMappings("red").GetSvcToIng("avisvc-xns")
+		return found && len(ingresses) == 1 && ingresses[0] == "default/ingress-xns"
+	}, 30*time.Second).Should(gomega.Equal(true))
+	// The backend is skipped without a ReferenceGrant in the namespace of the service.
+	g.Consistently(func() int {
+		return len(crossNamespacePools(modelName, "ingress-xns"))
+	}, 5*time.Second).Should(gomega.Equal(0))
+
+	CreateReferenceGrant(t, "grant-xns", "red", "default", "avisvc-xns")
+	g.Eventually(func() int {
+		return len(crossNamespacePools(modelName, "ingress-xns"))
+	}, 30*time.Second).Should(gomega.Equal(1))
+	pool := crossNamespacePools(modelName, "ingress-xns")[0]
+	g.Expect(pool.Servers).To(gomega.HaveLen(1))
+	g.Expect(pool.ServiceMetadata.Namespace).To(gomega.Equal("default"))
+
+	// Endpoint updates in the namespace of the service are processed for the ingress.
+	integrationtest.ScaleCreateEP(t, "red", "avisvc-xns")
+	g.Eventually(func() int {
+		pools := crossNamespacePools(modelName, "ingress-xns")
+		if len(pools) != 1 {
+			return 0
+		}
+		return len(pools[0].Servers)
+	}, 30*time.Second).Should(gomega.Equal(2))
+
+	if err := DynamicClient.Resource(lib.ReferenceGrantGVR).Namespace("red").Delete(context.TODO(), "grant-xns", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting ReferenceGrant: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(crossNamespacePools(modelName, "ingress-xns"))
+	}, 30*time.Second).Should(gomega.Equal(0))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "ingress-xns", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting Ingress: %v", err)
+	}
+	g.Eventually(func() int {
+		_, ingresses := objects.SharedSvcLister().IngressMappings("red").GetSvcToIng("avisvc-xns")
+		return len(ingresses)
+	}, 30*time.Second).Should(gomega.Equal(0))
+	integrationtest.DelSVC(t, "red", "avisvc-xns")
+	integrationtest.DelEP(t, "red", "avisvc-xns")
+}
+
+func TestCrossNamespaceIngressMappingsConcurrentUpdates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The ingresses in different namespaces are handled by different workers, and map to the same service.
+	svcLister := objects.SharedSvcLister()
+	var ingNamespaces []string
+	for i := 0; i < 50; i++ {
+		ingNamespaces = append(ingNamespaces, fmt.Sprintf("ing-ns-%d", i))
+	}
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for _, ingNS := range ingNamespaces {
+		wg.Add(1)
+		go func(ingNS string) {
+			defer wg.Done()
+			<-start
+			svcLister.UpdateCrossNamespaceIngressMappings(ingNS, "foo", "svc-ns", "concurrentsvc")
+		}(ingNS)
+	}
+	close(start)
+	wg.Wait()
+
+	_, ingresses := svcLister.IngressMappings("svc-ns").GetSvcToIng("concurrentsvc")
+	g.Expect(ingresses).To(gomega.HaveLen(len(ingNamespaces)))
+	for _, ingNS := range ingNamespaces {
+		g.Expect(ingresses).To(gomega.ContainElement(ingNS + "/foo"))
+		_, svcs := svcLister.IngressMappings(ingNS).GetIngToSvc("foo")
+		g.Expect(svcs).To(gomega.Equal([]string{"svc-ns/concurrentsvc"}))
+	}
+
+	start = make(chan struct{})
+	for _, ingNS := range ingNamespaces {
+		wg.Add(1)
+		go func(ingNS string) {
+			defer wg.Done()
+			<-start
+			svcLister.RemoveCrossNamespaceIngressMappings(ingNS, "foo", "svc-ns", "concurrentsvc")
+		}(ingNS)
+	}
+	close(start)
+	wg.Wait()
+
+	_, ingresses = svcLister.IngressMappings("svc-ns").GetSvcToIng("concurrentsvc")
+	g.Expect(ingresses).To(gomega.BeEmpty())
+}
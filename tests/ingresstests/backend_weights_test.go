@@ -0,0 +1,212 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+// canaryIngress returns an ingress for foo.com/foo with avisvc as the backend, and avisvc-canary as the
+// alternate backend of the same path.
+func canaryIngress(name string, weights string, secure bool) *networkingv1.Ingress {
+	fakeIngress := integrationtest.FakeIngress{
+		Name:        name,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}
+	if secure {
+		fakeIngress.TlsSecretDNS = map[string][]string{"my-secret": {"foo.com"}}
+	}
+	ingress := fakeIngress.Ingress()
+	ingress.Spec.Rules[0].HTTP.Paths = append(ingress.Spec.Rules[0].HTTP.Paths, networkingv1.HTTPIngressPath{
+		Path: "/foo",
+		Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: "avisvc-canary",
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		}},
+	})
+	if weights != "" {
+		ingress.Annotations = map[string]string{lib.BackendWeightsAnnotation: weights}
+	}
+	return ingress
+}
+
+// poolRatios returns the ratio of each pool in the poolgroup.
+func poolRatios(pgNode *avinodes.AviPoolGroupNode) map[string]int32 {
+	ratios := make(map[string]int32)
+	if pgNode == nil {
+		return ratios
+	}
+	for _, member := range pgNode.Members {
+		poolName := (*member.PoolRef)[len("/api/pool?name="):]
+		ratios[poolName] = *member.Ratio
+	}
+	return ratios
+}
+
+func TestL7ModelIngressBackendWeights(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingress := canaryIngress("foo-canary", `{"avisvc": 90, "avisvc-canary": 10}`, false)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingress, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolName := lib.GetL7PoolName("foo.com/foo", "default", "foo-canary", "")
+	canaryPoolName := lib.GetL7PoolName("foo.com/foo", "default", "foo-canary", "", "avisvc-canary")
+	getRatios := func() map[string]int32 {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		return poolRatios(aviModel.(*avinodes.AviObjectGraph).GetPoolGroupByName(lib.GetL7SharedPGName("cluster--Shared-L7-0")))
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 90, canaryPoolName: 10}))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	for _, pool := range nodes[0].PoolRefs {
+		if pool.Name == canaryPoolName {
+			g.Expect(pool.PriorityLabel).To(gomega.Equal("foo.com/foo"))
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+			g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.HavePrefix("1.2.1"))
+		}
+	}
+
+	// Weight changes only update the ratio of the pools in the poolgroup.
+	ingress = canaryIngress("foo-canary", `{"avisvc": 50, "avisvc-canary": 50}`, false)
+	ingress.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 50, canaryPoolName: 50}))
+
+	// Removing the alternate backend removes its pool, and retains the pool of the path.
+	ingress = (integrationtest.FakeIngress{
+		Name:        "foo-canary",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	ingress.ResourceVersion = "3"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 100}))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	for _, pool := range aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs {
+		g.Expect(pool.Name).NotTo(gomega.Equal(canaryPoolName))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-canary", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.BeEmpty())
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestL7ModelSecureIngressBackendWeights(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingress := canaryIngress("foo-canary", `{"avisvc-canary": 0}`, true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingress, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolName := lib.GetSniPoolName("foo-canary", "default", "foo.com", "/foo", "", false)
+	canaryPoolName := lib.GetSniPoolName("foo-canary", "default", "foo.com", "/foo", "", false, "avisvc-canary")
+	getRatios := func() map[string]int32 {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return nil
+		}
+		return poolRatios(nodes[0].SniNodes[0].GetPGForVSByName(lib.GetSniPGName("foo-canary", "default", "foo.com", "/foo", "", false)))
+	}
+	// The alternate backend with weight 0 is not selected for new connections.
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 100, canaryPoolName: 0}))
+
+	ingress = canaryIngress("foo-canary", `{"avisvc-canary": 20}`, true)
+	ingress.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 100, canaryPoolName: 20}))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0].PoolRefs).To(gomega.HaveLen(2))
+
+	ingress = (integrationtest.FakeIngress{
+		Name:         "foo-canary",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}).Ingress()
+	ingress.ResourceVersion = "3"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingress, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getRatios, 30*time.Second).Should(gomega.Equal(map[string]int32{poolName: 100}))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes[0].PoolRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-canary", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+	}, 30*time.Second).Should(gomega.Equal(0))
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
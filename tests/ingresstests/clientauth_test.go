@@ -0,0 +1,186 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func addCASecret(name, caCert string) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			lib.ClientAuthCAKey: []byte(caCert),
+		},
+	}
+	KubeClient.CoreV1().Secrets("default").Create(context.TODO(), secret, metav1.CreateOptions{})
+}
+
+// waitForSecret waits for the Secret to be present in the informer cache, as the HostRule is validated against it.
+func waitForSecret(g *gomega.WithT, name string) {
+	g.Eventually(func() error {
+		_, err := utils.GetInformers().SecretInformer.Lister().Secrets("default").Get(name)
+		return err
+	}, 10*time.Second).Should(gomega.BeNil())
+}
+
+func TestHostRuleClientAuthWithSecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-clientauth"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+	addCASecret("client-ca", "-----BEGIN CERTIFICATE-----\nclient-ca\n-----END CERTIFICATE-----")
+	waitForSecret(g, "client-ca")
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.ClientAuth = &v1alpha1.HostRuleClientAuth{
+		CA: v1alpha1.HostRuleSecret{
+			Name: "client-ca",
+			Type: v1alpha1.HostRuleSecretTypeSecretReference,
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	clientAuthName := lib.GetClientAuthName("cluster--foo.com")
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.ClientAuth != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	clientAuth := getRateLimitSniNode(modelName).ClientAuth
+	g.Expect(clientAuth.Name).To(gomega.Equal(clientAuthName))
+	g.Expect(clientAuth.Mode).To(gomega.Equal("SSL_CLIENT_CERTIFICATE_REQUIRE"))
+	g.Expect(clientAuth.PkiProfileName).To(gomega.Equal(clientAuthName))
+	g.Expect(clientAuth.PkiProfile).NotTo(gomega.BeNil())
+	g.Expect(clientAuth.PkiProfile.CACert).To(gomega.ContainSubstring("client-ca"))
+
+	// The application profile and the PKI profile are created in the controller.
+	clientAuthKey := cache.NamespaceName{Namespace: "admin", Name: clientAuthName}
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() bool {
+		_, found := mcache.AppProfileCache.AviCacheGet(clientAuthKey)
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+	_, found := mcache.PKIProfileCache.AviCacheGet(clientAuthKey)
+	g.Expect(found).To(gomega.Equal(true))
+
+	// A CA Secret without the CA bundle rejects the HostRule.
+	integrationtest.AddSecret("client-ca-invalid", "default", "tlsCert", "tlsKey")
+	waitForSecret(g, "client-ca-invalid")
+	hostrule.Spec.VirtualHost.TLS.ClientAuth.CA.Name = "client-ca-invalid"
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.ClientAuth == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.AppProfileCache.AviCacheGet(clientAuthKey)
+		return found
+	}, 30*time.Second).Should(gomega.Equal(false))
+	_, found = mcache.PKIProfileCache.AviCacheGet(clientAuthKey)
+	g.Expect(found).To(gomega.Equal(false))
+
+	integrationtest.DeleteSecret("client-ca", "default")
+	integrationtest.DeleteSecret("client-ca-invalid", "default")
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostRuleClientAuthWithPKIProfileRef(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-clientauth-ref"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.ClientAuth = &v1alpha1.HostRuleClientAuth{
+		Mode: lib.ClientAuthModeRequest,
+		CA: v1alpha1.HostRuleSecret{
+			Name: "thisisaviref-pkiprofile",
+			Type: v1alpha1.HostRuleSecretTypeAviReference,
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.ClientAuth != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	clientAuth := getRateLimitSniNode(modelName).ClientAuth
+	g.Expect(clientAuth.Mode).To(gomega.Equal("SSL_CLIENT_CERTIFICATE_REQUEST"))
+	g.Expect(clientAuth.PkiProfileName).To(gomega.Equal("thisisaviref-pkiprofile"))
+	g.Expect(clientAuth.PkiProfile).To(gomega.BeNil())
+
+	// The client certificates can not be validated along with an application profile of the HostRule.
+	hostrule.Spec.VirtualHost.ApplicationProfile = "thisisaviref-appprof"
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.ClientAuth == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
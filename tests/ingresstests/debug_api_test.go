@@ -0,0 +1,115 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/debug"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+// callDebugApi calls the handler of the debug API route with the query, and returns the response.
+func callDebugApi(t *testing.T, route, query string) *httptest.ResponseRecorder {
+	for _, operation := range debug.NewModel().ApiOperationMap() {
+		if operation.Route == route {
+			recorder := httptest.NewRecorder()
+			operation.Handler(recorder, httptest.NewRequest("GET", route+"?"+query, nil))
+			return recorder
+		}
+	}
+	t.Fatalf("route %s not found in the debug API", route)
+	return nil
+}
+
+func TestDebugApiForSNI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:         "foo-with-debug",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	var modelNames []string
+	json.Unmarshal(callDebugApi(t, "/api/debug/models", "").Body.Bytes(), &modelNames)
+	g.Expect(modelNames).To(gomega.ContainElement(modelName))
+
+	// The model is returned with the private keys of the certificates redacted.
+	var model debug.ModelResponse
+	resp := callDebugApi(t, "/api/debug/model", "name="+modelName)
+	g.Expect(resp.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(json.Unmarshal(resp.Body.Bytes(), &model)).To(gomega.Succeed())
+	g.Expect(model.Name).To(gomega.Equal(modelName))
+	var vsNode string
+	for _, node := range model.Nodes {
+		if node.Type == "VirtualServiceNode" {
+			vsNode = string(node.Node)
+		}
+	}
+	g.Expect(vsNode).To(gomega.ContainSubstring(`"Name":"cluster--foo.com"`))
+	g.Expect(vsNode).To(gomega.ContainSubstring(`"Key":"REDACTED"`))
+	g.Expect(vsNode).NotTo(gomega.ContainSubstring("dGxzS2V5")) // base64 of tlsKey
+	g.Expect(callDebugApi(t, "/api/debug/model", "name=admin/unknown").Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(callDebugApi(t, "/api/debug/model", "").Code).To(gomega.Equal(http.StatusBadRequest))
+
+	// The cache of the parent VS includes the cache of the SNI child VS.
+	var vsCache debug.VSCacheResponse
+	g.Eventually(func() int {
+		vsCache = debug.VSCacheResponse{}
+		json.Unmarshal(callDebugApi(t, "/api/debug/vscache", "name=cluster--Shared-L7-0").Body.Bytes(), &vsCache)
+		return len(vsCache.ChildVirtualServices)
+	}, 20*time.Second).Should(gomega.Equal(1))
+	g.Expect(vsCache.VirtualService.Name).To(gomega.Equal("cluster--Shared-L7-0"))
+	g.Expect(vsCache.VSVips).To(gomega.HaveLen(1))
+	g.Expect(vsCache.ChildVirtualServices[0].VirtualService.Name).To(gomega.Equal("cluster--foo.com"))
+	g.Expect(vsCache.ChildVirtualServices[0].Pools).To(gomega.HaveLen(1))
+	g.Expect(vsCache.ChildVirtualServices[0].SSLKeyCerts).To(gomega.HaveLen(1))
+	g.Expect(callDebugApi(t, "/api/debug/vscache", "name=unknown&tenant=admin").Code).To(gomega.Equal(http.StatusNotFound))
+
+	var k8sObjects debug.KubernetesObjectsResponse
+	resp = callDebugApi(t, "/api/debug/k8sobjects", "name=cluster--Shared-L7-0")
+	g.Expect(resp.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(json.Unmarshal(resp.Body.Bytes(), &k8sObjects)).To(gomega.Succeed())
+	g.Expect(k8sObjects.Ingresses).To(gomega.Equal([]string{"default/foo-with-debug"}))
+	g.Expect(k8sObjects.Services).To(gomega.Equal([]string{"default/avisvc"}))
+	g.Expect(k8sObjects.Secrets).To(gomega.Equal([]string{"default/my-secret"}))
+	g.Expect(k8sObjects.HostNames).To(gomega.Equal([]string{"foo.com"}))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-debug", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func setDefaultBackend(ingress *networkingv1.Ingress, svcName string) {
+	ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: svcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+}
+
+// defaultBackendPool returns the pool built for the default backend on the shard vs.
+func defaultBackendPool(modelName string) *avinodes.AviPoolNode {
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) == 0 || nodes[0].DefaultPoolGroup == "" {
+		return nil
+	}
+	for _, pool := range nodes[0].PoolRefs {
+		if pool.Name == nodes[0].DefaultPoolGroup {
+			return pool
+		}
+	}
+	return nil
+}
+
+func TestL7ModelIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-default", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-default", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-default",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	setDefaultBackend(ingrFake, "avisvc-default")
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() bool {
+		return defaultBackendPool(modelName) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].DefaultPoolGroup).To(gomega.Equal(lib.GetDefaultBackendName("cluster--Shared-L7-0")))
+	g.Expect(defaultBackendPool(modelName).Servers).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].HTTPDSrefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].HTTPDSrefs[0].PoolGroupRefs).To(gomega.HaveLen(2))
+	g.Expect(nodes[0].HTTPDSrefs[0].Script).To(gomega.ContainSubstring(`["foo.com"]=true`))
+	// The default backend pool is not a member of the shard poolgroup.
+	for _, pg := range nodes[0].PoolGroupRefs {
+		if pg.Name == "cluster--Shared-L7-0" {
+			for _, member := range pg.Members {
+				g.Expect(*member.PoolRef).NotTo(gomega.ContainSubstring(nodes[0].DefaultPoolGroup))
+			}
+		}
+	}
+
+	// Removing the default backend from the ingress removes the pool and the poolgroup.
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].DefaultPoolGroup
+	}, 30*time.Second).Should(gomega.Equal(""))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].HTTPDSrefs[0].PoolGroupRefs).To(gomega.HaveLen(1))
+	for _, pool := range nodes[0].PoolRefs {
+		g.Expect(pool.Name).NotTo(gomega.Equal(lib.GetDefaultBackendName("cluster--Shared-L7-0")))
+	}
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-default", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, "default", "avisvc-default")
+	integrationtest.DelEP(t, "default", "avisvc-default")
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestL7ModelIngressDefaultBackendConflict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc-default", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-default", false, false, "1.2.1")
+	integrationtest.CreateSVC(t, "default", "avisvc-default2", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-default2", false, false, "1.3.1")
+
+	ingrFake1 := (integrationtest.FakeIngress{
+		Name:        "foo-with-default1",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	setDefaultBackend(ingrFake1, "avisvc-default")
+	ingrFake1.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake1, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		pool := defaultBackendPool(modelName)
+		return pool != nil && pool.IngressName == "foo-with-default1"
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	ingrFake2 := (integrationtest.FakeIngress{
+		Name:        "foo-with-default2",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/bar"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	setDefaultBackend(ingrFake2, "avisvc-default2")
+	ingrFake2.CreationTimestamp = metav1.Now()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake2, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	// The default backend of the oldest ingress is retained.
+	found, ingresses := avinodes.SharedDefaultBackendLister().GetVSIngresses(avinodes.DefaultBackendVS{ModelName: modelName, VSName: "cluster--Shared-L7-0"})
+	g.Eventually(func() int {
+		found, ingresses = avinodes.SharedDefaultBackendLister().GetVSIngresses(avinodes.DefaultBackendVS{ModelName: modelName, VSName: "cluster--Shared-L7-0"})
+		return len(ingresses)
+	}, 30*time.Second).Should(gomega.Equal(2))
+	g.Expect(found).To(gomega.Equal(true))
+	g.Consistently(func() string {
+		if pool := defaultBackendPool(modelName); pool != nil {
+			return pool.IngressName
+		}
+		return ""
+	}, 5*time.Second).Should(gomega.Equal("foo-with-default1"))
+	g.Expect(*defaultBackendPool(modelName).Servers[0].Ip.Addr).To(gomega.HavePrefix("1.2.1"))
+
+	// The default backend of the next ingress is used once the owner is deleted.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-default1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		if pool := defaultBackendPool(modelName); pool != nil {
+			return pool.IngressName
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal("foo-with-default2"))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-default2", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() bool {
+		return defaultBackendPool(modelName) == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	integrationtest.DelSVC(t, "default", "avisvc-default")
+	integrationtest.DelEP(t, "default", "avisvc-default")
+	integrationtest.DelSVC(t, "default", "avisvc-default2")
+	integrationtest.DelEP(t, "default", "avisvc-default2")
+	TearDownTestForIngress(t, modelName)
+}
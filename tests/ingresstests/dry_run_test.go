@@ -0,0 +1,95 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/debug"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/rest"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func TestDryRunPlansRestOperations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	os.Setenv(lib.DRY_RUN, "true")
+	rest.ResetDryRunPlan()
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-dry-run",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolName := "cluster--foo.com_foo-default-foo-dry-run"
+	findPlannedOp := func(model, name string) *rest.PlannedRestOp {
+		for _, plannedOp := range rest.GetDryRunPlan()["admin/cluster--Shared-L7-0"] {
+			if plannedOp.Model == model && plannedOp.Name == name {
+				return plannedOp
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() *rest.PlannedRestOp {
+		return findPlannedOp("Pool", poolName)
+	}, 20*time.Second).ShouldNot(gomega.BeNil())
+
+	plannedPool := findPlannedOp("Pool", poolName)
+	g.Expect(plannedPool.Operation).To(gomega.Equal(rest.PlannedCreate))
+	g.Expect(plannedPool.Key).To(gomega.Equal(modelName))
+	g.Expect(plannedPool.Tenant).To(gomega.Equal("admin"))
+	g.Expect(plannedPool.Object).To(gomega.HaveKeyWithValue("name", poolName))
+	g.Expect(plannedPool.Cached).To(gomega.BeNil())
+	g.Expect(findPlannedOp("VirtualService", "cluster--Shared-L7-0")).NotTo(gomega.BeNil())
+
+	// Nothing is created, hence the pool is not added to the cache.
+	_, found := cache.SharedAviObjCache().PoolCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: poolName})
+	g.Expect(found).To(gomega.BeFalse())
+
+	var plan debug.DryRunPlanResponse
+	g.Expect(json.Unmarshal(callDebugApi(t, "/api/debug/plan", "").Body.Bytes(), &plan)).To(gomega.Succeed())
+	g.Expect(plan.DryRun).To(gomega.BeTrue())
+	g.Expect(plan.Plans).To(gomega.HaveKey("admin/cluster--Shared-L7-0"))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-dry-run", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Eventually(func() *rest.PlannedRestOp {
+		return findPlannedOp("Pool", poolName)
+	}, 20*time.Second).Should(gomega.BeNil())
+
+	os.Unsetenv(lib.DRY_RUN)
+	rest.ResetDryRunPlan()
+	TearDownTestForIngress(t, modelName)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func TestHTTPRuleInlineHealthMonitorsForSNI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-inline-hm"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:         "foo-with-hm",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := matchHTTPRule(rrname, nil)
+	httprule.Spec.Paths[0].HealthMonitors = []string{"thisisaviref-hm1"}
+	httprule.Spec.Paths[0].InlineHealthMonitors = []v1alpha1.HTTPRuleHealthMonitor{
+		{
+			Name:          "http",
+			Type:          "HTTP",
+			SendString:    "GET /healthz HTTP/1.1\r\nHost: foo.com\r\n\r\n",
+			ResponseCodes: []string{"HTTP_2XX"},
+			SendInterval:  5,
+			FailedChecks:  3,
+		},
+		{
+			Name:        "tcp",
+			Type:        "TCP",
+			MonitorPort: 9090,
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	poolName := "cluster--default-foo.com_foo-foo-with-hm"
+	getPool := func() *avinodes.AviPoolNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 || len(nodes[0].SniNodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].SniNodes[0].PoolRefs[0]
+	}
+	g.Eventually(func() int {
+		if pool := getPool(); pool != nil {
+			return len(pool.HTTPRuleHealthMonitors)
+		}
+		return 0
+	}, 30*time.Second).Should(gomega.Equal(2))
+
+	pool := getPool()
+	httpHMName := poolName + "-http--httprule"
+	tcpHMName := poolName + "-tcp--httprule"
+	g.Expect(pool.HealthMonitors).To(gomega.Equal([]string{
+		"/api/healthmonitor?name=thisisaviref-hm1",
+		"/api/healthmonitor?name=" + httpHMName,
+		"/api/healthmonitor?name=" + tcpHMName,
+	}))
+	httpHM := pool.HTTPRuleHealthMonitors[0]
+	g.Expect(httpHM.Name).To(gomega.Equal(httpHMName))
+	g.Expect(httpHM.Type).To(gomega.Equal("HEALTH_MONITOR_HTTP"))
+	g.Expect(httpHM.HTTPRequest).To(gomega.Equal("GET /healthz HTTP/1.1\r\nHost: foo.com\r\n\r\n"))
+	g.Expect(httpHM.ResponseCodes).To(gomega.Equal([]string{"HTTP_2XX"}))
+	g.Expect(httpHM.SendInterval).To(gomega.Equal(int32(5)))
+	g.Expect(httpHM.ReceiveTimeout).To(gomega.Equal(int32(4)))
+	g.Expect(httpHM.SuccessfulChecks).To(gomega.Equal(int32(2)))
+	g.Expect(httpHM.FailedChecks).To(gomega.Equal(int32(3)))
+	tcpHM := pool.HTTPRuleHealthMonitors[1]
+	g.Expect(tcpHM.Name).To(gomega.Equal(tcpHMName))
+	g.Expect(tcpHM.Type).To(gomega.Equal("HEALTH_MONITOR_TCP"))
+	g.Expect(tcpHM.MonitorPort).To(gomega.Equal(int32(9090)))
+	g.Expect(tcpHM.SendInterval).To(gomega.Equal(int32(10)))
+
+	mcache := cache.SharedAviObjCache()
+	healthMonitorFound := func(name string) func() bool {
+		return func() bool {
+			_, found := mcache.HealthMonitorCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: name})
+			return found
+		}
+	}
+	g.Eventually(healthMonitorFound(httpHMName), 20*time.Second).Should(gomega.BeTrue())
+	g.Eventually(healthMonitorFound(tcpHMName), 20*time.Second).Should(gomega.BeTrue())
+	g.Eventually(func() []cache.NamespaceName {
+		poolCache, found := mcache.PoolCache.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: poolName})
+		if !found {
+			return nil
+		}
+		return poolCache.(*cache.AviPoolCache).HealthMonitorCollection
+	}, 20*time.Second).Should(gomega.HaveLen(2))
+
+	// A receive timeout which is not shorter than the send interval rejects the HTTPRule.
+	httprule.Spec.Paths[0].InlineHealthMonitors[0].ReceiveTimeout = 5
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	// Removing a health monitor from the HTTPRule deletes it.
+	httprule.Spec.Paths[0].InlineHealthMonitors = httprule.Spec.Paths[0].InlineHealthMonitors[1:]
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	g.Eventually(healthMonitorFound(httpHMName), 20*time.Second).Should(gomega.BeFalse())
+	g.Expect(healthMonitorFound(tcpHMName)()).To(gomega.BeTrue())
+
+	// Deleting the HTTPRule deletes the remaining health monitor.
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(healthMonitorFound(tcpHMName), 20*time.Second).Should(gomega.BeFalse())
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-hm", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+// matchHTTPRule returns an HTTPRule for foo.com/foo with the matches.
+func matchHTTPRule(rrname string, matches []v1alpha1.HTTPRuleMatch) *v1alpha1.HTTPRule {
+	return &v1alpha1.HTTPRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      rrname,
+		},
+		Spec: v1alpha1.HTTPRuleSpec{
+			Fqdn: "foo.com",
+			Paths: []v1alpha1.HTTPRulePaths{{
+				Target:  "/foo",
+				Matches: matches,
+			}},
+		},
+	}
+}
+
+func getHTTPRuleStatus(rrname string) string {
+	httprule, err := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	return httprule.Status.Status
+}
+
+func TestHTTPRuleMatchesForSNI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-match"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:         "foo-with-matches",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := matchHTTPRule(rrname, []v1alpha1.HTTPRuleMatch{{
+		Headers: []v1alpha1.HTTPRuleHeaderMatch{{Name: "x-canary", Value: "true"}},
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}, {
+		Cookie:  &v1alpha1.HTTPRuleHeaderMatch{Name: "canary", MatchType: lib.MatchTypeExists},
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}})
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	pgName := lib.GetSniPGName("foo-with-matches", "default", "foo.com", "/foo", "", false)
+	getSniNode := func() *avinodes.AviVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return nil
+		}
+		return nodes[0].SniNodes[0]
+	}
+	getMatchRules := func() []avinodes.AviHostPathPortPoolPG {
+		var rules []avinodes.AviHostPathPortPoolPG
+		if sniNode := getSniNode(); sniNode != nil {
+			for _, policy := range sniNode.HttpPolicyRefs {
+				for _, hppmap := range policy.HppMap {
+					if hppmap.PathRule != nil {
+						rules = append(rules, hppmap)
+					}
+				}
+			}
+		}
+		return rules
+	}
+	g.Eventually(func() int {
+		return len(getMatchRules())
+	}, 30*time.Second).Should(gomega.Equal(2))
+
+	sniNode := getSniNode()
+	g.Expect(sniNode.PoolRefs).To(gomega.HaveLen(3))
+	g.Expect(sniNode.PoolGroupRefs).To(gomega.HaveLen(3))
+	for _, hppmap := range getMatchRules() {
+		g.Expect(hppmap.Path).To(gomega.Equal([]string{"/foo"}))
+		switch hppmap.Name {
+		case lib.GetHTTPRuleMatchName(pgName, 0):
+			g.Expect(hppmap.PoolGroup).To(gomega.Equal(lib.GetHTTPRuleMatchName(pgName, 0)))
+			g.Expect(hppmap.PathRule.HeaderMatches).To(gomega.HaveLen(1))
+			g.Expect(hppmap.PathRule.HeaderMatches[0].Name).To(gomega.Equal("x-canary"))
+			g.Expect(hppmap.PathRule.HeaderMatches[0].Values).To(gomega.Equal([]string{"true"}))
+			g.Expect(hppmap.PathRule.HeaderMatches[0].MatchCriteria).To(gomega.Equal("HDR_EQUALS"))
+			g.Expect(hppmap.PathRule.CookieMatch).To(gomega.BeNil())
+		case lib.GetHTTPRuleMatchName(pgName, 1):
+			g.Expect(hppmap.PathRule.HeaderMatches).To(gomega.BeEmpty())
+			g.Expect(hppmap.PathRule.CookieMatch.Name).To(gomega.Equal("canary"))
+			g.Expect(hppmap.PathRule.CookieMatch.MatchCriteria).To(gomega.Equal("HDR_EXISTS"))
+		default:
+			t.Fatalf("unexpected http policy rule %s", hppmap.Name)
+		}
+	}
+	for _, pool := range sniNode.PoolRefs {
+		if pool.Name == lib.GetHTTPRuleMatchName(pgName, 0) {
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+			g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.HavePrefix("1.2.1"))
+		}
+	}
+
+	// Removing a match from the HTTPRule removes its pool, poolgroup and http policy rule.
+	httprule = matchHTTPRule(rrname, httprule.Spec.Paths[0].Matches[:1])
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getMatchRules())
+	}, 30*time.Second).Should(gomega.Equal(1))
+	g.Expect(getSniNode().PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(getSniNode().PoolGroupRefs).To(gomega.HaveLen(2))
+
+	// Deleting the ingress removes the SNI child along with the objects of the matches.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-matches", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() bool {
+		return getSniNode() == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHTTPRuleMatchesInvalid(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	rrname := "samplerr-match-invalid"
+	httprule := matchHTTPRule(rrname, []v1alpha1.HTTPRuleMatch{{
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}})
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	httprule = matchHTTPRule(rrname, []v1alpha1.HTTPRuleMatch{{
+		Headers: []v1alpha1.HTTPRuleHeaderMatch{{Name: "x-canary", Value: "true", MatchType: lib.MatchTypeExists}},
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}})
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Error
+	}, 20*time.Second).Should(gomega.ContainSubstring("must not have a value"))
+
+	httprule = matchHTTPRule(rrname, []v1alpha1.HTTPRuleMatch{{
+		Headers: []v1alpha1.HTTPRuleHeaderMatch{{Name: "x-canary", MatchType: lib.MatchTypeExists}},
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}})
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func TestHTTPRuleHeadersAndRewriteForSNI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-rewrite"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.CreateSVC(t, "default", "avisvc-canary", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc-canary", false, false, "1.2.1")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:         "foo-with-rewrite",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := matchHTTPRule(rrname, []v1alpha1.HTTPRuleMatch{{
+		Headers: []v1alpha1.HTTPRuleHeaderMatch{{Name: "x-canary", Value: "true"}},
+		Backend: v1alpha1.HTTPRuleBackend{Service: "avisvc-canary", Port: 8080},
+	}})
+	httprule.Spec.Paths[0].RequestHeaders = &v1alpha1.HTTPRuleHeaders{
+		Add:     []v1alpha1.HTTPRuleHeader{{Name: "x-added", Value: "ako"}},
+		Replace: []v1alpha1.HTTPRuleHeader{{Name: "x-replaced", Value: "ako"}},
+		Remove:  []string{"x-removed"},
+	}
+	httprule.Spec.Paths[0].ResponseHeaders = &v1alpha1.HTTPRuleHeaders{
+		Remove: []string{"server"},
+	}
+	httprule.Spec.Paths[0].Rewrite = &v1alpha1.HTTPRuleRewrite{
+		PathPrefix: "/",
+		Host:       "foo.internal",
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	pgName := lib.GetSniPGName("foo-with-rewrite", "default", "foo.com", "/foo", "", false)
+	getPathRules := func() map[string]*avinodes.AviHTTPPathRule {
+		pathRules := make(map[string]*avinodes.AviHTTPPathRule)
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return pathRules
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+			return pathRules
+		}
+		for _, policy := range nodes[0].SniNodes[0].HttpPolicyRefs {
+			for _, hppmap := range policy.HppMap {
+				pathRules[hppmap.Name] = hppmap.PathRule
+			}
+		}
+		return pathRules
+	}
+	g.Eventually(func() bool {
+		pathRule, ok := getPathRules()[pgName]
+		return ok && pathRule != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	pathRules := getPathRules()
+	g.Expect(pathRules).To(gomega.HaveLen(2))
+	pathRule := pathRules[pgName]
+	g.Expect(pathRule.HeaderMatches).To(gomega.BeEmpty())
+	g.Expect(pathRule.RequestHeaders).To(gomega.Equal([]avinodes.AviHTTPHeaderAction{
+		{Action: "HTTP_REPLACE_HDR", Name: "x-replaced", Value: "ako"},
+		{Action: "HTTP_ADD_HDR", Name: "x-added", Value: "ako"},
+		{Action: "HTTP_REMOVE_HDR", Name: "x-removed"},
+	}))
+	g.Expect(pathRule.ResponseHeaders).To(gomega.Equal([]avinodes.AviHTTPHeaderAction{
+		{Action: "HTTP_REMOVE_HDR", Name: "server"},
+	}))
+	g.Expect(*pathRule.RewriteURL).To(gomega.Equal(avinodes.AviHTTPRewriteURL{
+		Host:        "foo.internal",
+		PathPrefix:  "/",
+		MatchPrefix: "/foo",
+	}))
+	// The requests switched by the matches of the path are modified as well.
+	matchPathRule := pathRules[lib.GetHTTPRuleMatchName(pgName, 0)]
+	g.Expect(matchPathRule.HeaderMatches).To(gomega.HaveLen(1))
+	g.Expect(matchPathRule.RequestHeaders).To(gomega.Equal(pathRule.RequestHeaders))
+	g.Expect(matchPathRule.ResponseHeaders).To(gomega.Equal(pathRule.ResponseHeaders))
+	g.Expect(matchPathRule.RewriteURL).To(gomega.Equal(pathRule.RewriteURL))
+
+	// A path prefix that is not an absolute path rejects the HTTPRule.
+	httprule.Spec.Paths[0].Rewrite.PathPrefix = "bar"
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	// Removing the header modifiers and the rewrite from the HTTPRule removes them from the http policy rule.
+	httprule = matchHTTPRule(rrname, nil)
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	g.Eventually(func() int {
+		return len(getPathRules())
+	}, 30*time.Second).Should(gomega.Equal(1))
+	g.Expect(getPathRules()[pgName]).To(gomega.BeNil())
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-rewrite", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.TeardownHTTPRule(t, rrname)
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	integrationtest.DelSVC(t, "default", "avisvc-canary")
+	integrationtest.DelEP(t, "default", "avisvc-canary")
+	TearDownTestForIngress(t, modelName)
+}
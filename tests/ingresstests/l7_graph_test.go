@@ -198,6 +198,73 @@ func TestL7Model(t *testing.T) {
 	TearDownTestForIngress(t, modelName)
 }
 
+func TestL7ModelExternalNameService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcExample := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "avisvc-external"},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: "backend.example.com.",
+			Ports:        []corev1.ServicePort{{Name: "foo0", Port: 8080, Protocol: "TCP"}},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Services("default").Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-external-name",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		ServiceName: "avisvc-external",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getServers := func() []avinodes.AviPoolMetaServer {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		g.Expect(nodes[0].PoolRefs[0].Port).To(gomega.Equal(int32(8080)))
+		return nodes[0].PoolRefs[0].Servers
+	}
+	// The pool server is the external name, which is resolved by the Service Engines.
+	g.Eventually(func() string {
+		if servers := getServers(); len(servers) == 1 && *servers[0].Ip.Type == "DNS" {
+			return servers[0].Hostname
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal("backend.example.com"))
+
+	// Changing the external name updates the pool server.
+	svcExample.Spec.ExternalName = "backend2.example.com"
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services("default").Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		if servers := getServers(); len(servers) == 1 {
+			return *servers[0].Ip.Addr
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal("backend2.example.com"))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), "foo-with-external-name", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, "default", "avisvc-external")
+	objects.SharedAviGraphLister().Delete(modelName)
+}
+
 func TestShardNamingConvention(t *testing.T) {
 	// checks naming convention of all generated nodes
 	g := gomega.NewGomegaWithT(t)
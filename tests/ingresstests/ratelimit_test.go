@@ -0,0 +1,229 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func getHostRuleStatus(hrname string) string {
+	hostrule, err := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	return hostrule.Status.Status
+}
+
+func getRateLimitSniNode(modelName string) *avinodes.AviVsNode {
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) != 1 || len(nodes[0].SniNodes) != 1 {
+		return nil
+	}
+	return nodes[0].SniNodes[0]
+}
+
+func TestHostRuleRateLimit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-ratelimit"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.RateLimit = &v1alpha1.RateLimit{
+		Requests: 100,
+		Period:   10,
+		Burst:    20,
+	}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	policyName := lib.GetRateLimitPolicy("cluster--foo.com")
+	getRateLimitPolicy := func() *avinodes.AviHttpPolicySetNode {
+		if sniNode := getRateLimitSniNode(modelName); sniNode != nil {
+			for _, policy := range sniNode.HttpPolicyRefs {
+				if policy.Name == policyName {
+					return policy
+				}
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getRateLimitPolicy() != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	policy := getRateLimitPolicy()
+	g.Expect(policy.SecurityRules).To(gomega.HaveLen(1))
+	g.Expect(policy.SecurityRules[0].Action).To(gomega.Equal(lib.RATE_LIMIT))
+	g.Expect(*policy.SecurityRules[0].RateLimit).To(gomega.Equal(avinodes.AviRateLimit{
+		Count:       100,
+		Period:      10,
+		Burst:       20,
+		PerClientIP: true,
+		Action:      "RL_ACTION_DROP_CONN",
+	}))
+	g.Expect(getRateLimitSniNode(modelName).RequestsRateLimit).To(gomega.BeNil())
+
+	// A rate limit per header is applied on the virtual service, and the rate limit policy is removed.
+	hostrule.Spec.VirtualHost.RateLimit = &v1alpha1.RateLimit{
+		Requests: 100,
+		Period:   10,
+		Key:      lib.RateLimitKeyHeader,
+		Header:   "x-api-key",
+		Action: v1alpha1.RateLimitAction{
+			Type:     lib.RateLimitActionRedirect,
+			Redirect: &v1alpha1.RateLimitRedirect{Path: "/busy"},
+		},
+	}
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.RequestsRateLimit != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Expect(getRateLimitPolicy()).To(gomega.BeNil())
+	requestsRateLimit := getRateLimitSniNode(modelName).RequestsRateLimit
+	g.Expect(requestsRateLimit.Header).To(gomega.Equal("x-api-key"))
+	g.Expect(requestsRateLimit.PerClientIP).To(gomega.BeFalse())
+	g.Expect(requestsRateLimit.Action).To(gomega.Equal("RL_ACTION_REDIRECT"))
+	g.Expect(*requestsRateLimit.Redirect).To(gomega.Equal(avinodes.AviHTTPRedirect{
+		Protocol:   "HTTPS",
+		Path:       "/busy",
+		StatusCode: lib.STATUS_REDIRECT,
+	}))
+
+	// A rate limit per header without the header rejects the HostRule.
+	hostrule.Spec.VirtualHost.RateLimit.Header = ""
+	hostrule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHostRuleStatus(hrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
+		sniNode := getRateLimitSniNode(modelName)
+		return sniNode != nil && sniNode.RequestsRateLimit == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Expect(getRateLimitPolicy()).To(gomega.BeNil())
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHTTPRuleRateLimitForSNI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-ratelimit"
+	SetUpIngressForCacheSyncCheck(t, true, true, modelName)
+
+	httprule := &v1alpha1.HTTPRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      rrname,
+		},
+		Spec: v1alpha1.HTTPRuleSpec{
+			Fqdn: "foo.com",
+			Paths: []v1alpha1.HTTPRulePaths{{
+				Target: "/foo",
+				RateLimit: &v1alpha1.RateLimit{
+					Requests: 50,
+					Period:   1,
+					Key:      lib.RateLimitKeyHeader,
+					Header:   "x-api-key",
+				},
+			}},
+		},
+	}
+	// The requests of a path can only be rate limited per client IP.
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Rejected"))
+
+	httprule.Spec.Paths[0].RateLimit = &v1alpha1.RateLimit{
+		Requests: 50,
+		Period:   1,
+		Action:   v1alpha1.RateLimitAction{Type: lib.RateLimitActionReport},
+	}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		return getHTTPRuleStatus(rrname)
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	pgName := lib.GetSniPGName("foo-with-targets", "default", "foo.com", "/foo", "", false)
+	getPathRule := func() *avinodes.AviHTTPPathRule {
+		if sniNode := getRateLimitSniNode(modelName); sniNode != nil {
+			for _, policy := range sniNode.HttpPolicyRefs {
+				for _, hppmap := range policy.HppMap {
+					if hppmap.Name == pgName {
+						return hppmap.PathRule
+					}
+				}
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getPathRule() != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+	g.Expect(*getPathRule().RateLimit).To(gomega.Equal(avinodes.AviRateLimit{
+		Count:       50,
+		Period:      1,
+		PerClientIP: true,
+		Action:      "RL_ACTION_NONE",
+	}))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		return getPathRule() == nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
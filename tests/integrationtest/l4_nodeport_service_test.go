@@ -272,3 +272,73 @@ func TestMultiPortL4SvcNodePort(t *testing.T) {
 
 	TearDownTestForSvcLBMultiport(t, g)
 }
+
+// TestSinglePortL4SvcNodePortExternalTrafficPolicyLocal tests that only the nodes which host the ready endpoints
+// of the Service are added to the pool, for the Local external traffic policy.
+func TestSinglePortL4SvcNodePortExternalTrafficPolicyLocal(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	SetNodePortMode()
+	defer SetClusterIPMode()
+	nodeIP1, nodeIP2 := "10.1.1.2", "10.1.1.3"
+	CreateNode(t, "testNode1", nodeIP1)
+	defer DeleteNode(t, "testNode1")
+	CreateNode(t, "testNode2", nodeIP2)
+	defer DeleteNode(t, "testNode2")
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	nodeName := "testNode1"
+	epExample := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: NAMESPACE, Name: SINGLEPORTSVC},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "1.1.1.1", NodeName: &nodeName}},
+			Ports:     []corev1.EndpointPort{{Name: "foo0", Port: 8080, Protocol: "TCP"}},
+		}},
+	}
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Create(context.TODO(), epExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Endpoint: %v", err)
+	}
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	getServerIPs := func() []string {
+		var serverIPs []string
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return serverIPs
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return serverIPs
+		}
+		for _, server := range nodes[0].PoolRefs[0].Servers {
+			serverIPs = append(serverIPs, *server.Ip.Addr)
+		}
+		return serverIPs
+	}
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.Equal([]string{nodeIP1}))
+
+	// The endpoint moves to the other node.
+	nodeName = "testNode2"
+	epExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.Equal([]string{nodeIP2}))
+
+	// All the nodes are added for the Cluster external traffic policy.
+	svcExample.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getServerIPs())
+	}, 10*time.Second).Should(gomega.Equal(2))
+
+	TearDownTestForSvcLB(t, g)
+}
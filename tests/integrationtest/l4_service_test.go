@@ -31,6 +31,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
 	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
@@ -697,6 +698,331 @@ func TestAviSvcCreationWithStaticIP(t *testing.T) {
 
 // Infra CRD tests via service annotation
 
+func TestAviSvcSessionAffinityClientIP(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	timeoutSeconds := int32(600)
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	svcExample.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeoutSeconds},
+	}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	persistenceProfileName := lib.GetPersistenceProfileName(vsName)
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) == 1 && nodes[0].PersistenceProfile != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PersistenceProfile.Name).To(gomega.Equal(persistenceProfileName))
+	g.Expect(nodes[0].PersistenceProfile.Timeout).To(gomega.Equal(int32(10)))
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].ApplicationPersistence).To(gomega.Equal("/api/applicationpersistenceprofile?name=" + persistenceProfileName))
+
+	mcache := cache.SharedAviObjCache()
+	persistenceProfileKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: persistenceProfileName}
+	g.Eventually(func() bool {
+		_, found := mcache.PersistenceProfileCache.AviCacheGet(persistenceProfileKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+
+	// The persistence profile is updated when the timeout changes.
+	timeoutSeconds = 3000
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() int32 {
+		_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PersistenceProfile.Timeout
+	}, 10*time.Second).Should(gomega.Equal(int32(50)))
+
+	// Removing the session affinity removes the persistence profile.
+	svcExample.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	svcExample.Spec.SessionAffinityConfig = nil
+	svcExample.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return nodes[0].PersistenceProfile == nil && nodes[0].PoolRefs[0].ApplicationPersistence == ""
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.PersistenceProfileCache.AviCacheGet(persistenceProfileKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcLoadBalancerSourceRanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.LoadBalancerSourceRanges = []string{"10.10.0.0/16", "10.20.30.0/33"}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	vsName := fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)
+	networkSecurityPolicyName := lib.GetNetworkSecurityPolicyName(vsName)
+	getSourceRanges := func() []string {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || nodes[0].NetworkSecurityPolicy == nil {
+			return nil
+		}
+		g.Expect(nodes[0].NetworkSecurityPolicy.Name).To(gomega.Equal(networkSecurityPolicyName))
+		return nodes[0].NetworkSecurityPolicy.SourceRanges
+	}
+	// The invalid source range is skipped.
+	g.Eventually(getSourceRanges, 10*time.Second).Should(gomega.Equal([]string{"10.10.0.0/16"}))
+
+	mcache := cache.SharedAviObjCache()
+	networkSecurityPolicyKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: networkSecurityPolicyName}
+	g.Eventually(func() bool {
+		_, found := mcache.NetworkSecurityPolicyCache.AviCacheGet(networkSecurityPolicyKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+
+	// The policy is updated when the source ranges change.
+	svcExample.Spec.LoadBalancerSourceRanges = []string{"10.10.0.0/16", "192.168.1.0/24"}
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(getSourceRanges, 10*time.Second).Should(gomega.Equal([]string{"10.10.0.0/16", "192.168.1.0/24"}))
+
+	// Removing the source ranges removes the policy.
+	svcExample.Spec.LoadBalancerSourceRanges = nil
+	svcExample.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].NetworkSecurityPolicy == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.NetworkSecurityPolicyCache.AviCacheGet(networkSecurityPolicyKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcLoadBalancerClass(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("LOAD_BALANCER_CLASS", "ako.vmware.com/avi-lb")
+	os.Setenv("ALLOW_NO_LOAD_BALANCER_CLASS", "false")
+	defer os.Unsetenv("LOAD_BALANCER_CLASS")
+	defer os.Unsetenv("ALLOW_NO_LOAD_BALANCER_CLASS")
+
+	hasVS := func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return false
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()) == 1
+	}
+
+	// The Services of other loadBalancerClasses, and without a loadBalancerClass, are not handled.
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	otherClass := "metallb.io/metallb"
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.LoadBalancerClass = &otherClass
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	g.Consistently(hasVS, 3*time.Second).Should(gomega.Equal(false))
+
+	svcExample.Spec.LoadBalancerClass = nil
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Consistently(hasVS, 3*time.Second).Should(gomega.Equal(false))
+
+	// The Service of the configured loadBalancerClass is handled.
+	aviClass := "ako.vmware.com/avi-lb"
+	svcExample.Spec.LoadBalancerClass = &aviClass
+	svcExample.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(hasVS, 10*time.Second).Should(gomega.Equal(true))
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(true))
+
+	// The virtualservice is deleted when the loadBalancerClass changes to one not handled by AKO.
+	svcExample.Spec.LoadBalancerClass = &otherClass
+	svcExample.ResourceVersion = "4"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(hasVS, 10*time.Second).Should(gomega.Equal(false))
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 15*time.Second).Should(gomega.Equal(false))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcForeignLoadBalancerClass(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hasVS := func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return false
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()) == 1
+	}
+
+	// AKO is not configured with a loadBalancerClass, the Service of another loadBalancerClass is not handled.
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	otherClass := "metallb.io/metallb"
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.LoadBalancerClass = &otherClass
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	g.Consistently(hasVS, 3*time.Second).Should(gomega.Equal(false))
+
+	// The Service is handled once it has no loadBalancerClass.
+	svcExample.Spec.LoadBalancerClass = nil
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(hasVS, 10*time.Second).Should(gomega.Equal(true))
+
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcDualStack(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The VIP network allocates both the IPv4 and the IPv6 VIPs.
+	vipNetworks := lib.GetVipNetworkList()
+	lib.SetVipNetworkList([]akov1alpha1.AviInfraSettingVipNetwork{{NetworkName: "net123", Cidr: "10.10.10.0/24", V6Cidr: "2002::/64"}})
+	defer lib.SetVipNetworkList(vipNetworks)
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	dualStack := corev1.IPFamilyPolicyPreferDualStack
+	svcExample := ConstructService(NAMESPACE, SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, make(map[string]string))
+	svcExample.Spec.IPFamilyPolicy = &dualStack
+	svcExample.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	epExample := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: NAMESPACE, Name: SINGLEPORTSVC},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "1.1.1.1"}, {IP: "2001::1"}},
+			Ports:     []corev1.EndpointPort{{Name: "foo0", Port: 8080, Protocol: "TCP"}},
+		}},
+	}
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Create(context.TODO(), epExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Endpoint: %v", err)
+	}
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	getVS := func() *avinodes.AviVsNode {
+		found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 {
+			return nil
+		}
+		return nodes[0]
+	}
+	getServerIPs := func() []string {
+		var serverIPs []string
+		if vs := getVS(); vs != nil && len(vs.PoolRefs) == 1 {
+			for _, server := range vs.PoolRefs[0].Servers {
+				serverIPs = append(serverIPs, *server.Ip.Type+"/"+*server.Ip.Addr)
+			}
+		}
+		return serverIPs
+	}
+	// Both the IP families are used for the VIP and the servers.
+	g.Eventually(func() string {
+		if vs := getVS(); vs != nil && len(vs.VSVIPRefs) == 1 {
+			return vs.VSVIPRefs[0].IPFamily
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal("V4_V6"))
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.ConsistOf("V4/1.1.1.1", "V6/2001::1"))
+
+	// A single stack IPv6 Service uses only the IPv6 servers.
+	singleStack := corev1.IPFamilyPolicySingleStack
+	svcExample.Spec.IPFamilyPolicy = &singleStack
+	svcExample.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		if vs := getVS(); vs != nil && len(vs.VSVIPRefs) == 1 {
+			return vs.VSVIPRefs[0].IPFamily
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal("V6"))
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.ConsistOf("V6/2001::1"))
+
+	// A single stack IPv4 Service uses the ipFamily that AKO is configured with.
+	svcExample.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+	svcExample.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.ConsistOf("V4/1.1.1.1"))
+	g.Expect(getVS().VSVIPRefs[0].IPFamily).To(gomega.Equal(""))
+
+	// Without an IPv6 VIP network, a dual-stack Service gets only the IPv4 VIP.
+	lib.SetVipNetworkList([]akov1alpha1.AviInfraSettingVipNetwork{{NetworkName: "net123"}})
+	svcExample.Spec.IPFamilyPolicy = &dualStack
+	svcExample.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	svcExample.ResourceVersion = "4"
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(getServerIPs, 10*time.Second).Should(gomega.ConsistOf("V4/1.1.1.1", "V6/2001::1"))
+	g.Expect(getVS().VSVIPRefs[0].IPFamily).To(gomega.Equal(""))
+
+	TearDownTestForSvcLB(t, g)
+}
+
 func TestWithInfraSettingStatusUpdates(t *testing.T) {
 	// create infraSetting, svcLB with bad seGroup/networkName
 	// check for Rejected status, check layer 2 for defaults
@@ -965,3 +1291,162 @@ func TestInfraSettingChangeMapping(t *testing.T) {
 	TeardownAviInfraSetting(t, settingName2)
 	TearDownTestForSvcLB(t, g)
 }
+
+func TestInfraSettingZoneAwareness(t *testing.T) {
+	// create nodes in two zones, svcLB with endpoints on both nodes
+	// enable zone awareness in the infraSetting, the servers are split into the pools per zone of a pool group
+	// move the node to a zone which is not listed and back, remove an endpoint and add it back
+	// disable zone awareness, the servers are in a single pool
+
+	g := gomega.NewGomegaWithT(t)
+	settingName := "infra-setting-zone"
+
+	for nodeName, zone := range map[string]string{"zoneNode1": "zone-a", "zoneNode2": "zone-b"} {
+		nodeExample := (FakeNode{
+			Name:     nodeName,
+			PodCIDR:  "10.244.0.0/24",
+			PodCIDRs: []string{"10.244.0.0/24"},
+			Version:  "1",
+		}).Node()
+		nodeExample.Labels = map[string]string{corev1.LabelTopologyZone: zone}
+		if _, err := KubeClient.CoreV1().Nodes().Create(context.TODO(), nodeExample, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in adding Node: %v", err)
+		}
+	}
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	svcExample.Annotations = map[string]string{lib.InfraSettingNameAnnotation: settingName}
+	if _, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	node1, node2 := "zoneNode1", "zoneNode2"
+	epExample := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: NAMESPACE, Name: SINGLEPORTSVC},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "1.1.1.1", NodeName: &node1}, {IP: "1.1.1.2", NodeName: &node2}},
+			Ports:     []corev1.EndpointPort{{Name: "foo1", Port: 8080, Protocol: "TCP"}},
+		}},
+	}
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Create(context.TODO(), epExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Endpoint: %v", err)
+	}
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	setting := FakeAviInfraSetting{
+		Name:        settingName,
+		SeGroupName: "thisisaviref-" + settingName + "-seGroup",
+		Networks:    []string{"thisisaviref-" + settingName + "-networkName"},
+	}
+	settingCreate := setting.AviInfraSetting()
+	settingCreate.Spec.ZoneAwareness.Zones = []string{"zone-a", "zone-b"}
+	if _, err := lib.AKOControlConfig().CRDClientset().AkoV1alpha1().AviInfraSettings().Create(context.TODO(), settingCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding AviInfraSetting: %v", err)
+	}
+
+	poolName := lib.GetL4PoolName(SINGLEPORTSVC, NAMESPACE, "TCP", 8080)
+	// zoneServers returns the servers of the pools, keyed by the zone of the pool, or by the pool name if the
+	// servers are not split by zone.
+	zoneServers := func() map[string][]string {
+		servers := make(map[string][]string)
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				for _, pool := range nodes[0].PoolRefs {
+					zone := strings.TrimPrefix(pool.Name, poolName+"--")
+					servers[zone] = []string{}
+					for _, server := range pool.Servers {
+						servers[zone] = append(servers[zone], *server.Ip.Addr)
+					}
+				}
+			}
+		}
+		return servers
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		"zone-a": {"1.1.1.1"}, "zone-b": {"1.1.1.2"}, "other-zones": {},
+	}))
+
+	// the port selects the pool group, whose pools are ranked by the order of the zones
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	vsNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0]
+	g.Expect(vsNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(vsNode.PoolGroupRefs[0].Name).To(gomega.Equal(poolName))
+	priorityLabels := make(map[string]string)
+	for _, member := range vsNode.PoolGroupRefs[0].Members {
+		priorityLabels[*member.PoolRef] = *member.PriorityLabel
+	}
+	g.Expect(priorityLabels).To(gomega.Equal(map[string]string{
+		"/api/pool?name=" + poolName + "--zone-a":      "3",
+		"/api/pool?name=" + poolName + "--zone-b":      "2",
+		"/api/pool?name=" + poolName + "--other-zones": "1",
+	}))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(1))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool[0].PoolGroup).To(gomega.Equal("/api/poolgroup?name=" + poolName))
+	g.Expect(vsNode.L4PolicyRefs[0].PortPool[0].Pool).To(gomega.BeEmpty())
+
+	// the node moves to a zone which is not listed
+	node, err := KubeClient.CoreV1().Nodes().Get(context.TODO(), node1, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting Node: %v", err)
+	}
+	node.Labels[corev1.LabelTopologyZone] = "zone-c"
+	node.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Node: %v", err)
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		"zone-a": {}, "zone-b": {"1.1.1.2"}, "other-zones": {"1.1.1.1"},
+	}))
+
+	node.Labels[corev1.LabelTopologyZone] = "zone-a"
+	node.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Node: %v", err)
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		"zone-a": {"1.1.1.1"}, "zone-b": {"1.1.1.2"}, "other-zones": {},
+	}))
+
+	// the endpoint in the preferred zone is removed, its pool is kept without servers
+	epExample.Subsets[0].Addresses = []corev1.EndpointAddress{{IP: "1.1.1.2", NodeName: &node2}}
+	epExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		"zone-a": {}, "zone-b": {"1.1.1.2"}, "other-zones": {},
+	}))
+
+	epExample.Subsets[0].Addresses = []corev1.EndpointAddress{{IP: "1.1.1.1", NodeName: &node1}, {IP: "1.1.1.2", NodeName: &node2}}
+	epExample.ResourceVersion = "3"
+	if _, err := KubeClient.CoreV1().Endpoints(NAMESPACE).Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		"zone-a": {"1.1.1.1"}, "zone-b": {"1.1.1.2"}, "other-zones": {},
+	}))
+
+	settingUpdate := setting.AviInfraSetting()
+	settingUpdate.ResourceVersion = "2"
+	if _, err := lib.AKOControlConfig().CRDClientset().AkoV1alpha1().AviInfraSettings().Update(context.TODO(), settingUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating AviInfraSetting: %v", err)
+	}
+	g.Eventually(zoneServers, 35*time.Second).Should(gomega.Equal(map[string][]string{
+		poolName: {"1.1.1.1", "1.1.1.2"},
+	}))
+	_, aviModel = objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolGroupRefs).To(gomega.BeEmpty())
+
+	TeardownAviInfraSetting(t, settingName)
+	TearDownTestForSvcLB(t, g)
+	for _, nodeName := range []string{node1, node2} {
+		if err := KubeClient.CoreV1().Nodes().Delete(context.TODO(), nodeName, metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("error in deleting Node: %v", err)
+		}
+	}
+}
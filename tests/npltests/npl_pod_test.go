@@ -719,6 +719,53 @@ func TestNPLLBSvcDelPod(t *testing.T) {
 	tearDownTestForSvcLB(t, g)
 }
 
+// TestNPLLBSvcDrainTerminatingPod creates a Service type LB and a Pod with matching label, with a drain period.
+// Then the Pod is marked for deletion, and it is verified that the Server is drained before it is deleted from model
+func TestNPLLBSvcDrainTerminatingPod(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("SERVER_DRAIN_PERIOD", "5")
+	defer os.Unsetenv("SERVER_DRAIN_PERIOD")
+	selectors := make(map[string]string)
+	selectors["app"] = "npl"
+	objects.SharedAviGraphLister().Delete(defaultLBModel)
+	createPodWithNPLAnnotation(selectors)
+	setUpTestForSvcLB(t)
+
+	getServers := func() []avinodes.AviPoolMetaServer {
+		_, aviModel := objects.SharedAviGraphLister().Get(defaultLBModel)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) != 1 || len(nodes[0].PoolRefs) != 1 {
+			return nil
+		}
+		return nodes[0].PoolRefs[0].Servers
+	}
+	g.Eventually(func() int {
+		return len(getServers())
+	}, 40*time.Second).Should(gomega.Equal(1))
+	g.Expect(getServers()[0].Draining).To(gomega.BeFalse())
+
+	// If the Pod is being deleted, the server should be drained, and then deleted from model
+	testPod := getTestPod(selectors)
+	testPod.Annotations = map[string]string{lib.NPLPodAnnotation: "[{\"podPort\":8080,\"nodeIP\":\"10.10.10.10\",\"nodePort\":40001}]"}
+	deletionTimestamp := metav1.Now()
+	testPod.DeletionTimestamp = &deletionTimestamp
+	testPod.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Pods(defaultNS).Update(context.TODO(), &testPod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Pod: %v", err)
+	}
+	g.Eventually(func() bool {
+		servers := getServers()
+		return len(servers) == 1 && servers[0].Draining
+	}, 4*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() int {
+		return len(getServers())
+	}, 40*time.Second).Should(gomega.Equal(0))
+	tearDownTestForSvcLB(t, g)
+}
+
 // TestNPLLBSvcNoLabel creates a Service of type LB with no Label and a Pod with NPL annotation.
 // Then it is verified that no server is getting added in the model.
 func TestNPLLBSvcNoLabel(t *testing.T) {
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rendertests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/render"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const manifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: avisvc
+  namespace: red
+spec:
+  ports:
+  - name: http
+    port: 8080
+    targetPort: 80
+---
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: avisvc
+  namespace: red
+subsets:
+- addresses:
+  - ip: 10.1.1.1
+  - ip: 10.1.1.2
+  ports:
+  - name: http
+    port: 80
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: foo-tls
+  namespace: red
+type: kubernetes.io/tls
+data:
+  tls.crt: dGxzQ2VydA==
+  tls.key: dGxzS2V5
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: foo
+  namespace: red
+spec:
+  tls:
+  - hosts: [foo.avi.internal]
+    secretName: foo-tls
+  rules:
+  - host: foo.avi.internal
+    http:
+      paths:
+      - path: /foo
+        pathType: Prefix
+        backend:
+          service:
+            name: avisvc
+            port:
+              number: 8080
+---
+apiVersion: ako.vmware.com/v1alpha1
+kind: HTTPRule
+metadata:
+  name: foo-rule
+  namespace: red
+spec:
+  fqdn: foo.avi.internal
+  paths:
+  - target: /foo
+    loadBalancerPolicy:
+      algorithm: LB_ALGORITHM_CONSISTENT_HASH
+      hash: LB_ALGORITHM_CONSISTENT_HASH_SOURCE_IP_ADDRESS
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: lbsvc
+spec:
+  type: LoadBalancer
+  ports:
+  - name: tcp
+    port: 8080
+    targetPort: 80
+---
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: lbsvc
+subsets:
+- addresses:
+  - ip: 10.1.2.1
+  ports:
+  - name: tcp
+    port: 80
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: skipped
+`
+
+var output *render.Output
+
+func TestMain(m *testing.M) {
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+	os.Setenv("AUTO_L4_FQDN", "default")
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("DISABLE_STATIC_ROUTE_SYNC", "true")
+
+	objs, err := render.DecodeManifests(strings.NewReader(manifests))
+	if err != nil {
+		utils.AviLog.Fatalf("Failed to decode the manifests: %v", err)
+	}
+	// Render sets up the shared informers and caches, hence the manifests of all the tests are rendered once.
+	output, err = render.Render(objs, render.Options{DNSSubDomains: []string{"avi.internal"}})
+	if err != nil {
+		utils.AviLog.Fatalf("Failed to render the manifests: %v", err)
+	}
+	os.Exit(m.Run())
+}
+
+func findObject(modelName, objType, objName string) map[string]interface{} {
+	for _, model := range output.Models {
+		if model.Name != modelName {
+			continue
+		}
+		for _, obj := range model.Objects {
+			if obj.Type == objType && obj.Name == objName {
+				return obj.Object.(map[string]interface{})
+			}
+		}
+	}
+	return nil
+}
+
+func TestDecodeManifests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objs, err := render.DecodeManifests(strings.NewReader(manifests))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	// The object of the unknown kind is skipped.
+	g.Expect(objs).To(gomega.HaveLen(7))
+
+	_, err = render.DecodeManifests(strings.NewReader("kind: Service\nmetadata: [\n"))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestRenderIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-6"
+	g.Expect(findObject(modelName, "VirtualService", "cluster--Shared-L7-6")).NotTo(gomega.BeNil())
+	g.Expect(findObject(modelName, "VsVip", "cluster--Shared-L7-6")).NotTo(gomega.BeNil())
+	sniVS := findObject(modelName, "VirtualService", "cluster--foo.avi.internal")
+	g.Expect(sniVS).NotTo(gomega.BeNil())
+	g.Expect(sniVS["type"]).To(gomega.Equal("VS_TYPE_VH_CHILD"))
+
+	pool := findObject(modelName, "Pool", "cluster--red-foo.avi.internal_foo-foo")
+	g.Expect(pool).NotTo(gomega.BeNil())
+	g.Expect(pool["servers"]).To(gomega.HaveLen(2))
+	g.Expect(pool["lb_algorithm"]).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH"))
+
+	cert := findObject(modelName, "SSLKeyAndCertificate", "cluster--foo.avi.internal")
+	g.Expect(cert).NotTo(gomega.BeNil())
+	g.Expect(cert["key"]).To(gomega.Equal("REDACTED"))
+}
+
+func TestRenderL4Service(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--default-lbsvc"
+	g.Expect(findObject(modelName, "VirtualService", "cluster--default-lbsvc")).NotTo(gomega.BeNil())
+	g.Expect(findObject(modelName, "L4PolicySet", "cluster--default-lbsvc")).NotTo(gomega.BeNil())
+
+	vsVip := findObject(modelName, "VsVip", "cluster--default-lbsvc")
+	g.Expect(vsVip).NotTo(gomega.BeNil())
+	g.Expect(vsVip["dns_info"]).To(gomega.HaveLen(1))
+	g.Expect(vsVip["dns_info"].([]interface{})[0]).To(gomega.HaveKeyWithValue("fqdn", "lbsvc.default.avi.internal"))
+
+	pool := findObject(modelName, "Pool", "cluster--default-lbsvc--8080")
+	g.Expect(pool).NotTo(gomega.BeNil())
+	g.Expect(pool["servers"]).To(gomega.HaveLen(1))
+	server := pool["servers"].([]interface{})[0].(map[string]interface{})
+	g.Expect(server["ip"]).To(gomega.HaveKeyWithValue("addr", "10.1.2.1"))
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright 2023 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package tracingtests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+var KubeClient *k8sfake.Clientset
+var CRDClient *crdfake.Clientset
+var ctrl *k8s.AviController
+var collector *spanCollector
+
+// span holds the fields of the exported spans which are checked by the tests.
+type span struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+}
+
+func (s span) attribute(key string) string {
+	for _, attr := range s.Attributes {
+		if attr.Key == key {
+			return attr.Value.StringValue
+		}
+	}
+	return ""
+}
+
+// spanCollector serves the OTLP/HTTP traces endpoint, and collects the exported spans.
+type spanCollector struct {
+	lock  sync.Mutex
+	paths []string
+	spans map[string]span
+}
+
+func (c *spanCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []span `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.paths = append(c.paths, r.URL.Path)
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, s := range scopeSpans.Spans {
+				c.spans[s.SpanID] = s
+			}
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// find returns the spans with the name, and the value of the key attribute.
+func (c *spanCollector) find(name, key string) []span {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var spans []span
+	for _, s := range c.spans {
+		if s.Name == name && s.attribute(utils.TraceKeyAttribute) == key {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+func (c *spanCollector) parent(s span) (span, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	parent, ok := c.spans[s.ParentSpanID]
+	return parent, ok
+}
+
+func TestMain(m *testing.M) {
+	os.Setenv("VIP_NETWORK_LIST", `[{"networkName":"net123"}]`)
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv("SERVICE_TYPE", "ClusterIP")
+	os.Setenv("AUTO_L4_FQDN", "disable")
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("SHARD_VS_SIZE", "LARGE")
+
+	collector = &spanCollector{spans: make(map[string]span)}
+	otlpServer := httptest.NewServer(collector)
+	defer otlpServer.Close()
+	if err := utils.InitTracing(utils.TraceExporterOTLP, otlpServer.URL, map[string]string{"ako.cluster_name": "cluster"}); err != nil {
+		utils.AviLog.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	akoControlConfig := lib.AKOControlConfig()
+	KubeClient = k8sfake.NewSimpleClientset()
+	CRDClient = crdfake.NewSimpleClientset()
+	akoControlConfig.SetCRDClientset(CRDClient)
+	akoControlConfig.SetAKOInstanceFlag(true)
+	akoControlConfig.SetEventRecorder(lib.AKOEventComponent, KubeClient, true)
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient}
+	k8s.NewCRDInformers(CRDClient)
+
+	integrationtest.InitializeFakeAKOAPIServer()
+
+	integrationtest.NewAviFakeClientInstance(KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+	wgLeaderElection := &sync.WaitGroup{}
+	waitGroupMap["leaderElection"] = wgLeaderElection
+
+	integrationtest.AddConfigMap(KubeClient)
+	integrationtest.PollForSyncStart(ctrl, 10)
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddDefaultIngressClass()
+	ctrl.SetSEGroupCloudNameFromNSAnnotations()
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+func TestTraceOfL4Service(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := integrationtest.SINGLEPORTMODEL
+	svcKey := utils.L4LBService + "/" + integrationtest.NAMESPACE + "/" + integrationtest.SINGLEPORTSVC
+	integrationtest.CreateSVC(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false)
+	integrationtest.CreateEP(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC, false, false, "1.1.1")
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	vsKey := cache.NamespaceName{Namespace: integrationtest.AVINAMESPACE, Name: "cluster--" + integrationtest.NAMESPACE + "-" + integrationtest.SINGLEPORTSVC}
+	g.Eventually(func() bool {
+		_, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	// The spans are exported in batches, every few seconds.
+	g.Eventually(func() int {
+		return len(collector.find("status", modelName))
+	}, 30*time.Second).ShouldNot(gomega.BeZero())
+
+	// The key of the Service is ingested, and the graph of its model is built.
+	ingestionSpans := collector.find("ingestion", svcKey)
+	g.Expect(ingestionSpans).NotTo(gomega.BeEmpty())
+	g.Expect(ingestionSpans[0].ParentSpanID).To(gomega.BeEmpty())
+	graphSpans := collector.find("graph", svcKey)
+	g.Expect(graphSpans).NotTo(gomega.BeEmpty())
+	parent, ok := collector.parent(graphSpans[0])
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(parent.Name).To(gomega.Equal("ingestion"))
+
+	// The model is processed by the rest layer, in the trace of the key which published it.
+	var restSpan span
+	for _, s := range collector.find("rest", modelName) {
+		if parent, ok := collector.parent(s); ok && parent.Name == "graph" && parent.attribute(utils.TraceKeyAttribute) == svcKey {
+			restSpan = s
+			g.Expect(parent.TraceID).To(gomega.Equal(s.TraceID))
+		}
+	}
+	g.Expect(restSpan.SpanID).NotTo(gomega.BeEmpty())
+	g.Expect(restSpan.attribute("ako.queue")).To(gomega.Equal(utils.GraphLayer))
+
+	// The REST calls to the Avi Controller are children of the rest span.
+	var aviRestSpans []span
+	for _, s := range collector.find("avi.rest", modelName) {
+		if s.ParentSpanID == restSpan.SpanID {
+			aviRestSpans = append(aviRestSpans, s)
+		}
+	}
+	g.Expect(aviRestSpans).NotTo(gomega.BeEmpty())
+	var models []string
+	for _, s := range aviRestSpans {
+		g.Expect(s.Kind).To(gomega.Equal(3))
+		g.Expect(s.attribute("http.method")).NotTo(gomega.BeEmpty())
+		models = append(models, s.attribute("ako.avi.model"))
+	}
+	g.Expect(models).To(gomega.ContainElements("VsVip", "Pool", "VirtualService"))
+
+	// The status of the Service is updated in the same trace.
+	var statusSpans []span
+	for _, s := range collector.find("status", modelName) {
+		if s.ParentSpanID == restSpan.SpanID {
+			statusSpans = append(statusSpans, s)
+		}
+	}
+	g.Expect(statusSpans).NotTo(gomega.BeEmpty())
+	g.Expect(statusSpans[0].TraceID).To(gomega.Equal(restSpan.TraceID))
+
+	collector.lock.Lock()
+	g.Expect(collector.paths).To(gomega.ContainElement("/v1/traces"))
+	collector.lock.Unlock()
+
+	integrationtest.DelSVC(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC)
+	integrationtest.DelEP(t, integrationtest.NAMESPACE, integrationtest.SINGLEPORTSVC)
+	g.Eventually(func() bool {
+		_, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.BeFalse())
+}
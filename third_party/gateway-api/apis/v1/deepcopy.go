@@ -0,0 +1,81 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Only the status types are deep copied, as the objects are always converted
+// afresh from the unstructured objects cached by the dynamic informers.
+
+func deepCopyConditions(in []metav1.Condition) []metav1.Condition {
+	if in == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func (in *GatewayClassStatus) DeepCopy() *GatewayClassStatus {
+	if in == nil {
+		return nil
+	}
+	return &GatewayClassStatus{Conditions: deepCopyConditions(in.Conditions)}
+}
+
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := &GatewayStatus{Conditions: deepCopyConditions(in.Conditions)}
+	for _, address := range in.Addresses {
+		if address.Type != nil {
+			addressType := *address.Type
+			address.Type = &addressType
+		}
+		out.Addresses = append(out.Addresses, address)
+	}
+	for _, listener := range in.Listeners {
+		listener.SupportedKinds = append([]RouteGroupKind(nil), listener.SupportedKinds...)
+		listener.Conditions = deepCopyConditions(listener.Conditions)
+		out.Listeners = append(out.Listeners, listener)
+	}
+	return out
+}
+
+func (in *RouteStatus) DeepCopy() *RouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := &RouteStatus{}
+	for _, parent := range in.Parents {
+		parent.Conditions = deepCopyConditions(parent.Conditions)
+		out.Parents = append(out.Parents, parent)
+	}
+	return out
+}
+
+func (in *HTTPRouteStatus) DeepCopy() *HTTPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	return &HTTPRouteStatus{RouteStatus: *in.RouteStatus.DeepCopy()}
+}
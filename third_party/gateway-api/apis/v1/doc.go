@@ -0,0 +1,29 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1 contains the subset of the gateway.networking.k8s.io/v1 API types
+// consumed by AKO. The field names and json tags mirror sigs.k8s.io/gateway-api
+// so that objects read through the dynamic client can be converted with
+// runtime.DefaultUnstructuredConverter.
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const GroupName = "gateway.networking.k8s.io"
+
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
@@ -0,0 +1,354 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	ObjectName        string
+	SectionName       string
+	Hostname          string
+	PortNumber        int32
+	ProtocolType      string
+	Namespace         string
+	Group             string
+	Kind              string
+	GatewayController string
+)
+
+const (
+	HTTPProtocolType  ProtocolType = "HTTP"
+	HTTPSProtocolType ProtocolType = "HTTPS"
+	TLSProtocolType   ProtocolType = "TLS"
+	TCPProtocolType   ProtocolType = "TCP"
+	UDPProtocolType   ProtocolType = "UDP"
+)
+
+// GatewayClass
+
+type GatewayClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewayClassSpec   `json:"spec"`
+	Status GatewayClassStatus `json:"status,omitempty"`
+}
+
+type GatewayClassSpec struct {
+	ControllerName GatewayController    `json:"controllerName"`
+	ParametersRef  *ParametersReference `json:"parametersRef,omitempty"`
+	Description    *string              `json:"description,omitempty"`
+}
+
+type ParametersReference struct {
+	Group     Group      `json:"group"`
+	Kind      Kind       `json:"kind"`
+	Name      string     `json:"name"`
+	Namespace *Namespace `json:"namespace,omitempty"`
+}
+
+type GatewayClassStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Gateway
+
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewaySpec   `json:"spec"`
+	Status GatewayStatus `json:"status,omitempty"`
+}
+
+type GatewaySpec struct {
+	GatewayClassName ObjectName       `json:"gatewayClassName"`
+	Listeners        []Listener       `json:"listeners"`
+	Addresses        []GatewayAddress `json:"addresses,omitempty"`
+}
+
+type Listener struct {
+	Name          SectionName       `json:"name"`
+	Hostname      *Hostname         `json:"hostname,omitempty"`
+	Port          PortNumber        `json:"port"`
+	Protocol      ProtocolType      `json:"protocol"`
+	TLS           *GatewayTLSConfig `json:"tls,omitempty"`
+	AllowedRoutes *AllowedRoutes    `json:"allowedRoutes,omitempty"`
+}
+
+type TLSModeType string
+
+const (
+	TLSModeTerminate   TLSModeType = "Terminate"
+	TLSModePassthrough TLSModeType = "Passthrough"
+)
+
+type GatewayTLSConfig struct {
+	Mode            *TLSModeType            `json:"mode,omitempty"`
+	CertificateRefs []SecretObjectReference `json:"certificateRefs,omitempty"`
+}
+
+type SecretObjectReference struct {
+	Group     *Group     `json:"group,omitempty"`
+	Kind      *Kind      `json:"kind,omitempty"`
+	Name      ObjectName `json:"name"`
+	Namespace *Namespace `json:"namespace,omitempty"`
+}
+
+type FromNamespaces string
+
+const (
+	NamespacesFromAll      FromNamespaces = "All"
+	NamespacesFromSame     FromNamespaces = "Same"
+	NamespacesFromSelector FromNamespaces = "Selector"
+)
+
+type AllowedRoutes struct {
+	Namespaces *RouteNamespaces `json:"namespaces,omitempty"`
+	Kinds      []RouteGroupKind `json:"kinds,omitempty"`
+}
+
+type RouteNamespaces struct {
+	From     *FromNamespaces       `json:"from,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+type RouteGroupKind struct {
+	Group *Group `json:"group,omitempty"`
+	Kind  Kind   `json:"kind"`
+}
+
+type AddressType string
+
+const (
+	IPAddressType       AddressType = "IPAddress"
+	HostnameAddressType AddressType = "Hostname"
+)
+
+type GatewayAddress struct {
+	Type  *AddressType `json:"type,omitempty"`
+	Value string       `json:"value"`
+}
+
+type GatewayStatusAddress GatewayAddress
+
+type GatewayStatus struct {
+	Addresses  []GatewayStatusAddress `json:"addresses,omitempty"`
+	Conditions []metav1.Condition     `json:"conditions,omitempty"`
+	Listeners  []ListenerStatus       `json:"listeners,omitempty"`
+}
+
+type ListenerStatus struct {
+	Name           SectionName        `json:"name"`
+	SupportedKinds []RouteGroupKind   `json:"supportedKinds"`
+	AttachedRoutes int32              `json:"attachedRoutes"`
+	Conditions     []metav1.Condition `json:"conditions"`
+}
+
+// HTTPRoute
+
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPRouteSpec   `json:"spec"`
+	Status HTTPRouteStatus `json:"status,omitempty"`
+}
+
+type CommonRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+}
+
+type ParentReference struct {
+	Group       *Group       `json:"group,omitempty"`
+	Kind        *Kind        `json:"kind,omitempty"`
+	Namespace   *Namespace   `json:"namespace,omitempty"`
+	Name        ObjectName   `json:"name"`
+	SectionName *SectionName `json:"sectionName,omitempty"`
+	Port        *PortNumber  `json:"port,omitempty"`
+}
+
+type HTTPRouteSpec struct {
+	CommonRouteSpec `json:",inline"`
+	Hostnames       []Hostname      `json:"hostnames,omitempty"`
+	Rules           []HTTPRouteRule `json:"rules,omitempty"`
+}
+
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch  `json:"matches,omitempty"`
+	Filters     []HTTPRouteFilter `json:"filters,omitempty"`
+	BackendRefs []HTTPBackendRef  `json:"backendRefs,omitempty"`
+}
+
+type PathMatchType string
+
+const (
+	PathMatchExact             PathMatchType = "Exact"
+	PathMatchPathPrefix        PathMatchType = "PathPrefix"
+	PathMatchRegularExpression PathMatchType = "RegularExpression"
+)
+
+type HTTPPathMatch struct {
+	Type  *PathMatchType `json:"type,omitempty"`
+	Value *string        `json:"value,omitempty"`
+}
+
+type HeaderMatchType string
+
+const (
+	HeaderMatchExact             HeaderMatchType = "Exact"
+	HeaderMatchRegularExpression HeaderMatchType = "RegularExpression"
+)
+
+type HTTPHeaderName string
+
+type HTTPHeaderMatch struct {
+	Type  *HeaderMatchType `json:"type,omitempty"`
+	Name  HTTPHeaderName   `json:"name"`
+	Value string           `json:"value"`
+}
+
+type HTTPMethod string
+
+type HTTPRouteMatch struct {
+	Path    *HTTPPathMatch    `json:"path,omitempty"`
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+	Method  *HTTPMethod       `json:"method,omitempty"`
+}
+
+type HTTPRouteFilterType string
+
+const (
+	HTTPRouteFilterRequestHeaderModifier  HTTPRouteFilterType = "RequestHeaderModifier"
+	HTTPRouteFilterResponseHeaderModifier HTTPRouteFilterType = "ResponseHeaderModifier"
+	HTTPRouteFilterRequestRedirect        HTTPRouteFilterType = "RequestRedirect"
+	HTTPRouteFilterURLRewrite             HTTPRouteFilterType = "URLRewrite"
+)
+
+type HTTPRouteFilter struct {
+	Type                   HTTPRouteFilterType        `json:"type"`
+	RequestHeaderModifier  *HTTPHeaderFilter          `json:"requestHeaderModifier,omitempty"`
+	ResponseHeaderModifier *HTTPHeaderFilter          `json:"responseHeaderModifier,omitempty"`
+	RequestRedirect        *HTTPRequestRedirectFilter `json:"requestRedirect,omitempty"`
+	URLRewrite             *HTTPURLRewriteFilter      `json:"urlRewrite,omitempty"`
+}
+
+type HTTPHeader struct {
+	Name  HTTPHeaderName `json:"name"`
+	Value string         `json:"value"`
+}
+
+type HTTPHeaderFilter struct {
+	Set    []HTTPHeader `json:"set,omitempty"`
+	Add    []HTTPHeader `json:"add,omitempty"`
+	Remove []string     `json:"remove,omitempty"`
+}
+
+type HTTPPathModifierType string
+
+const (
+	FullPathHTTPPathModifier    HTTPPathModifierType = "ReplaceFullPath"
+	PrefixMatchHTTPPathModifier HTTPPathModifierType = "ReplacePrefixMatch"
+)
+
+type HTTPPathModifier struct {
+	Type               HTTPPathModifierType `json:"type"`
+	ReplaceFullPath    *string              `json:"replaceFullPath,omitempty"`
+	ReplacePrefixMatch *string              `json:"replacePrefixMatch,omitempty"`
+}
+
+type HTTPRequestRedirectFilter struct {
+	Scheme     *string           `json:"scheme,omitempty"`
+	Hostname   *PreciseHostname  `json:"hostname,omitempty"`
+	Path       *HTTPPathModifier `json:"path,omitempty"`
+	Port       *PortNumber       `json:"port,omitempty"`
+	StatusCode *int              `json:"statusCode,omitempty"`
+}
+
+type PreciseHostname string
+
+type HTTPURLRewriteFilter struct {
+	Hostname *PreciseHostname  `json:"hostname,omitempty"`
+	Path     *HTTPPathModifier `json:"path,omitempty"`
+}
+
+type BackendObjectReference struct {
+	Group     *Group      `json:"group,omitempty"`
+	Kind      *Kind       `json:"kind,omitempty"`
+	Name      ObjectName  `json:"name"`
+	Namespace *Namespace  `json:"namespace,omitempty"`
+	Port      *PortNumber `json:"port,omitempty"`
+}
+
+type BackendRef struct {
+	BackendObjectReference `json:",inline"`
+	Weight                 *int32 `json:"weight,omitempty"`
+}
+
+type HTTPBackendRef struct {
+	BackendRef `json:",inline"`
+	Filters    []HTTPRouteFilter `json:"filters,omitempty"`
+}
+
+type RouteParentStatus struct {
+	ParentRef      ParentReference    `json:"parentRef"`
+	ControllerName GatewayController  `json:"controllerName"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type RouteStatus struct {
+	Parents []RouteParentStatus `json:"parents"`
+}
+
+type HTTPRouteStatus struct {
+	RouteStatus `json:",inline"`
+}
+
+// Condition types and reasons used in status.
+
+const (
+	GatewayClassConditionStatusAccepted = "Accepted"
+
+	GatewayConditionAccepted   = "Accepted"
+	GatewayConditionProgrammed = "Programmed"
+
+	ListenerConditionAccepted     = "Accepted"
+	ListenerConditionProgrammed   = "Programmed"
+	ListenerConditionResolvedRefs = "ResolvedRefs"
+
+	RouteConditionAccepted     = "Accepted"
+	RouteConditionResolvedRefs = "ResolvedRefs"
+
+	GatewayReasonAccepted                 = "Accepted"
+	GatewayReasonProgrammed               = "Programmed"
+	GatewayReasonInvalid                  = "Invalid"
+	GatewayReasonAddressNotAssigned       = "AddressNotAssigned"
+	GatewayReasonPending                  = "Pending"
+	ListenerReasonInvalidCertificateRef   = "InvalidCertificateRef"
+	ListenerReasonUnsupportedProtocol     = "UnsupportedProtocol"
+	RouteReasonAccepted                   = "Accepted"
+	RouteReasonNotAllowedByListeners      = "NotAllowedByListeners"
+	RouteReasonNoMatchingParent           = "NoMatchingParent"
+	RouteReasonNoMatchingListenerHostname = "NoMatchingListenerHostname"
+	RouteReasonResolvedRefs               = "ResolvedRefs"
+	RouteReasonBackendNotFound            = "BackendNotFound"
+	RouteReasonRefNotPermitted            = "RefNotPermitted"
+	RouteReasonInvalidKind                = "InvalidKind"
+	RouteReasonUnsupportedValue           = "UnsupportedValue"
+)
@@ -0,0 +1,38 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+func (in *TLSRouteStatus) DeepCopy() *TLSRouteStatus {
+	if in == nil {
+		return nil
+	}
+	return &TLSRouteStatus{RouteStatus: *in.RouteStatus.DeepCopy()}
+}
+
+func (in *TCPRouteStatus) DeepCopy() *TCPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	return &TCPRouteStatus{RouteStatus: *in.RouteStatus.DeepCopy()}
+}
+
+func (in *UDPRouteStatus) DeepCopy() *UDPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	return &UDPRouteStatus{RouteStatus: *in.RouteStatus.DeepCopy()}
+}
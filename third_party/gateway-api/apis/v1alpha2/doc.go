@@ -0,0 +1,28 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha2 contains the subset of the gateway.networking.k8s.io/v1alpha2 API types
+// consumed by AKO, i.e. TLSRoute, TCPRoute and UDPRoute. The common types are shared with
+// the v1 package, as done upstream.
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+)
+
+var SchemeGroupVersion = schema.GroupVersion{Group: gatewayv1.GroupName, Version: "v1alpha2"}
@@ -0,0 +1,93 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/third_party/gateway-api/apis/v1"
+)
+
+// TLSRoute
+
+type TLSRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TLSRouteSpec   `json:"spec"`
+	Status TLSRouteStatus `json:"status,omitempty"`
+}
+
+type TLSRouteSpec struct {
+	gatewayv1.CommonRouteSpec `json:",inline"`
+	Hostnames                 []gatewayv1.Hostname `json:"hostnames,omitempty"`
+	Rules                     []TLSRouteRule       `json:"rules"`
+}
+
+type TLSRouteRule struct {
+	BackendRefs []gatewayv1.BackendRef `json:"backendRefs,omitempty"`
+}
+
+type TLSRouteStatus struct {
+	gatewayv1.RouteStatus `json:",inline"`
+}
+
+// TCPRoute
+
+type TCPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TCPRouteSpec   `json:"spec"`
+	Status TCPRouteStatus `json:"status,omitempty"`
+}
+
+type TCPRouteSpec struct {
+	gatewayv1.CommonRouteSpec `json:",inline"`
+	Rules                     []TCPRouteRule `json:"rules"`
+}
+
+type TCPRouteRule struct {
+	BackendRefs []gatewayv1.BackendRef `json:"backendRefs,omitempty"`
+}
+
+type TCPRouteStatus struct {
+	gatewayv1.RouteStatus `json:",inline"`
+}
+
+// UDPRoute
+
+type UDPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UDPRouteSpec   `json:"spec"`
+	Status UDPRouteStatus `json:"status,omitempty"`
+}
+
+type UDPRouteSpec struct {
+	gatewayv1.CommonRouteSpec `json:",inline"`
+	Rules                     []UDPRouteRule `json:"rules"`
+}
+
+type UDPRouteRule struct {
+	BackendRefs []gatewayv1.BackendRef `json:"backendRefs,omitempty"`
+}
+
+type UDPRouteStatus struct {
+	gatewayv1.RouteStatus `json:",inline"`
+}